@@ -0,0 +1,294 @@
+// Package arena compares bots by playing them against each other over many seeded
+// deals: a heads-up round robin between every pair and, optionally, a ring game with
+// everyone at one table.
+//
+// Stacks are reset before every hand, so each hand is an independent sample and win
+// rates are reported in big blinds per 100 hands with a 95% confidence interval. In
+// duplicate mode every deck is replayed with the players rotated through the seats, so
+// each player gets every set of cards in every position and card luck cancels out.
+package arena
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/dangogh/GoPoker/deck"
+	"github.com/dangogh/GoPoker/game"
+)
+
+// Entrant is a bot taking part. New builds a fresh player for each match, so bots that
+// learn about their opponents start every match from scratch.
+type Entrant struct {
+	Name string
+	New  func(seed int64) game.Player
+}
+
+// Config describes a competition.
+type Config struct {
+	Entrants []Entrant
+	// Deals is the number of decks dealt to each heads-up pairing; 0 skips the round robin.
+	Deals int
+	// RingDeals is the number of decks dealt to the ring game; 0 skips it. The ring game
+	// seats every entrant, so it needs 3 to game.MaxSeats of them.
+	RingDeals int
+	// Duplicate replays each deck once per seat rotation.
+	Duplicate bool
+	// Stack is every player's stack at the start of each hand; 0 means 100 big blinds.
+	Stack int
+	Game  game.Config
+	Seed  int64
+}
+
+// Matchup is the heads-up result between two entrants, from A's point of view.
+type Matchup struct {
+	A, B  string
+	Hands int
+	BB100 float64 `json:"bb_per_100"`
+	CI95  float64 `json:"ci95"`
+	// Wins, Losses and Draws count deals (duplicate sets in duplicate mode) by who came out ahead.
+	Wins, Losses, Draws int
+}
+
+// Standing is one entrant's overall result.
+type Standing struct {
+	Name  string
+	Hands int
+	Net   int     // chips won
+	BB100 float64 `json:"bb_per_100"`
+	CI95  float64 `json:"ci95"`
+	// Elo is the rating fitted to the heads-up results, with each deal weighted by the
+	// chips that changed hands; 0 for ring standings.
+	Elo float64 `json:"elo,omitempty"`
+}
+
+// Report holds the results. Ladder ranks the heads-up results by Elo; Ring ranks the
+// ring game by win rate.
+type Report struct {
+	Ladder   []Standing `json:"ladder,omitempty"`
+	Matchups []Matchup  `json:"matchups,omitempty"`
+	Ring     []Standing `json:"ring,omitempty"`
+}
+
+// Run plays the competition.
+func Run(cfg Config) (*Report, error) {
+	if err := validate(&cfg); err != nil {
+		return nil, err
+	}
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	rep := &Report{}
+
+	if cfg.Deals > 0 {
+		n := len(cfg.Entrants)
+		samples := make([][]float64, n) // per-deal results of each entrant, in big blinds
+		nets, hands := make([]int, n), make([]int, n)
+		var pairs []pairing
+		for a := 0; a < n; a++ {
+			for b := a + 1; b < n; b++ {
+				res, err := play(cfg, []int{a, b}, cfg.Deals, rng)
+				if err != nil {
+					return nil, fmt.Errorf("%s vs %s: %w", cfg.Entrants[a].Name, cfg.Entrants[b].Name, err)
+				}
+				m := Matchup{A: cfg.Entrants[a].Name, B: cfg.Entrants[b].Name, Hands: res.hands}
+				m.BB100, m.CI95 = bb100(res.samples[0])
+				won, lost := 0.0, 0.0
+				for _, x := range res.samples[0] {
+					switch {
+					case x > 0:
+						m.Wins++
+						won += x
+					case x < 0:
+						m.Losses++
+						lost -= x
+					default:
+						m.Draws++
+					}
+				}
+				pairs = append(pairs, newPairing(a, b, m, won, lost))
+				rep.Matchups = append(rep.Matchups, m)
+				for k, e := range []int{a, b} {
+					samples[e] = append(samples[e], res.samples[k]...)
+					nets[e] += res.net[k]
+					hands[e] += res.hands
+				}
+			}
+		}
+		elo := fitElo(n, pairs)
+		for i, e := range cfg.Entrants {
+			s := Standing{Name: e.Name, Hands: hands[i], Net: nets[i], Elo: elo[i]}
+			s.BB100, s.CI95 = bb100(samples[i])
+			rep.Ladder = append(rep.Ladder, s)
+		}
+		sort.SliceStable(rep.Ladder, func(i, j int) bool { return rep.Ladder[i].Elo > rep.Ladder[j].Elo })
+	}
+
+	if cfg.RingDeals > 0 {
+		all := make([]int, len(cfg.Entrants))
+		for i := range all {
+			all[i] = i
+		}
+		res, err := play(cfg, all, cfg.RingDeals, rng)
+		if err != nil {
+			return nil, fmt.Errorf("ring game: %w", err)
+		}
+		for i, e := range cfg.Entrants {
+			s := Standing{Name: e.Name, Hands: res.hands, Net: res.net[i]}
+			s.BB100, s.CI95 = bb100(res.samples[i])
+			rep.Ring = append(rep.Ring, s)
+		}
+		sort.SliceStable(rep.Ring, func(i, j int) bool { return rep.Ring[i].BB100 > rep.Ring[j].BB100 })
+	}
+	return rep, nil
+}
+
+func validate(cfg *Config) error {
+	if len(cfg.Entrants) < 2 {
+		return fmt.Errorf("at least two entrants are required")
+	}
+	seen := map[string]bool{}
+	for _, e := range cfg.Entrants {
+		if e.Name == "" || e.New == nil {
+			return fmt.Errorf("entrants need a name and a constructor")
+		}
+		if seen[e.Name] {
+			return fmt.Errorf("duplicate entrant %q", e.Name)
+		}
+		seen[e.Name] = true
+	}
+	if cfg.Deals < 0 || cfg.RingDeals < 0 {
+		return fmt.Errorf("deals must be >= 0")
+	}
+	if cfg.Deals == 0 && cfg.RingDeals == 0 {
+		return fmt.Errorf("nothing to play: set deals or ring deals")
+	}
+	if cfg.RingDeals > 0 && (len(cfg.Entrants) < 3 || len(cfg.Entrants) > game.MaxSeats) {
+		return fmt.Errorf("the ring game needs 3 to %d entrants, got %d", game.MaxSeats, len(cfg.Entrants))
+	}
+	if cfg.Game.BigBlind <= 0 {
+		return fmt.Errorf("a big blind is required to measure win rates")
+	}
+	if cfg.Stack == 0 {
+		cfg.Stack = 100 * cfg.Game.BigBlind
+	}
+	if cfg.Stack < 0 {
+		return fmt.Errorf("stack must be > 0")
+	}
+	return nil
+}
+
+// pairing tallies one heads-up matchup for the rating fit; draws count half to each side.
+type pairing struct {
+	a, b         int
+	aWins, bWins float64
+}
+
+// newPairing splits the decided deals of a matchup in proportion to the chips each side
+// won in them. Counting deals alone would reward a bot that wins many small pots and
+// loses a few big ones.
+func newPairing(a, b int, m Matchup, won, lost float64) pairing {
+	p := pairing{a: a, b: b, aWins: float64(m.Draws) / 2, bWins: float64(m.Draws) / 2}
+	if decided := float64(m.Wins + m.Losses); decided > 0 {
+		p.aWins += decided * won / (won + lost)
+		p.bWins += decided * lost / (won + lost)
+	}
+	return p
+}
+
+// matchResult is what play returns, indexed like the entrants it was given.
+type matchResult struct {
+	hands   int
+	net     []int
+	samples [][]float64 // one result per deal, in big blinds per hand
+	decks   []DeckResult
+}
+
+// play deals the given number of decks to the entrants. Each deck is played once per
+// seat rotation in duplicate mode; otherwise once, with the rotation moving on every
+// deal so seats even out.
+func play(cfg Config, entrants []int, deals int, rng *rand.Rand) (*matchResult, error) {
+	n := len(entrants)
+	players := make([]game.Player, n)
+	for i, e := range entrants {
+		players[i] = cfg.Entrants[e].New(rng.Int63())
+	}
+	res := &matchResult{net: make([]int, n), samples: make([][]float64, n)}
+	seats := make([]*game.Seat, n)
+	for i := range seats {
+		seats[i] = &game.Seat{}
+	}
+	bb := float64(cfg.Game.BigBlind)
+
+	for d := 0; d < deals; d++ {
+		full := deck.NewDeck()
+		full.ShuffleWith(rng)
+		order := full.Cards()
+		rotations := []int{d % n}
+		if cfg.Duplicate {
+			rotations = rotations[:0]
+			for r := 0; r < n; r++ {
+				rotations = append(rotations, r)
+			}
+		}
+		dr := DeckResult{Deck: d + 1, Cards: order, Net: make([]int, n)}
+		for _, r := range rotations {
+			// entrant k sits in seat (k+r)%n, so over all rotations each one holds every
+			// seat's cards from every position
+			for k := range entrants {
+				e := cfg.Entrants[entrants[k]]
+				*seats[(k+r)%n] = game.Seat{Name: e.Name, Stack: cfg.Stack, Player: players[k]}
+			}
+			hr, err := game.Play(deck.FromCards(order), seats, 0, cfg.Game)
+			if err != nil {
+				return nil, fmt.Errorf("deal %d: %w", d+1, err)
+			}
+			byEntrant := make([]int, n)
+			for k := range entrants {
+				byEntrant[k] = hr.Net[(k+r)%n]
+				dr.Net[k] += byEntrant[k]
+			}
+			dr.Rotations = append(dr.Rotations, byEntrant)
+			res.hands++
+		}
+		for k := range entrants {
+			res.net[k] += dr.Net[k]
+			res.samples[k] = append(res.samples[k], float64(dr.Net[k])/bb/float64(len(rotations)))
+		}
+		res.decks = append(res.decks, dr)
+	}
+	return res, nil
+}
+
+// WriteText prints the ladder, the head-to-head results and the ring game as tables.
+func (r *Report) WriteText(w io.Writer) error {
+	width := 3
+	for _, s := range append(r.Ladder, r.Ring...) {
+		width = max(width, len(s.Name))
+	}
+	var b strings.Builder
+	if len(r.Ladder) > 0 {
+		b.WriteString("Heads-up ladder\n")
+		fmt.Fprintf(&b, "%2s  %-*s  %6s  %8s  %7s  %7s\n", "#", width, "Bot", "Elo", "bb/100", "±95%", "Hands")
+		for i, s := range r.Ladder {
+			fmt.Fprintf(&b, "%2d  %-*s  %6.0f  %+8.1f  %7.1f  %7d\n", i+1, width, s.Name, s.Elo, s.BB100, s.CI95, s.Hands)
+		}
+		b.WriteString("\nHead to head\n")
+		for _, m := range r.Matchups {
+			fmt.Fprintf(&b, "%*s vs %-*s  %+8.1f ± %.1f bb/100  W-L-D %d-%d-%d over %d hands\n",
+				width, m.A, width, m.B, m.BB100, m.CI95, m.Wins, m.Losses, m.Draws, m.Hands)
+		}
+	}
+	if len(r.Ring) > 0 {
+		if len(r.Ladder) > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("Ring game\n")
+		fmt.Fprintf(&b, "%2s  %-*s  %8s  %7s  %8s  %7s\n", "#", width, "Bot", "bb/100", "±95%", "Net", "Hands")
+		for i, s := range r.Ring {
+			fmt.Fprintf(&b, "%2d  %-*s  %+8.1f  %7.1f  %8d  %7d\n", i+1, width, s.Name, s.BB100, s.CI95, s.Net, s.Hands)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,109 @@
+package arena
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/bot"
+	"github.com/dangogh/GoPoker/game"
+)
+
+func entrant(name, kind string) Entrant {
+	return Entrant{Name: name, New: func(seed int64) game.Player {
+		p, err := bot.New(kind, seed)
+		if err != nil {
+			panic(err)
+		}
+		return p
+	}}
+}
+
+var blinds = game.Config{SmallBlind: 1, BigBlind: 2}
+
+// Two copies of a deterministic bot mirror each other exactly in duplicate mode.
+func TestDuplicateCancelsLuck(t *testing.T) {
+	rep, err := Run(Config{
+		Entrants:  []Entrant{entrant("A", "basic"), entrant("B", "basic")},
+		Deals:     200,
+		Duplicate: true,
+		Game:      blinds,
+		Seed:      1,
+	})
+	require.NoError(t, err)
+	require.Len(t, rep.Matchups, 1)
+	m := rep.Matchups[0]
+	assert.Equal(t, 400, m.Hands)
+	assert.Zero(t, m.BB100)
+	assert.Zero(t, m.CI95)
+	assert.Equal(t, 200, m.Draws)
+	assert.InDelta(t, rep.Ladder[0].Elo, rep.Ladder[1].Elo, 1e-6)
+
+	// without duplicate the same bots show card luck
+	rep, err = Run(Config{Entrants: []Entrant{entrant("A", "basic"), entrant("B", "basic")}, Deals: 200, Game: blinds, Seed: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 200, rep.Matchups[0].Hands)
+	assert.Positive(t, rep.Matchups[0].CI95)
+}
+
+func TestRoundRobinRanksBots(t *testing.T) {
+	cfg := Config{
+		Entrants:  []Entrant{entrant("random", "random"), entrant("basic", "basic"), entrant("tp", "tp")},
+		Deals:     300,
+		RingDeals: 100,
+		Duplicate: true,
+		Game:      blinds,
+		Seed:      7,
+	}
+	rep, err := Run(cfg)
+	require.NoError(t, err)
+
+	require.Len(t, rep.Matchups, 3)
+	assert.Equal(t, "random", rep.Ladder[2].Name, "the random bot finishes last")
+	assert.Greater(t, rep.Ladder[0].Elo, rep.Ladder[2].Elo)
+	for _, m := range rep.Matchups {
+		assert.Equal(t, 300, m.Wins+m.Losses+m.Draws)
+	}
+
+	require.Len(t, rep.Ring, 3)
+	net := 0
+	for _, s := range rep.Ring {
+		assert.Equal(t, 300, s.Hands)
+		net += s.Net
+	}
+	assert.Zero(t, net, "chips are conserved")
+	assert.Less(t, rep.Ring[2].BB100, rep.Ring[0].BB100)
+
+	again, err := Run(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, rep, again, "the seed replays the competition")
+}
+
+func TestRunErrors(t *testing.T) {
+	two := []Entrant{entrant("A", "basic"), entrant("B", "basic")}
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"one entrant", Config{Entrants: two[:1], Deals: 1, Game: blinds}, "at least two"},
+		{"same name", Config{Entrants: []Entrant{two[0], two[0]}, Deals: 1, Game: blinds}, "duplicate entrant"},
+		{"nothing to play", Config{Entrants: two, Game: blinds}, "nothing to play"},
+		{"small ring", Config{Entrants: two, RingDeals: 1, Game: blinds}, "ring game needs"},
+		{"no blind", Config{Entrants: two, Deals: 1}, "big blind"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Run(tc.cfg)
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+}
+
+func TestNewPairingWeighsChips(t *testing.T) {
+	// A wins 6 small deals for 6 big blinds, B wins 2 for 18 and 2 are even
+	p := newPairing(0, 1, Matchup{Wins: 6, Losses: 2, Draws: 2}, 6, 18)
+	assert.InDelta(t, 1+8*6.0/24, p.aWins, 1e-9)
+	assert.InDelta(t, 1+8*18.0/24, p.bWins, 1e-9)
+}
@@ -0,0 +1,114 @@
+package arena
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/game"
+)
+
+// DeckResult is one shuffled deck played once per seat rotation. In rotation r entrant
+// k sits in seat (k+r) mod n, with the button in seat 0.
+type DeckResult struct {
+	Deck  int          `json:"deck"` // 1-based
+	Cards []cards.Card `json:"-"`    // the deck in dealing order
+	// Net is each entrant's chip result summed over the rotations. Everyone played the
+	// same cards from the same seats, so it measures decisions rather than luck: heads-up
+	// it is the differential between the two, and in a ring it is the edge over the field.
+	Net []int `json:"net"`
+	// Rotations[r][k] is entrant k's result in rotation r.
+	Rotations [][]int `json:"rotations"`
+}
+
+// DuplicateReport is the result of Duplicate.
+type DuplicateReport struct {
+	Entrants []string     `json:"entrants"`
+	Decks    []DeckResult `json:"decks"`
+	// Totals aggregates every deck; the confidence interval treats each deck as one
+	// sample.
+	Totals []Standing `json:"totals"`
+}
+
+// Duplicate deals cfg.Deals decks to all the entrants at one table and replays each
+// deck with the players rotated through every seat. Config.Duplicate and RingDeals are
+// ignored; 2 to game.MaxSeats entrants may play.
+func Duplicate(cfg Config) (*DuplicateReport, error) {
+	if cfg.Deals <= 0 {
+		return nil, fmt.Errorf("deals must be > 0")
+	}
+	cfg.Duplicate, cfg.RingDeals = true, 0
+	if err := validate(&cfg); err != nil {
+		return nil, err
+	}
+	if len(cfg.Entrants) > game.MaxSeats {
+		return nil, fmt.Errorf("at most %d entrants fit at one table", game.MaxSeats)
+	}
+	all := make([]int, len(cfg.Entrants))
+	for i := range all {
+		all[i] = i
+	}
+	res, err := play(cfg, all, cfg.Deals, rand.New(rand.NewSource(cfg.Seed)))
+	if err != nil {
+		return nil, err
+	}
+	rep := &DuplicateReport{Decks: res.decks}
+	for i, e := range cfg.Entrants {
+		rep.Entrants = append(rep.Entrants, e.Name)
+		s := Standing{Name: e.Name, Hands: res.hands, Net: res.net[i]}
+		s.BB100, s.CI95 = bb100(res.samples[i])
+		rep.Totals = append(rep.Totals, s)
+	}
+	return rep, nil
+}
+
+// WriteText lists each deck's differentials, then the totals. With perDeck false only
+// the totals are printed.
+func (r *DuplicateReport) WriteText(w io.Writer, perDeck bool) error {
+	width := 6
+	for _, name := range r.Entrants {
+		width = max(width, len(name))
+	}
+	var b strings.Builder
+	if perDeck {
+		fmt.Fprintf(&b, "%5s  %s", "Deck", pad("First cards", firstCardsWidth))
+		for _, name := range r.Entrants {
+			fmt.Fprintf(&b, "  %*s", width, name)
+		}
+		b.WriteString("\n")
+		for _, d := range r.Decks {
+			fmt.Fprintf(&b, "%5d  %s", d.Deck, pad(cardList(d.Cards, 5), firstCardsWidth))
+			for _, net := range d.Net {
+				fmt.Fprintf(&b, "  %+*d", width, net)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "%-*s  %8s  %8s  %7s  %6s\n", width, "Bot", "Net", "bb/100", "±95%", "Hands")
+	for _, s := range r.Totals {
+		fmt.Fprintf(&b, "%-*s  %+8d  %+8.1f  %7.1f  %6d\n", width, s.Name, s.Net, s.BB100, s.CI95, s.Hands)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// firstCardsWidth fits five cards such as "10♣ 10♦ 10♥ 10♠ 9♣".
+const firstCardsWidth = 19
+
+// cardList prints the first n cards separated by spaces.
+func cardList(cs []cards.Card, n int) string {
+	names := make([]string, 0, n)
+	for _, c := range cs[:min(n, len(cs))] {
+		names = append(names, c.String())
+	}
+	return strings.Join(names, " ")
+}
+
+// pad left-aligns s in width columns; suit symbols take several bytes but one column.
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", max(0, width-utf8.RuneCountInString(s)))
+}
@@ -0,0 +1,83 @@
+package arena
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicate(t *testing.T) {
+	cfg := Config{
+		Entrants: []Entrant{entrant("basic", "basic"), entrant("tp", "tp"), entrant("lag", "lag")},
+		Deals:    20,
+		Game:     blinds,
+		Seed:     4,
+	}
+	rep, err := Duplicate(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"basic", "tp", "lag"}, rep.Entrants)
+	require.Len(t, rep.Decks, 20)
+
+	total := make([]int, 3)
+	for i, d := range rep.Decks {
+		assert.Equal(t, i+1, d.Deck)
+		assert.Len(t, d.Cards, 52)
+		require.Len(t, d.Rotations, 3, "one replay per seat rotation")
+		sum := 0
+		for k := range d.Net {
+			byRotation := 0
+			for _, r := range d.Rotations {
+				byRotation += r[k]
+			}
+			assert.Equal(t, d.Net[k], byRotation)
+			sum += d.Net[k]
+			total[k] += d.Net[k]
+		}
+		assert.Zero(t, sum, "differentials of a deck sum to zero")
+	}
+	for k, s := range rep.Totals {
+		assert.Equal(t, total[k], s.Net)
+		assert.Equal(t, 60, s.Hands)
+	}
+
+	again, err := Duplicate(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, rep, again)
+
+	var buf bytes.Buffer
+	require.NoError(t, rep.WriteText(&buf, true))
+	assert.Contains(t, buf.String(), "First cards")
+	assert.Contains(t, buf.String(), "   20  ")
+	buf.Reset()
+	require.NoError(t, rep.WriteText(&buf, false))
+	assert.NotContains(t, buf.String(), "First cards")
+	assert.Contains(t, buf.String(), "bb/100")
+}
+
+// Identical deterministic strategies have no differential on any deck.
+func TestDuplicateMirrorsIdenticalBots(t *testing.T) {
+	rep, err := Duplicate(Config{
+		Entrants: []Entrant{entrant("A", "basic"), entrant("B", "basic"), entrant("C", "basic")},
+		Deals:    30,
+		Game:     blinds,
+		Seed:     8,
+	})
+	require.NoError(t, err)
+	for _, d := range rep.Decks {
+		assert.Equal(t, []int{0, 0, 0}, d.Net, "deck %d", d.Deck)
+	}
+}
+
+func TestDuplicateErrors(t *testing.T) {
+	_, err := Duplicate(Config{Entrants: []Entrant{entrant("A", "basic"), entrant("B", "basic")}, Game: blinds})
+	assert.ErrorContains(t, err, "deals")
+
+	many := make([]Entrant, 9)
+	for i := range many {
+		many[i] = entrant(string(rune('A'+i)), "basic")
+	}
+	_, err = Duplicate(Config{Entrants: many, Deals: 1, Game: blinds})
+	assert.ErrorContains(t, err, "at most")
+}
@@ -0,0 +1,41 @@
+package arena
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBB100(t *testing.T) {
+	rate, ci := bb100(nil)
+	assert.Zero(t, rate)
+	assert.Zero(t, ci)
+
+	rate, ci = bb100([]float64{2})
+	assert.Equal(t, 200.0, rate)
+	assert.Zero(t, ci)
+
+	// mean 0.5, sample sd 1.2910, n 4
+	rate, ci = bb100([]float64{-1, 0, 1, 2})
+	assert.InDelta(t, 50, rate, 1e-9)
+	assert.InDelta(t, 100*1.96*math.Sqrt(5.0/3)/2, ci, 1e-9)
+}
+
+func TestFitElo(t *testing.T) {
+	even := fitElo(3, []pairing{{0, 1, 5, 5}, {0, 2, 5, 5}, {1, 2, 5, 5}})
+	for _, r := range even {
+		assert.InDelta(t, EloBase, r, 1e-6)
+	}
+
+	// with the prior, 10-1 becomes 10.5-1.5: odds of 7 to 1
+	two := fitElo(2, []pairing{{0, 1, 10, 1}})
+	assert.InDelta(t, 400*math.Log10(7), two[0]-two[1], 1e-6)
+	assert.InDelta(t, 2*EloBase, two[0]+two[1], 1e-6)
+
+	// ratings are transitive through a common opponent and stay finite when unbeaten
+	chain := fitElo(3, []pairing{{0, 1, 20, 0}, {1, 2, 20, 0}})
+	assert.Greater(t, chain[0], chain[1])
+	assert.Greater(t, chain[1], chain[2])
+	assert.False(t, math.IsInf(chain[0], 0))
+}
@@ -0,0 +1,51 @@
+package bot
+
+import (
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/game"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// Basic draws with hand.RecommendDiscards and bets by made-hand strength. It never
+// bluffs, which keeps it predictable enough to learn against.
+type Basic struct{}
+
+func (Basic) Discard(v game.View) ([]int, error) { return recommended(v), nil }
+
+func (Basic) Act(v game.View) (game.Action, error) {
+	e := hand.Evaluate(hand.Hand{Cards: v.Hand})
+	strong, playable := false, false
+	if v.Round == game.PreDraw {
+		// before the draw only pairs matter: jacks or better is worth raising
+		strong = e.Category > hand.OnePair || (e.Category == hand.OnePair && e.Ranks[0] >= cards.Jack)
+		playable = e.Category >= hand.OnePair || isDraw(v.Hand)
+	} else {
+		st, err := hand.StrengthOf(e)
+		if err != nil {
+			return call(v), nil
+		}
+		strong = st.Beats >= 0.95
+		playable = st.Beats >= 0.75
+	}
+
+	switch {
+	case strong && v.MinRaise > 0:
+		// size to roughly half the pot so strong hands build it without scaring everyone off
+		return game.Action{Kind: game.Raise, Amount: max(v.MinRaise, v.Bets[v.Seat]+v.ToCall+v.Pot/2)}, nil
+	case v.ToCall == 0:
+		return game.Action{Kind: game.Check}, nil
+	case playable && v.ToCall <= max(v.Pot/2, v.BigBlind):
+		return game.Action{Kind: game.Call}, nil
+	case strong:
+		return game.Action{Kind: game.Call}, nil
+	default:
+		return game.Action{Kind: game.Fold}, nil
+	}
+}
+
+// isDraw reports four to a flush or an open straight: a high-card hand that wants
+// exactly one card.
+func isDraw(cs []cards.Card) bool {
+	h := hand.Hand{Cards: cs}
+	return hand.Evaluate(h).Category == hand.HighCard && len(hand.RecommendDiscards(h, 4)) == 1
+}
@@ -0,0 +1,51 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/game"
+)
+
+func TestBasicAct(t *testing.T) {
+	tests := []struct {
+		name  string
+		hand  string
+		round game.Round
+		v     game.View
+		want  game.ActionKind
+	}{
+		{"raises jacks or better", "Jc Jd 7h 4s 2c", game.PreDraw, game.View{ToCall: 2, MinRaise: 4, Pot: 3, BigBlind: 2}, game.Raise},
+		{"calls small pair", "5c 5d Kh 9s 2c", game.PreDraw, game.View{ToCall: 2, MinRaise: 4, Pot: 3, BigBlind: 2}, game.Call},
+		{"calls with four to a flush", "Ah Kh 9h 4h 2c", game.PreDraw, game.View{ToCall: 2, MinRaise: 4, Pot: 3, BigBlind: 2}, game.Call},
+		{"folds junk", "Kc 9d 7h 4s 2c", game.PreDraw, game.View{ToCall: 2, MinRaise: 4, Pot: 3, BigBlind: 2}, game.Fold},
+		{"checks junk when free", "Kc 9d 7h 4s 2c", game.PostDraw, game.View{MinRaise: 2, Pot: 6, BigBlind: 2}, game.Check},
+		{"folds a pair to a big bet", "5c 5d Kh 9s 2c", game.PostDraw, game.View{ToCall: 40, MinRaise: 80, Pot: 50, BigBlind: 2}, game.Fold},
+		{"bets a flush", "Ah Kh 9h 4h 2h", game.PostDraw, game.View{MinRaise: 2, Pot: 6, BigBlind: 2}, game.Raise},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cs, err := cards.ParseList(tc.hand)
+			require.NoError(t, err)
+			v := tc.v
+			v.Hand, v.Round, v.Bets = cs, tc.round, []int{0, 0}
+			a, err := Basic{}.Act(v)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, a.Kind)
+			if a.Kind == game.Raise {
+				assert.GreaterOrEqual(t, a.Amount, v.MinRaise)
+			}
+		})
+	}
+}
+
+func TestBasicDiscard(t *testing.T) {
+	cs, err := cards.ParseList("Ah Kh 9h 4h 2c")
+	require.NoError(t, err)
+	got, err := Basic{}.Discard(game.View{Hand: cs})
+	require.NoError(t, err)
+	assert.Equal(t, []int{4}, got)
+}
@@ -0,0 +1,115 @@
+// Package bot provides computer opponents for five-card draw. Every bot implements
+// game.Player, so the same bots sit at a single hand, a table session, a tournament or
+// a simulation, and tables can mix styles freely.
+//
+// Built-in styles:
+//
+//	basic          draws with hand.RecommendDiscards and bets by made-hand strength
+//	random         picks uniformly among legal actions and discards
+//	tight-passive  plays few hands and rarely raises
+//	loose-aggressive plays many hands, raises often and bluffs folders
+//	equity         simulates the rest of the hand with Monte Carlo and compares its
+//	               winning chances to the price of calling
+//	rules:<file>   follows the rules in a JSON file (see ParseRules)
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dangogh/GoPoker/game"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// Player is the interface all bots implement: one method for betting (folding,
+// calling and bet sizing) and one for the draw.
+type Player = game.Player
+
+// factories builds each named style; seed makes randomized bots reproducible.
+var factories = map[string]func(seed int64) Player{
+	"basic":            func(int64) Player { return Basic{} },
+	"random":           func(seed int64) Player { return NewRandom(seed) },
+	"tight-passive":    func(int64) Player { return NewTightPassive() },
+	"loose-aggressive": func(seed int64) Player { return NewLooseAggressive(seed) },
+	"equity":           func(seed int64) Player { return NewEquity(DefaultEquityTrials, seed) },
+}
+
+// aliases are short names accepted by New.
+var aliases = map[string]string{"tp": "tight-passive", "lag": "loose-aggressive"}
+
+// Kinds lists the built-in styles accepted by New.
+func Kinds() []string {
+	var ks []string
+	for k := range factories {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+	return append(ks, "rules:<file>")
+}
+
+// New builds a bot by style name. "rules:path" loads a rule file.
+func New(kind string, seed int64) (Player, error) {
+	if path, ok := strings.CutPrefix(kind, "rules:"); ok {
+		return LoadRules(path)
+	}
+	if full, ok := aliases[kind]; ok {
+		kind = full
+	}
+	f, ok := factories[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown bot %q (valid options: %s)", kind, strings.Join(Kinds(), ", "))
+	}
+	return f(seed), nil
+}
+
+// opponents counts the other players still in the hand.
+func opponents(v game.View) int {
+	n := 0
+	for i := range v.Dealt {
+		if i != v.Seat && v.Dealt[i] && !v.Folded[i] {
+			n++
+		}
+	}
+	return n
+}
+
+// potOdds is the share of the final pot a call costs: the equity needed to break even.
+func potOdds(v game.View) float64 {
+	if v.ToCall == 0 {
+		return 0
+	}
+	return float64(v.ToCall) / float64(v.Pot+v.ToCall)
+}
+
+// raise returns a raise sized at a fraction of the pot after calling, or a call when
+// raising is not allowed. The engine caps it at the player's stack.
+func raise(v game.View, potFraction float64) game.Action {
+	if v.MinRaise == 0 {
+		return call(v)
+	}
+	to := v.Bets[v.Seat] + v.ToCall + int(potFraction*float64(v.Pot+v.ToCall))
+	return game.Action{Kind: game.Raise, Amount: max(v.MinRaise, to)}
+}
+
+// call calls, or checks when there is nothing to call.
+func call(v game.View) game.Action {
+	if v.ToCall == 0 {
+		return game.Action{Kind: game.Check}
+	}
+	return game.Action{Kind: game.Call}
+}
+
+// fold folds, or checks when that is free.
+func fold(v game.View) game.Action {
+	if v.ToCall == 0 {
+		return game.Action{Kind: game.Check}
+	}
+	return game.Action{Kind: game.Fold}
+}
+
+// recommended is the standard draw used by most bots.
+func recommended(v game.View) []int {
+	h := hand.Hand{Cards: v.Hand}
+	return hand.RecommendDiscards(h, hand.ComputeMaxDiscard(h))
+}
@@ -0,0 +1,62 @@
+package bot
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/game"
+	"github.com/dangogh/GoPoker/table"
+)
+
+func TestNew(t *testing.T) {
+	for _, kind := range []string{"basic", "random", "tight-passive", "tp", "loose-aggressive", "lag", "equity"} {
+		p, err := New(kind, 1)
+		require.NoError(t, err, kind)
+		assert.NotNil(t, p, kind)
+	}
+
+	_, err := New("shark", 1)
+	assert.ErrorContains(t, err, "unknown bot")
+	_, err = New("rules:does-not-exist.json", 1)
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "r.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules": [{"action": "call"}]}`), 0o644))
+	p, err := New("rules:"+path, 1)
+	require.NoError(t, err)
+	assert.IsType(t, &RuleBot{}, p)
+}
+
+// Every style should survive a long session against every other without errors and
+// without creating or losing chips.
+func TestMixedTable(t *testing.T) {
+	tb := table.New(game.Config{SmallBlind: 1, BigBlind: 2}, rand.New(rand.NewSource(3)))
+	kinds := []string{"basic", "random", "tp", "lag", "equity"}
+	for i, k := range kinds {
+		p, err := New(k, int64(i))
+		require.NoError(t, err)
+		_, err = tb.Sit(-1, k, 100, p)
+		require.NoError(t, err)
+	}
+	_, err := tb.Run(60)
+	require.NoError(t, err)
+
+	total := 0
+	for _, s := range tb.Seats() {
+		total += s.Stack
+	}
+	assert.Equal(t, 100*len(kinds), total)
+}
+
+func TestPotOddsAndOpponents(t *testing.T) {
+	v := game.View{Seat: 0, Pot: 30, ToCall: 10,
+		Dealt: []bool{true, true, true, false}, Folded: []bool{false, true, false, false}}
+	assert.InDelta(t, 0.25, potOdds(v), 1e-9)
+	assert.Equal(t, 1, opponents(v))
+	assert.Zero(t, potOdds(game.View{Pot: 30}))
+}
@@ -0,0 +1,261 @@
+package bot
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/deck"
+	"github.com/dangogh/GoPoker/game"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// DefaultEquityTrials is enough for decisions to be stable to a few percent while
+// keeping a hand fast.
+const DefaultEquityTrials = 500
+
+// Equity estimates its share of the pot by dealing out the rest of the hand many times
+// and calls when that share beats the price. Opponents are assumed to draw like
+// hand.RecommendDiscards; after the draw, their hands are sampled from the starting
+// hands that draw as many cards as each one actually did, so a pat opponent is
+// credited with a made hand.
+type Equity struct {
+	trials int
+	rng    *rand.Rand
+}
+
+// NewEquity returns an Equity bot running the given number of trials per decision.
+func NewEquity(trials int, seed int64) *Equity {
+	if trials <= 0 {
+		trials = DefaultEquityTrials
+	}
+	return &Equity{trials: trials, rng: rand.New(rand.NewSource(seed))}
+}
+
+func (q *Equity) Act(v game.View) (game.Action, error) {
+	opp := opponents(v)
+	if opp == 0 {
+		return call(v), nil
+	}
+	eq := q.equity(v, nil, q.trials)
+	// a fair share is 1/(n+1); raise once the hand is well ahead of it
+	switch {
+	case eq >= min(0.9, 1.5/float64(opp+1)):
+		return raise(v, eq), nil
+	case v.ToCall == 0 || eq >= potOdds(v):
+		return call(v), nil
+	}
+	return game.Action{Kind: game.Fold}, nil
+}
+
+// Discard tries a few sensible draws and keeps the one that wins the most. Every
+// candidate respects the draw limit, so the draw scored is the draw played.
+func (q *Equity) Discard(v game.View) ([]int, error) {
+	limit := hand.ComputeMaxDiscard(hand.Hand{Cards: v.Hand})
+	cands := [][]int{recommended(v), {}, lowest(v.Hand, singletons(v.Hand), limit)}
+	best, bestEq := cands[0], -1.0
+	for i, c := range cands {
+		if i > 0 && slices.Equal(c, cands[0]) {
+			continue
+		}
+		if eq := q.equity(v, c, q.trials/2); eq > bestEq {
+			best, bestEq = c, eq
+		}
+	}
+	return best, nil
+}
+
+// equity is the average share of the pot the bot wins against the live opponents.
+// Before the draw the bot draws to discards, or to its recommended discards when
+// discards is nil.
+func (q *Equity) equity(v game.View, discards []int, trials int) float64 {
+	if v.Round == game.PreDraw && discards == nil {
+		discards = recommended(v)
+	}
+	var seats []int
+	for i := range v.Dealt {
+		if i != v.Seat && v.Dealt[i] && !v.Folded[i] {
+			seats = append(seats, i)
+		}
+	}
+	d := deck.NewDeck()
+	d.RemoveCards(v.Hand)
+	unseen, _ := d.Deal(d.Len())
+
+	total := 0.0
+	p := &pool{rng: q.rng}
+	for t := 0; t < max(trials, 1); t++ {
+		p.reset(unseen)
+		mine := v.Hand
+		if v.Round == game.PreDraw {
+			mine = p.draw(v.Hand, discards)
+		}
+		best := hand.Evaluate(hand.Hand{Cards: mine})
+		winners := 1
+		for _, s := range seats {
+			drawn := -1
+			if v.Round == game.PostDraw && s < len(v.Drawn) {
+				drawn = v.Drawn[s]
+			}
+			cs := p.opponent(drawn)
+			if cs == nil {
+				break // a full table can run the deck short; ignore who is left
+			}
+			e := hand.Evaluate(hand.Hand{Cards: cs})
+			switch c := hand.Compare(e, best); {
+			case c > 0:
+				best, winners = e, 0
+			case c == 0 && winners > 0:
+				winners++
+			}
+		}
+		if winners > 0 {
+			total += 1 / float64(winners)
+		}
+	}
+	return total / float64(max(trials, 1))
+}
+
+// pool holds the shuffled unseen cards of one simulated deal.
+type pool struct {
+	rng   *rand.Rand
+	cards []cards.Card
+	next  int
+}
+
+func (p *pool) reset(unseen []cards.Card) {
+	p.cards = append(p.cards[:0], unseen...)
+	p.next = 0
+	p.shuffle()
+}
+
+// shuffle reshuffles the cards not yet dealt.
+func (p *pool) shuffle() {
+	rest := p.cards[p.next:]
+	p.rng.Shuffle(len(rest), func(i, j int) { rest[i], rest[j] = rest[j], rest[i] })
+}
+
+// take deals up to n cards.
+func (p *pool) take(n int) []cards.Card {
+	n = min(n, len(p.cards)-p.next)
+	cs := p.cards[p.next : p.next+n]
+	p.next += n
+	return cs
+}
+
+// draw replaces the discards with new cards, as many as are left.
+func (p *pool) draw(cs []cards.Card, discards []int) []cards.Card {
+	out := append([]cards.Card(nil), cs...)
+	for i, c := range p.take(len(discards)) {
+		out[discards[i]] = c
+	}
+	return out
+}
+
+// opponent deals an opponent's final hand. When drawn is known (>= 0), the starting
+// hand comes from the hands whose recommended draw is that many cards. It returns nil
+// when fewer than five cards are left.
+func (p *pool) opponent(drawn int) []cards.Card {
+	if len(p.cards)-p.next < 5 {
+		return nil
+	}
+	if drawn >= 0 && drawn < len(startsByDraw) {
+		starts := startingHands(drawn)
+		for try := 0; try < 20; try++ {
+			s := starts[p.rng.Intn(len(starts))]
+			if p.claim(s.cards[:]) {
+				return p.draw(s.cards[:], s.discards)
+			}
+		}
+	}
+	start := p.take(5)
+	h := hand.Hand{Cards: start}
+	return p.draw(start, hand.RecommendDiscards(h, hand.ComputeMaxDiscard(h)))
+}
+
+// claim deals exactly cs if they are all still undealt.
+func (p *pool) claim(cs []cards.Card) bool {
+	at := make([]int, 0, len(cs))
+	for _, c := range cs {
+		k := slices.Index(p.cards[p.next:], c)
+		if k < 0 {
+			return false
+		}
+		at = append(at, p.next+k)
+	}
+	for i, k := range at {
+		p.cards[p.next+i], p.cards[k] = p.cards[k], p.cards[p.next+i]
+		// a later card may have been the one just swapped out
+		for j := i + 1; j < len(at); j++ {
+			if at[j] == p.next+i {
+				at[j] = k
+			}
+		}
+	}
+	p.next += len(cs)
+	return true
+}
+
+type startingHand struct {
+	cards    [5]cards.Card
+	discards []int
+}
+
+// startsByDraw samples starting hands by the size of their recommended draw. Standing
+// pat is rare (about one deal in seventy), so the samples are built once and shared.
+var (
+	startsByDraw [5][]startingHand
+	startsOnce   [5]sync.Once
+)
+
+const startsPerDraw = 1000
+
+func startingHands(drawn int) []startingHand {
+	startsOnce[drawn].Do(func() {
+		rng := rand.New(rand.NewSource(int64(drawn) + 1))
+		for len(startsByDraw[drawn]) < startsPerDraw {
+			d := deck.NewDeck()
+			d.ShuffleWith(rng)
+			cs, _ := d.Deal(5)
+			h := hand.Hand{Cards: cs}
+			if disc := hand.RecommendDiscards(h, hand.ComputeMaxDiscard(h)); len(disc) == drawn {
+				startsByDraw[drawn] = append(startsByDraw[drawn], startingHand{cards: [5]cards.Card(cs), discards: disc})
+			}
+		}
+	})
+	return startsByDraw[drawn]
+}
+
+// singletons are the indexes of unpaired cards; with no pair at all, every card but
+// the highest.
+func singletons(cs []cards.Card) []int {
+	count := map[cards.Rank]int{}
+	top := 0
+	for i, c := range cs {
+		count[c.Rank]++
+		if c.Rank > cs[top].Rank {
+			top = i
+		}
+	}
+	paired := len(count) < len(cs)
+	var out []int
+	for i, c := range cs {
+		if count[c.Rank] == 1 && (paired || i != top) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// lowest keeps the n lowest-ranked of the indexes idx, in index order.
+func lowest(cs []cards.Card, idx []int, n int) []int {
+	if len(idx) <= n {
+		return idx
+	}
+	byRank := slices.Clone(idx)
+	slices.SortStableFunc(byRank, func(a, b int) int { return int(cs[a].Rank) - int(cs[b].Rank) })
+	out := byRank[:n]
+	slices.Sort(out)
+	return out
+}
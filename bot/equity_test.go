@@ -0,0 +1,103 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/game"
+)
+
+func headsUp(t *testing.T, h string, round game.Round, drawn int) game.View {
+	t.Helper()
+	cs, err := cards.ParseList(h)
+	require.NoError(t, err)
+	return game.View{Hand: cs, Round: round, Bets: []int{0, 0},
+		Dealt: []bool{true, true}, Folded: []bool{false, false}, Drawn: []int{-1, drawn}}
+}
+
+func TestEquityEstimates(t *testing.T) {
+	q := NewEquity(2000, 1)
+
+	flush := q.equity(headsUp(t, "Ah Kh 9h 4h 2h", game.PostDraw, 3), nil, 2000)
+	assert.Greater(t, flush, 0.95, "a flush crushes a three-card draw")
+
+	// a pat opponent is credited with a made hand, so kings are worth much less
+	vsDrawer := q.equity(headsUp(t, "Kc Kd 9h 4s 2c", game.PostDraw, 3), nil, 2000)
+	vsPat := q.equity(headsUp(t, "Kc Kd 9h 4s 2c", game.PostDraw, 0), nil, 2000)
+	assert.Greater(t, vsDrawer, 0.6)
+	assert.Less(t, vsPat, vsDrawer-0.2)
+
+	junk := q.equity(headsUp(t, "9c 7d 5h 4s 2c", game.PreDraw, -1), nil, 2000)
+	assert.Less(t, junk, 0.35)
+}
+
+func TestEquityAct(t *testing.T) {
+	q := NewEquity(500, 1)
+
+	v := headsUp(t, "Ah Kh 9h 4h 2h", game.PostDraw, 3)
+	v.MinRaise, v.Pot = 2, 10
+	a, err := q.Act(v)
+	require.NoError(t, err)
+	assert.Equal(t, game.Raise, a.Kind)
+
+	v = headsUp(t, "9c 7d 5h 4s 2c", game.PostDraw, 0)
+	v.ToCall, v.MinRaise, v.Pot = 20, 40, 30
+	a, err = q.Act(v)
+	require.NoError(t, err)
+	assert.Equal(t, game.Fold, a.Kind)
+
+	// nobody left to beat: just take the pot
+	v.Folded[1] = true
+	a, err = q.Act(v)
+	require.NoError(t, err)
+	assert.Equal(t, game.Call, a.Kind)
+}
+
+func TestEquityDiscard(t *testing.T) {
+	q := NewEquity(400, 1)
+	d, err := q.Discard(headsUp(t, "Ah Kh 9h 4h 2c", game.PreDraw, -1))
+	require.NoError(t, err)
+	assert.Equal(t, []int{4}, d, "draws to the flush")
+
+	d, err = q.Discard(headsUp(t, "8c 7d 6h 5s 4c", game.PreDraw, -1))
+	require.NoError(t, err)
+	assert.Empty(t, d, "stands pat with a straight")
+
+	// no pair and no ace: the table allows three cards, not the four singletons
+	for _, h := range []string{"Kc Jd 9h 6s 3c", "9c Kd 7h 4s 2c"} {
+		d, err = q.Discard(headsUp(t, h, game.PreDraw, -1))
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(d), 3, h)
+	}
+}
+
+func TestLowest(t *testing.T) {
+	cs, err := cards.ParseList("Kc Jd 9h 6s 3c")
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 3, 4}, lowest(cs, []int{1, 2, 3, 4}, 3))
+	assert.Equal(t, []int{1, 4}, lowest(cs, []int{1, 4}, 3), "already within the limit")
+
+	cs, err = cards.ParseList("9c Kd 7h 4s 2c")
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 3, 4}, lowest(cs, singletons(cs), 3))
+}
+
+func TestSingletons(t *testing.T) {
+	tests := []struct {
+		hand string
+		want []int
+	}{
+		{"Kc Kd 9h 4s 2c", []int{2, 3, 4}},
+		{"Kc Kd 9h 9s 2c", []int{4}},
+		{"9c Kd 7h 4s 2c", []int{0, 2, 3, 4}},
+		{"Kc Kd Kh 9s 9c", nil},
+	}
+	for _, tc := range tests {
+		cs, err := cards.ParseList(tc.hand)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, singletons(cs), tc.hand)
+	}
+}
@@ -0,0 +1,36 @@
+package bot
+
+import (
+	"math/rand"
+
+	"github.com/dangogh/GoPoker/game"
+)
+
+// Random picks uniformly among the legal actions and discards a random number of
+// random cards. It is a baseline: any strategy worth keeping should beat it easily.
+type Random struct {
+	rng *rand.Rand
+}
+
+// NewRandom returns a Random bot; the same seed replays the same choices.
+func NewRandom(seed int64) *Random {
+	return &Random{rng: rand.New(rand.NewSource(seed))}
+}
+
+func (r *Random) Act(v game.View) (game.Action, error) {
+	opts := []game.Action{call(v)}
+	if v.ToCall > 0 {
+		opts = append(opts, game.Action{Kind: game.Fold})
+	}
+	if v.MinRaise > 0 {
+		// anywhere from the minimum raise to all-in
+		allIn := v.Bets[v.Seat] + v.Stack
+		opts = append(opts, game.Action{Kind: game.Raise, Amount: v.MinRaise + r.rng.Intn(max(allIn-v.MinRaise, 0)+1)})
+	}
+	return opts[r.rng.Intn(len(opts))], nil
+}
+
+func (r *Random) Discard(v game.View) ([]int, error) {
+	n := r.rng.Intn(4) // the engine trims it if the hand allows fewer
+	return r.rng.Perm(len(v.Hand))[:n], nil
+}
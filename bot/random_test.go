@@ -0,0 +1,37 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/game"
+)
+
+func TestRandomStaysLegal(t *testing.T) {
+	cs, err := cards.ParseList("Kc 9d 7h 4s 2c")
+	require.NoError(t, err)
+	r := NewRandom(1)
+	seen := map[game.ActionKind]bool{}
+	for i := 0; i < 200; i++ {
+		v := game.View{Hand: cs, ToCall: 2, MinRaise: 4, Stack: 50, Pot: 3, Bets: []int{0, 2}}
+		a, err := r.Act(v)
+		require.NoError(t, err)
+		seen[a.Kind] = true
+		if a.Kind == game.Raise {
+			assert.GreaterOrEqual(t, a.Amount, v.MinRaise)
+			assert.LessOrEqual(t, a.Amount, v.Stack)
+		}
+
+		free, err := r.Act(game.View{Hand: cs, Bets: []int{0, 0}})
+		require.NoError(t, err)
+		assert.Equal(t, game.Check, free.Kind, "never folds or raises when neither is possible")
+
+		d, err := r.Discard(game.View{Hand: cs})
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(d), 3)
+	}
+	assert.Len(t, seen, 3, "fold, call and raise all occur")
+}
@@ -0,0 +1,193 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/game"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// Rule is one line of a rule file. All the conditions that are set must hold for the
+// rule to apply; the first rule that applies decides.
+type Rule struct {
+	// Round is "pre", "post" or empty for both.
+	Round string `json:"round,omitempty"`
+	// MinHand is the weakest category that qualifies, e.g. "One Pair".
+	MinHand string `json:"min_hand,omitempty"`
+	// MinRank is the lowest top rank of the hand, e.g. "J" with MinHand "One Pair"
+	// means jacks or better.
+	MinRank string `json:"min_rank,omitempty"`
+	// MinBeats is the lowest share of five-card hands the hand must beat, 0-1.
+	MinBeats float64 `json:"min_beats,omitempty"`
+	// MaxCall is the largest call, as a fraction of the pot, the rule accepts.
+	MaxCall float64 `json:"max_call,omitempty"`
+	// Action is "fold", "check", "call" or "raise". Folding and checking check when
+	// that is free; calling checks when there is nothing to call.
+	Action string `json:"action"`
+	// Size is the raise as a fraction of the pot after calling; 0 means half the pot.
+	Size float64 `json:"size,omitempty"`
+
+	category hand.Category
+	rank     cards.Rank
+}
+
+// Rules is the content of a rule file, for example:
+//
+//	{
+//	  "draw": "recommend",
+//	  "rules": [
+//	    {"round": "pre", "min_hand": "One Pair", "min_rank": "J", "action": "raise"},
+//	    {"round": "pre", "min_hand": "One Pair", "max_call": 0.5, "action": "call"},
+//	    {"round": "post", "min_beats": 0.9, "action": "raise", "size": 1},
+//	    {"action": "fold"}
+//	  ]
+//	}
+//
+// When no rule applies the bot checks or folds.
+type Rules struct {
+	// Draw is "recommend" (hand.RecommendDiscards, the default), "pat" or "keep-pairs"
+	// (throw every unpaired card).
+	Draw  string `json:"draw,omitempty"`
+	Rules []Rule `json:"rules"`
+}
+
+// RuleBot plays by a Rules file.
+type RuleBot struct {
+	rules Rules
+}
+
+// ParseRules reads and checks a JSON rule file.
+func ParseRules(r io.Reader) (*RuleBot, error) {
+	var rs Rules
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&rs); err != nil {
+		return nil, fmt.Errorf("parsing rules: %w", err)
+	}
+	switch rs.Draw {
+	case "", "recommend", "pat", "keep-pairs":
+	default:
+		return nil, fmt.Errorf("unknown draw %q (valid options: recommend, pat, keep-pairs)", rs.Draw)
+	}
+	for i := range rs.Rules {
+		if err := rs.Rules[i].check(); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+	}
+	return &RuleBot{rules: rs}, nil
+}
+
+// LoadRules reads a rule file from disk.
+func LoadRules(path string) (*RuleBot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	b, err := ParseRules(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return b, nil
+}
+
+// check validates the rule and resolves its names.
+func (r *Rule) check() error {
+	switch r.Round {
+	case "", "pre", "post":
+	default:
+		return fmt.Errorf("unknown round %q (valid options: pre, post)", r.Round)
+	}
+	switch r.Action {
+	case "fold", "check", "call", "raise":
+	default:
+		return fmt.Errorf("unknown action %q (valid options: fold, check, call, raise)", r.Action)
+	}
+	if r.MinHand != "" {
+		c, ok := categoryNamed(r.MinHand)
+		if !ok {
+			return fmt.Errorf("unknown hand %q", r.MinHand)
+		}
+		r.category = c
+	}
+	if r.MinRank != "" {
+		rk, err := cards.ParseRank(r.MinRank)
+		if err != nil {
+			return err
+		}
+		r.rank = rk
+	}
+	if r.MinBeats < 0 || r.MinBeats > 1 {
+		return fmt.Errorf("min_beats must be between 0 and 1")
+	}
+	if r.MaxCall < 0 || r.Size < 0 {
+		return fmt.Errorf("max_call and size must be >= 0")
+	}
+	return nil
+}
+
+func categoryNamed(name string) (hand.Category, bool) {
+	for c := hand.HighCard; c <= hand.StraightFlush; c++ {
+		if strings.EqualFold(c.String(), name) {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// matches reports whether the rule applies to the decision.
+func (r Rule) matches(v game.View, e hand.EvaluatedHand) bool {
+	if (r.Round == "pre" && v.Round != game.PreDraw) || (r.Round == "post" && v.Round != game.PostDraw) {
+		return false
+	}
+	if r.MinHand != "" && e.Category < r.category {
+		return false
+	}
+	// the rank only counts within the minimum category; better hands always qualify
+	if r.MinRank != "" && (r.MinHand == "" || e.Category == r.category) && e.Ranks[0] < r.rank {
+		return false
+	}
+	if r.MinBeats > 0 && beats(v.Hand) < r.MinBeats {
+		return false
+	}
+	if r.MaxCall > 0 && float64(v.ToCall) > r.MaxCall*float64(v.Pot) {
+		return false
+	}
+	return true
+}
+
+func (b *RuleBot) Act(v game.View) (game.Action, error) {
+	e := hand.Evaluate(hand.Hand{Cards: v.Hand})
+	for _, r := range b.rules.Rules {
+		if !r.matches(v, e) {
+			continue
+		}
+		switch r.Action {
+		case "call":
+			return call(v), nil
+		case "raise":
+			size := r.Size
+			if size == 0 {
+				size = 0.5
+			}
+			return raise(v, size), nil
+		}
+		return fold(v), nil
+	}
+	return fold(v), nil
+}
+
+func (b *RuleBot) Discard(v game.View) ([]int, error) {
+	switch b.rules.Draw {
+	case "pat":
+		return nil, nil
+	case "keep-pairs":
+		return singletons(v.Hand), nil
+	}
+	return recommended(v), nil
+}
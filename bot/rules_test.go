@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/game"
+)
+
+const sampleRules = `{
+  "draw": "keep-pairs",
+  "rules": [
+    {"round": "pre", "min_hand": "one pair", "min_rank": "J", "action": "raise"},
+    {"round": "pre", "min_hand": "One Pair", "max_call": 0.5, "action": "call"},
+    {"round": "post", "min_beats": 0.9, "action": "raise", "size": 1},
+    {"round": "post", "min_hand": "One Pair", "action": "call"}
+  ]
+}`
+
+func TestRuleBotAct(t *testing.T) {
+	b, err := ParseRules(strings.NewReader(sampleRules))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		hand  string
+		round game.Round
+		v     game.View
+		want  game.ActionKind
+	}{
+		{"raises jacks", "Jc Jd 7h 4s 2c", game.PreDraw, game.View{ToCall: 2, MinRaise: 4, Pot: 6}, game.Raise},
+		{"raises two pair regardless of rank", "3c 3d 2h 2s 9c", game.PreDraw, game.View{ToCall: 2, MinRaise: 4, Pot: 6}, game.Raise},
+		{"calls a small pair cheaply", "5c 5d 7h 4s 2c", game.PreDraw, game.View{ToCall: 2, MinRaise: 4, Pot: 6}, game.Call},
+		{"folds a small pair to a big bet", "5c 5d 7h 4s 2c", game.PreDraw, game.View{ToCall: 10, MinRaise: 20, Pot: 6}, game.Fold},
+		{"falls through to fold", "Kc 9d 7h 4s 2c", game.PreDraw, game.View{ToCall: 2, MinRaise: 4, Pot: 6}, game.Fold},
+		{"checks instead of folding when free", "Kc 9d 7h 4s 2c", game.PostDraw, game.View{MinRaise: 2, Pot: 6}, game.Check},
+		{"bets two pair", "Kc Kd 7h 7s 2c", game.PostDraw, game.View{MinRaise: 2, Pot: 6}, game.Raise},
+		{"calls with a pair", "5c 5d 7h 4s 2c", game.PostDraw, game.View{ToCall: 30, MinRaise: 60, Pot: 6}, game.Call},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cs, err := cards.ParseList(tc.hand)
+			require.NoError(t, err)
+			v := tc.v
+			v.Hand, v.Round, v.Bets = cs, tc.round, []int{0, 0}
+			a, err := b.Act(v)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, a.Kind)
+		})
+	}
+
+	cs, err := cards.ParseList("5c 5d 7h 4s 2c")
+	require.NoError(t, err)
+	d, err := b.Discard(game.View{Hand: cs})
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 3, 4}, d)
+}
+
+func TestParseRulesErrors(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"bad json", `{`, "parsing rules"},
+		{"unknown field", `{"rules": [{"action": "call", "min_pot": 3}]}`, "unknown field"},
+		{"bad action", `{"rules": [{"action": "shove"}]}`, "rule 1: unknown action"},
+		{"bad round", `{"rules": [{"round": "river", "action": "call"}]}`, "unknown round"},
+		{"bad hand", `{"rules": [{"min_hand": "Two Pairs", "action": "call"}]}`, "unknown hand"},
+		{"bad rank", `{"rules": [{"min_rank": "1", "action": "call"}]}`, "rank"},
+		{"bad beats", `{"rules": [{"min_beats": 2, "action": "call"}]}`, "min_beats"},
+		{"bad draw", `{"draw": "all", "rules": []}`, "unknown draw"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseRules(strings.NewReader(tc.in))
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package bot
+
+import (
+	"math/rand"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/game"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// beats is the share of five-card hands the bot's current hand beats.
+func beats(cs []cards.Card) float64 {
+	st, err := hand.StrengthOf(hand.Evaluate(hand.Hand{Cards: cs}))
+	if err != nil {
+		return 0
+	}
+	return st.Beats
+}
+
+// TightPassive plays only good hands and prefers calling to raising: it raises only
+// the very best hands and folds to pressure without a strong holding.
+type TightPassive struct{}
+
+// NewTightPassive returns a TightPassive bot.
+func NewTightPassive() TightPassive { return TightPassive{} }
+
+func (TightPassive) Discard(v game.View) ([]int, error) { return recommended(v), nil }
+
+func (TightPassive) Act(v game.View) (game.Action, error) {
+	e := hand.Evaluate(hand.Hand{Cards: v.Hand})
+	if v.Round == game.PreDraw {
+		switch {
+		case e.Category >= hand.ThreeOfKind:
+			return raise(v, 0.5), nil
+		case e.Category == hand.TwoPair || (e.Category == hand.OnePair && e.Ranks[0] >= cards.Queen):
+			return call(v), nil
+		}
+		return fold(v), nil
+	}
+	b := beats(v.Hand)
+	switch {
+	case b >= 0.99: // straight or better
+		return raise(v, 0.5), nil
+	case b >= 0.9 && potOdds(v) <= 0.35:
+		return call(v), nil
+	}
+	return fold(v), nil
+}
+
+// LooseAggressive plays most hands and bets them hard. It watches its opponents and
+// bluffs more often against players who fold a lot.
+type LooseAggressive struct {
+	Tracker
+	rng *rand.Rand
+}
+
+// NewLooseAggressive returns a LooseAggressive bot; the seed drives its bluffs.
+func NewLooseAggressive(seed int64) *LooseAggressive {
+	return &LooseAggressive{rng: rand.New(rand.NewSource(seed))}
+}
+
+func (l *LooseAggressive) Discard(v game.View) ([]int, error) { return recommended(v), nil }
+
+func (l *LooseAggressive) Act(v game.View) (game.Action, error) {
+	e := hand.Evaluate(hand.Hand{Cards: v.Hand})
+	// bluff a quarter of the time against unknown players, up to about 80% against
+	// opponents who always fold
+	bluff := l.rng.Float64() < 0.25+0.55*l.foldRate(v)
+	if v.Round == game.PreDraw {
+		switch {
+		case e.Category >= hand.TwoPair || (e.Category == hand.OnePair && e.Ranks[0] >= cards.Ten):
+			return raise(v, 1), nil
+		case e.Category == hand.OnePair || isDraw(v.Hand) || e.Ranks[0] == cards.Ace:
+			if bluff {
+				return raise(v, 0.75), nil
+			}
+			return call(v), nil
+		case bluff:
+			return raise(v, 0.75), nil
+		case potOdds(v) <= 0.3:
+			return call(v), nil
+		}
+		return fold(v), nil
+	}
+	b := beats(v.Hand)
+	switch {
+	case b >= 0.9:
+		return raise(v, 1), nil
+	case bluff:
+		return raise(v, 0.75), nil
+	case b >= 0.6 && potOdds(v) <= 0.4:
+		return call(v), nil
+	}
+	return fold(v), nil
+}
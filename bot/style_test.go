@@ -0,0 +1,71 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/game"
+)
+
+func TestTightPassiveAct(t *testing.T) {
+	open := game.View{ToCall: 2, MinRaise: 4, Pot: 3, BigBlind: 2}
+	tests := []struct {
+		name  string
+		hand  string
+		round game.Round
+		v     game.View
+		want  game.ActionKind
+	}{
+		{"folds jacks", "Jc Jd 7h 4s 2c", game.PreDraw, open, game.Fold},
+		{"calls queens", "Qc Qd 7h 4s 2c", game.PreDraw, open, game.Call},
+		{"raises trips", "7c 7d 7h 4s 2c", game.PreDraw, open, game.Raise},
+		{"checks a weak hand", "Kc 9d 7h 4s 2c", game.PostDraw, game.View{MinRaise: 2, Pot: 6}, game.Check},
+		{"calls two pair", "Kc Kd 7h 7s 2c", game.PostDraw, game.View{ToCall: 4, MinRaise: 8, Pot: 12}, game.Call},
+		{"folds two pair to a big bet", "Kc Kd 7h 7s 2c", game.PostDraw, game.View{ToCall: 40, MinRaise: 80, Pot: 50}, game.Fold},
+		{"raises a straight", "8c 7d 6h 5s 4c", game.PostDraw, game.View{MinRaise: 2, Pot: 6}, game.Raise},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cs, err := cards.ParseList(tc.hand)
+			require.NoError(t, err)
+			v := tc.v
+			v.Hand, v.Round, v.Bets = cs, tc.round, []int{0, 0}
+			a, err := TightPassive{}.Act(v)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, a.Kind)
+		})
+	}
+}
+
+// The loose-aggressive bot bluffs junk more often once it has seen its opponent fold.
+func TestLooseAggressiveBluffsFolders(t *testing.T) {
+	cs, err := cards.ParseList("Kc 9d 7h 4s 2c")
+	require.NoError(t, err)
+	v := game.View{Hand: cs, Round: game.PostDraw, MinRaise: 2, Pot: 6, Bets: []int{0, 0},
+		Names: []string{"Lag", "Nit"}, Dealt: []bool{true, true}, Folded: []bool{false, false}}
+
+	bluffs := func(l *LooseAggressive) int {
+		n := 0
+		for i := 0; i < 400; i++ {
+			a, err := l.Act(v)
+			require.NoError(t, err)
+			if a.Kind == game.Raise {
+				n++
+			}
+		}
+		return n
+	}
+
+	fresh := NewLooseAggressive(1)
+	watched := NewLooseAggressive(1)
+	for i := 0; i < 10; i++ {
+		watched.Observe(game.Event{Kind: game.EventDeal, Name: "Nit"})
+		watched.Observe(game.Event{Kind: game.EventAction, Name: "Nit", Action: game.Action{Kind: game.Fold}})
+	}
+	unknown, folder := bluffs(fresh), bluffs(watched)
+	assert.InDelta(t, 100, unknown, 40)
+	assert.Greater(t, folder, 2*unknown)
+}
@@ -0,0 +1,92 @@
+package bot
+
+import "github.com/dangogh/GoPoker/game"
+
+// Stats summarizes what a player has been seen doing.
+type Stats struct {
+	Hands  int // hands dealt in
+	Played int // hands in which they called or raised at least once
+	Calls  int
+	Raises int // bets and raises
+	Folds  int
+}
+
+// FoldRate is the share of their betting actions, checks aside, that were folds.
+func (s Stats) FoldRate() float64 {
+	n := s.Calls + s.Raises + s.Folds
+	if n == 0 {
+		return 0
+	}
+	return float64(s.Folds) / float64(n)
+}
+
+// Looseness is the share of hands they put chips in voluntarily.
+func (s Stats) Looseness() float64 {
+	if s.Hands == 0 {
+		return 0
+	}
+	return float64(s.Played) / float64(s.Hands)
+}
+
+// Tracker builds Stats for every player it sees from public events. Embed it in a bot
+// to make the bot a game.Watcher.
+type Tracker struct {
+	stats  map[string]*Stats
+	played map[string]bool // already counted in Played this hand
+}
+
+// Observe records one event.
+func (t *Tracker) Observe(e game.Event) {
+	if t.stats == nil {
+		t.stats, t.played = map[string]*Stats{}, map[string]bool{}
+	}
+	s, ok := t.stats[e.Name]
+	if !ok {
+		s = &Stats{}
+		t.stats[e.Name] = s
+	}
+	switch e.Kind {
+	case game.EventDeal:
+		s.Hands++
+		t.played[e.Name] = false
+	case game.EventAction:
+		switch e.Action.Kind {
+		case game.Fold:
+			s.Folds++
+			return
+		case game.Call:
+			s.Calls++
+		case game.Bet, game.Raise:
+			s.Raises++
+		default:
+			return
+		}
+		if !t.played[e.Name] {
+			s.Played++
+			t.played[e.Name] = true
+		}
+	}
+}
+
+// Stats returns what has been seen of the named player.
+func (t *Tracker) Stats(name string) Stats {
+	if s, ok := t.stats[name]; ok {
+		return *s
+	}
+	return Stats{}
+}
+
+// foldRate averages the fold rate of the opponents still in the hand.
+func (t *Tracker) foldRate(v game.View) float64 {
+	total, n := 0.0, 0
+	for i := range v.Dealt {
+		if i != v.Seat && v.Dealt[i] && !v.Folded[i] {
+			total += t.Stats(v.Names[i]).FoldRate()
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return total / float64(n)
+}
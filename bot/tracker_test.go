@@ -0,0 +1,44 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dangogh/GoPoker/game"
+)
+
+func TestTracker(t *testing.T) {
+	var tr Tracker
+	act := func(name string, k game.ActionKind) {
+		tr.Observe(game.Event{Kind: game.EventAction, Name: name, Action: game.Action{Kind: k}})
+	}
+	for hand := 0; hand < 4; hand++ {
+		tr.Observe(game.Event{Kind: game.EventDeal, Name: "Ann"})
+		tr.Observe(game.Event{Kind: game.EventDeal, Name: "Bob"})
+		act("Ann", game.Raise)
+		if hand%2 == 0 {
+			act("Bob", game.Fold)
+			continue
+		}
+		act("Bob", game.Call)
+		act("Ann", game.Bet)
+		act("Bob", game.Check)
+		act("Bob", game.Fold)
+	}
+
+	ann := tr.Stats("Ann")
+	assert.Equal(t, Stats{Hands: 4, Played: 4, Raises: 6}, ann)
+	assert.Equal(t, 1.0, ann.Looseness())
+	assert.Zero(t, ann.FoldRate())
+
+	bob := tr.Stats("Bob")
+	assert.Equal(t, Stats{Hands: 4, Played: 2, Calls: 2, Folds: 4}, bob)
+	assert.InDelta(t, 4.0/6, bob.FoldRate(), 1e-9)
+	assert.Equal(t, 0.5, bob.Looseness())
+
+	assert.Equal(t, Stats{}, tr.Stats("Cy"))
+
+	v := game.View{Seat: 0, Names: []string{"Ann", "Bob"}, Dealt: []bool{true, true}, Folded: []bool{false, false}}
+	assert.InDelta(t, 4.0/6, tr.foldRate(v), 1e-9)
+}
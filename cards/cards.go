@@ -1,6 +1,10 @@
 package cards
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
 
 type Suit int
 
@@ -61,6 +65,51 @@ var suitNames = map[Suit]string{
 	Spades:   "♠",
 }
 
+// String returns the short rank symbol used on cards ("A", "10", "7").
+func (r Rank) String() string {
+	if n, ok := rankNames[r]; ok {
+		return n
+	}
+	return fmt.Sprintf("Rank(%d)", int(r))
+}
+
+var rankWords = map[Rank]string{
+	Two:   "Two",
+	Three: "Three",
+	Four:  "Four",
+	Five:  "Five",
+	Six:   "Six",
+	Seven: "Seven",
+	Eight: "Eight",
+	Nine:  "Nine",
+	Ten:   "Ten",
+	Jack:  "Jack",
+	Queen: "Queen",
+	King:  "King",
+	Ace:   "Ace",
+}
+
+// Name returns the rank spelled out ("Ace", "Seven") for hand descriptions.
+func (r Rank) Name() string {
+	if w, ok := rankWords[r]; ok {
+		return w
+	}
+	return fmt.Sprintf("Rank(%d)", int(r))
+}
+
+// Plural returns the plural name ("Aces", "Sixes") as used in "Pair of Sixes".
+func (r Rank) Plural() string {
+	if r == Six {
+		return "Sixes"
+	}
+	return r.Name() + "s"
+}
+
+// Valid reports whether c is one of the 52 cards; the zero Card is not.
+func (c Card) Valid() bool {
+	return c.Rank >= Two && c.Rank <= Ace && c.Suit >= Clubs && c.Suit <= Spades
+}
+
 func (c Card) String() string {
 	r, okR := rankNames[c.Rank]
 	s, okS := suitNames[c.Suit]
@@ -69,3 +118,107 @@ func (c Card) String() string {
 	}
 	return r + s
 }
+
+// Notation returns the card in the ASCII compact form Parse reads, e.g. "As" or "Td".
+// It suits wire formats and logs, where suit symbols and the two-character "10" are
+// awkward to type and to align.
+func (c Card) Notation() string {
+	r, okR := rankChars[c.Rank]
+	s, okS := suitChars[c.Suit]
+	if !okR || !okS {
+		return c.String()
+	}
+	return r + s
+}
+
+var rankChars = map[Rank]string{
+	Two: "2", Three: "3", Four: "4", Five: "5", Six: "6", Seven: "7", Eight: "8", Nine: "9",
+	Ten: "T", Jack: "J", Queen: "Q", King: "K", Ace: "A",
+}
+
+var suitChars = map[Suit]string{Clubs: "c", Diamonds: "d", Hearts: "h", Spades: "s"}
+
+var rankLetters = map[string]Rank{
+	"2": Two, "3": Three, "4": Four, "5": Five, "6": Six, "7": Seven, "8": Eight, "9": Nine,
+	"T": Ten, "10": Ten, "J": Jack, "Q": Queen, "K": King, "A": Ace,
+}
+
+var suitLetters = map[string]Suit{
+	"c": Clubs, "d": Diamonds, "h": Hearts, "s": Spades,
+	"♣": Clubs, "♦": Diamonds, "♥": Hearts, "♠": Spades,
+}
+
+// ParseRank parses a rank in short notation ("2".."9", "T" or "10", "J", "Q", "K", "A").
+// Letters are case-insensitive.
+func ParseRank(s string) (Rank, error) {
+	if r, ok := rankLetters[strings.ToUpper(s)]; ok {
+		return r, nil
+	}
+	return 0, fmt.Errorf("invalid rank %q", s)
+}
+
+// Parse parses a card in compact notation: a rank followed by a suit letter or symbol,
+// e.g. "As", "Td", "10h" or "K♠". This is the notation used by range strings and CLIs,
+// where the spelled-out "rank suit" form would be too verbose.
+func Parse(s string) (Card, error) {
+	s = strings.TrimSpace(s)
+	// the suit is the final rune; symbols are multi-byte so split on runes, not bytes
+	runes := []rune(s)
+	if len(runes) < 2 {
+		return Card{}, fmt.Errorf("invalid card %q", s)
+	}
+	rank, err := ParseRank(string(runes[:len(runes)-1]))
+	if err != nil {
+		return Card{}, fmt.Errorf("invalid card %q: %w", s, err)
+	}
+	suit, ok := suitLetters[strings.ToLower(string(runes[len(runes)-1]))]
+	if !ok {
+		return Card{}, fmt.Errorf("invalid card %q: invalid suit %q", s, string(runes[len(runes)-1]))
+	}
+	return NewCard(suit, rank), nil
+}
+
+// ParseList parses a list of compact cards separated by whitespace or commas
+// ("As Kd, 7c"). Concatenated cards without separators ("AsKd7c") are also accepted
+// so board strings can be pasted as they are usually written. Duplicates are rejected
+// because no real deal can contain the same card twice.
+func ParseList(s string) ([]Card, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+	out := make([]Card, 0, len(fields))
+	seen := make(map[Card]bool, len(fields))
+	for _, f := range fields {
+		parts, err := splitConcatenated(f)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range parts {
+			c, err := Parse(p)
+			if err != nil {
+				return nil, err
+			}
+			if seen[c] {
+				return nil, fmt.Errorf("duplicate card %s", c)
+			}
+			seen[c] = true
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// splitConcatenated splits "AsKd10c" into ["As", "Kd", "10c"] by cutting after each suit.
+func splitConcatenated(s string) ([]string, error) {
+	var parts []string
+	start := 0
+	runes := []rune(s)
+	for i, r := range runes {
+		if _, ok := suitLetters[strings.ToLower(string(r))]; ok {
+			parts = append(parts, string(runes[start:i+1]))
+			start = i + 1
+		}
+	}
+	if start != len(runes) {
+		return nil, fmt.Errorf("invalid card %q", string(runes[start:]))
+	}
+	return parts, nil
+}
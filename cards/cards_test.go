@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewCard(t *testing.T) {
@@ -59,6 +60,21 @@ func TestCardString(t *testing.T) {
 	}
 }
 
+func TestNotationRoundTrips(t *testing.T) {
+	assert.Equal(t, "As", NewCard(Spades, Ace).Notation())
+	assert.Equal(t, "Td", NewCard(Diamonds, Ten).Notation())
+	assert.Equal(t, "2c", NewCard(Clubs, Two).Notation())
+	assert.Equal(t, "Card(99,0)", Card{Rank: 99}.Notation())
+	for s := Clubs; s <= Spades; s++ {
+		for r := Two; r <= Ace; r++ {
+			c := NewCard(s, r)
+			got, err := Parse(c.Notation())
+			require.NoError(t, err)
+			assert.Equal(t, c, got)
+		}
+	}
+}
+
 func TestCardStringInvalidRank(t *testing.T) {
 	card := Card{Suit: Clubs, Rank: Rank(99)}
 	str := card.String()
@@ -77,6 +93,28 @@ func TestCardStringInvalidBoth(t *testing.T) {
 	assert.Equal(t, "Card(88,99)", str, "invalid suit and rank should return fallback format")
 }
 
+func TestCardValid(t *testing.T) {
+	tests := []struct {
+		name string
+		card Card
+		want bool
+	}{
+		{"two of clubs", NewCard(Clubs, Two), true},
+		{"ace of spades", NewCard(Spades, Ace), true},
+		{"zero card", Card{}, false},
+		{"rank below two", NewCard(Hearts, 1), false},
+		{"rank above ace", NewCard(Hearts, 15), false},
+		{"negative suit", NewCard(-1, King), false},
+		{"suit above spades", NewCard(4, King), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.card.Valid())
+		})
+	}
+}
+
 func TestAllSuits(t *testing.T) {
 	suits := []Suit{Clubs, Diamonds, Hearts, Spades}
 	expected := []string{"♣", "♦", "♥", "♠"}
@@ -126,3 +164,86 @@ func TestSuitValues(t *testing.T) {
 	assert.Equal(t, 2, int(Hearts))
 	assert.Equal(t, 3, int(Spades))
 }
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Card
+		wantErr bool
+	}{
+		{"As", NewCard(Spades, Ace), false},
+		{"td", NewCard(Diamonds, Ten), false},
+		{"10h", NewCard(Hearts, Ten), false},
+		{"K♠", NewCard(Spades, King), false},
+		{" 2c ", NewCard(Clubs, Two), false},
+		{"A", Card{}, true},
+		{"1s", Card{}, true},
+		{"Ax", Card{}, true},
+		{"", Card{}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := Parse(tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseList(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []Card
+		wantErr bool
+	}{
+		{"spaces", "As Kd", []Card{NewCard(Spades, Ace), NewCard(Diamonds, King)}, false},
+		{"commas", "As,Kd, 7c", []Card{NewCard(Spades, Ace), NewCard(Diamonds, King), NewCard(Clubs, Seven)}, false},
+		{"concatenated", "AsKd10c", []Card{NewCard(Spades, Ace), NewCard(Diamonds, King), NewCard(Clubs, Ten)}, false},
+		{"empty", "", []Card{}, false},
+		{"duplicate", "As as", nil, true},
+		{"trailing rank", "AsK", nil, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseList(tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRankNames(t *testing.T) {
+	tests := []struct {
+		rank   Rank
+		name   string
+		plural string
+	}{
+		{Ace, "Ace", "Aces"},
+		{Six, "Six", "Sixes"},
+		{Ten, "Ten", "Tens"},
+		{Two, "Two", "Twos"},
+		{Rank(1), "Rank(1)", "Rank(1)s"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.name, tc.rank.Name())
+		assert.Equal(t, tc.plural, tc.rank.Plural())
+	}
+}
+
+func TestRankString(t *testing.T) {
+	assert.Equal(t, "A", Ace.String())
+	assert.Equal(t, "10", Ten.String())
+	assert.Equal(t, "7", Seven.String())
+	assert.Equal(t, "Rank(99)", Rank(99).String())
+}
@@ -0,0 +1,136 @@
+package cfr
+
+// tree is a game tree materialized once so best responses can memoize node values.
+type tree struct {
+	terminal bool
+	utility  float64
+	chance   bool
+	probs    []float64 // chance probabilities
+	player   int
+	info     string
+	children []*tree
+}
+
+func build(st State) *tree {
+	switch {
+	case st.Terminal():
+		return &tree{terminal: true, utility: st.Utility()}
+	case st.Chance():
+		t := &tree{chance: true}
+		for _, o := range st.ChanceOutcomes() {
+			t.probs = append(t.probs, o.Prob)
+			t.children = append(t.children, build(o.State))
+		}
+		return t
+	}
+	t := &tree{player: st.Player(), info: st.InfoSet()}
+	for a := range st.Actions() {
+		t.children = append(t.children, build(st.Next(a)))
+	}
+	return t
+}
+
+// bestResponse finds player p's best reply to the strategy and returns its value to p.
+type bestResponse struct {
+	p      int
+	s      Strategy
+	reach  map[string][]reached // p's information sets and how likely each state is
+	action map[string]int
+	value  map[*tree]float64
+}
+
+type reached struct {
+	node *tree
+	prob float64 // chance and opponent reach
+}
+
+func (br *bestResponse) collect(t *tree, prob float64) {
+	switch {
+	case t.terminal:
+	case t.chance:
+		for i, c := range t.children {
+			br.collect(c, prob*t.probs[i])
+		}
+	case t.player == br.p:
+		br.reach[t.info] = append(br.reach[t.info], reached{t, prob})
+		for _, c := range t.children {
+			br.collect(c, prob)
+		}
+	default:
+		for i, pr := range br.s.probs(t.info, len(t.children)) {
+			if pr > 0 {
+				br.collect(t.children[i], prob*pr)
+			}
+		}
+	}
+}
+
+func (br *bestResponse) val(t *tree) float64 {
+	if v, ok := br.value[t]; ok {
+		return v
+	}
+	v := 0.0
+	switch {
+	case t.terminal:
+		v = t.utility
+		if br.p == 1 {
+			v = -v
+		}
+	case t.chance:
+		for i, c := range t.children {
+			v += t.probs[i] * br.val(c)
+		}
+	case t.player == br.p:
+		v = br.val(t.children[br.best(t.info)])
+	default:
+		for i, pr := range br.s.probs(t.info, len(t.children)) {
+			if pr > 0 {
+				v += pr * br.val(t.children[i])
+			}
+		}
+	}
+	br.value[t] = v
+	return v
+}
+
+// best picks the action maximizing p's value summed over every state in the
+// information set, weighted by how likely the opponent and chance make each one.
+func (br *bestResponse) best(info string) int {
+	if a, ok := br.action[info]; ok {
+		return a
+	}
+	states := br.reach[info]
+	bestA, bestV := 0, 0.0
+	for a := range states[0].node.children {
+		v := 0.0
+		for _, r := range states {
+			if r.prob > 0 {
+				v += r.prob * br.val(r.node.children[a])
+			}
+		}
+		if a == 0 || v > bestV {
+			bestA, bestV = a, v
+		}
+	}
+	br.action[info] = bestA
+	return bestA
+}
+
+// BestResponseValue is what player p wins on average by best-responding to the
+// strategy while the other player follows it.
+func BestResponseValue(g Game, s Strategy, p int) float64 {
+	return bestResponseValue(build(g.Root()), s, p)
+}
+
+func bestResponseValue(root *tree, s Strategy, p int) float64 {
+	br := &bestResponse{p: p, s: s, reach: map[string][]reached{}, action: map[string]int{}, value: map[*tree]float64{}}
+	br.collect(root, 1)
+	return br.val(root)
+}
+
+// Exploitability is the average of what each player gains by best-responding to the
+// strategy: zero exactly at a Nash equilibrium, in the game's utility units per hand.
+func Exploitability(g Game, s Strategy) float64 {
+	root := build(g.Root())
+	return (bestResponseValue(root, s, 0) + bestResponseValue(root, s, 1)) / 2
+}
@@ -0,0 +1,238 @@
+// Package cfr solves small two-player zero-sum poker games with counterfactual regret
+// minimization (the CFR+ variant: regret matching with regrets floored at zero,
+// alternating updates and linearly weighted strategy averaging).
+//
+// Games are described as trees of States. The average strategy the solver returns
+// converges to a Nash equilibrium; Exploitability measures how far from one it is.
+package cfr
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// State is a node in a game tree. Utilities are always from player 0's point of view;
+// player 1 receives the negation.
+type State interface {
+	Terminal() bool
+	// Utility is player 0's payoff at a terminal state.
+	Utility() float64
+	// Chance reports whether nature moves next; ChanceOutcomes lists the successors.
+	Chance() bool
+	ChanceOutcomes() []Outcome
+	// Player is the player to act (0 or 1) at a decision node.
+	Player() int
+	// InfoSet identifies what the player to act knows; states the player cannot tell
+	// apart must return the same string and the same Actions.
+	InfoSet() string
+	Actions() []string
+	// Next applies the action with the given index.
+	Next(action int) State
+}
+
+// Outcome is one result of a chance node.
+type Outcome struct {
+	State State
+	Prob  float64
+}
+
+// Game is anything with a root state.
+type Game interface {
+	Root() State
+}
+
+// Policy is the mixed strategy at one information set.
+type Policy struct {
+	Actions []string  `json:"actions"`
+	Probs   []float64 `json:"probs"`
+}
+
+// Strategy maps information sets to policies. Information sets it does not mention are
+// played uniformly at random.
+type Strategy map[string]Policy
+
+// probs returns the policy for an information set, uniform if unknown.
+func (s Strategy) probs(info string, n int) []float64 {
+	if p, ok := s[info]; ok && len(p.Probs) == n {
+		return p.Probs
+	}
+	u := make([]float64, n)
+	for i := range u {
+		u[i] = 1 / float64(n)
+	}
+	return u
+}
+
+// WriteText lists every information set with its action probabilities, sorted by name.
+func (s Strategy) WriteText(w io.Writer) error {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	width := 0
+	for _, k := range keys {
+		width = max(width, len(k))
+	}
+	for _, k := range keys {
+		p := s[k]
+		if _, err := fmt.Fprintf(w, "%-*s", width, k); err != nil {
+			return err
+		}
+		for i, a := range p.Actions {
+			if _, err := fmt.Fprintf(w, "  %s %5.3f", a, p.Probs[i]); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type node struct {
+	actions  []string
+	regret   []float64
+	strategy []float64 // weighted sum of current strategies
+}
+
+// current is the regret-matching strategy.
+func (n *node) current() []float64 {
+	sigma := make([]float64, len(n.regret))
+	total := 0.0
+	for _, r := range n.regret {
+		total += max(r, 0)
+	}
+	for i, r := range n.regret {
+		if total > 0 {
+			sigma[i] = max(r, 0) / total
+		} else {
+			sigma[i] = 1 / float64(len(sigma))
+		}
+	}
+	return sigma
+}
+
+// Solver accumulates regrets over iterations.
+type Solver struct {
+	game       Game
+	nodes      map[string]*node
+	iterations int
+}
+
+// NewSolver prepares a solver for the game.
+func NewSolver(g Game) *Solver {
+	return &Solver{game: g, nodes: map[string]*node{}}
+}
+
+// Iterations is the number of iterations run so far.
+func (s *Solver) Iterations() int { return s.iterations }
+
+// Run performs more iterations; each one updates both players once.
+func (s *Solver) Run(iterations int) {
+	for i := 0; i < iterations; i++ {
+		s.iterations++
+		for p := 0; p < 2; p++ {
+			s.cfr(s.game.Root(), p, 1, 1)
+		}
+	}
+}
+
+// cfr returns the expected utility for player p, given the probabilities with which p
+// (reachP) and everyone else, chance included (reachO), reach the state.
+func (s *Solver) cfr(st State, p int, reachP, reachO float64) float64 {
+	if st.Terminal() {
+		if p == 0 {
+			return st.Utility()
+		}
+		return -st.Utility()
+	}
+	if st.Chance() {
+		v := 0.0
+		for _, o := range st.ChanceOutcomes() {
+			v += o.Prob * s.cfr(o.State, p, reachP, reachO*o.Prob)
+		}
+		return v
+	}
+
+	info := st.InfoSet()
+	n, ok := s.nodes[info]
+	if !ok {
+		actions := st.Actions()
+		n = &node{actions: actions, regret: make([]float64, len(actions)), strategy: make([]float64, len(actions))}
+		s.nodes[info] = n
+	}
+	sigma := n.current()
+
+	if st.Player() != p {
+		v := 0.0
+		for a, pr := range sigma {
+			if pr > 0 {
+				v += pr * s.cfr(st.Next(a), p, reachP, reachO*pr)
+			}
+		}
+		return v
+	}
+
+	values := make([]float64, len(sigma))
+	v := 0.0
+	for a, pr := range sigma {
+		values[a] = s.cfr(st.Next(a), p, reachP*pr, reachO)
+		v += pr * values[a]
+	}
+	// CFR+: floor regrets at zero and weight later strategies more
+	weight := float64(s.iterations)
+	for a := range sigma {
+		n.regret[a] = max(0, n.regret[a]+reachO*(values[a]-v))
+		n.strategy[a] += weight * reachP * sigma[a]
+	}
+	return v
+}
+
+// Strategy returns the average strategy, which is what converges to equilibrium.
+func (s *Solver) Strategy() Strategy {
+	out := make(Strategy, len(s.nodes))
+	for info, n := range s.nodes {
+		total := 0.0
+		for _, x := range n.strategy {
+			total += x
+		}
+		probs := make([]float64, len(n.strategy))
+		for i, x := range n.strategy {
+			if total > 0 {
+				probs[i] = x / total
+			} else {
+				probs[i] = 1 / float64(len(probs))
+			}
+		}
+		out[info] = Policy{Actions: n.actions, Probs: probs}
+	}
+	return out
+}
+
+// Value is player 0's expected utility when both players follow the strategy.
+func Value(g Game, s Strategy) float64 {
+	return value(g.Root(), s)
+}
+
+func value(st State, s Strategy) float64 {
+	switch {
+	case st.Terminal():
+		return st.Utility()
+	case st.Chance():
+		v := 0.0
+		for _, o := range st.ChanceOutcomes() {
+			v += o.Prob * value(o.State, s)
+		}
+		return v
+	}
+	v := 0.0
+	for a, pr := range s.probs(st.InfoSet(), len(st.Actions())) {
+		if pr > 0 {
+			v += pr * value(st.Next(a), s)
+		}
+	}
+	return v
+}
@@ -0,0 +1,321 @@
+package cfr
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/deck"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// DrawGame is a heads-up, one-draw five-card game reduced to a size CFR can solve
+// exactly. Chance picks one of a fixed set of pre-shuffled deals, so the game tree and
+// its exploitability are exact for that sample. Players see only a coarse bucket of
+// their own hand (see preBucket and postBucket), which makes many deals look alike.
+//
+// Each player antes 1. A betting round with a bet of 1 precedes the draw and one with
+// a bet of 2 follows it; there are no raises. At the draw a player chooses to stand
+// pat or discard 1, 2 or 3 cards, always throwing away the cards least likely to
+// matter (see discardsFor). Player 1 sees how many cards player 0 drew.
+type DrawGame struct {
+	deals []drawDeal
+}
+
+// drawActions are the draw choices; the index is the number of cards discarded.
+var drawActions = []string{"pat", "d1", "d2", "d3"}
+
+type drawDeal struct {
+	pre [2]string
+	// post[p][d0][d1] is player p's bucket after player 0 drew d0 and player 1 drew d1
+	post [2][4][4]string
+	// result[d0][d1] is +1 if player 0 wins the showdown, -1 if player 1 does, 0 on a tie
+	result [4][4]int
+	// matches[p][k] reports whether discarding k cards is hand.RecommendDiscards's choice
+	matches [2][4]bool
+}
+
+// NewDrawGame samples the deals. The same seed always builds the same game.
+func NewDrawGame(deals int, seed int64) (*DrawGame, error) {
+	if deals <= 0 {
+		return nil, fmt.Errorf("deals must be > 0")
+	}
+	rng := rand.New(rand.NewSource(seed))
+	g := &DrawGame{}
+	for i := 0; i < deals; i++ {
+		d := deck.NewDeck()
+		d.ShuffleWith(rng)
+		cs, err := d.Deal(16) // two hands and up to three replacement cards each
+		if err != nil {
+			return nil, err
+		}
+		g.deals = append(g.deals, newDrawDeal([2][]cards.Card{cs[0:5], cs[5:10]}, cs[10:]))
+	}
+	return g, nil
+}
+
+func newDrawDeal(hands [2][]cards.Card, stub []cards.Card) drawDeal {
+	var dd drawDeal
+	for p, cs := range hands {
+		dd.pre[p] = preBucket(cs)
+		rec := hand.RecommendDiscards(hand.Hand{Cards: cs}, 3)
+		sort.Ints(rec)
+		for k := range drawActions {
+			dd.matches[p][k] = slices.Equal(discardsFor(cs, k), rec)
+		}
+	}
+	for d0 := range drawActions {
+		final0 := redraw(hands[0], d0, stub)
+		e0 := hand.Evaluate(hand.Hand{Cards: final0})
+		for d1 := range drawActions {
+			final1 := redraw(hands[1], d1, stub[d0:])
+			e1 := hand.Evaluate(hand.Hand{Cards: final1})
+			dd.post[0][d0][d1] = postBucket(e0)
+			dd.post[1][d0][d1] = postBucket(e1)
+			dd.result[d0][d1] = hand.Compare(e0, e1)
+		}
+	}
+	return dd
+}
+
+// discardsFor returns the sorted indexes of the k cards to throw away: the card that
+// breaks up four to a flush or straight, then singletons before paired cards, lower
+// ranks before higher.
+func discardsFor(cs []cards.Card, k int) []int {
+	breaker := -1
+	if rec := hand.RecommendDiscards(hand.Hand{Cards: cs}, 4); len(rec) == 1 {
+		breaker = rec[0]
+	}
+	count := map[cards.Rank]int{}
+	for _, c := range cs {
+		count[c.Rank]++
+	}
+	idx := []int{0, 1, 2, 3, 4}
+	sort.SliceStable(idx, func(a, b int) bool {
+		if (idx[a] == breaker) != (idx[b] == breaker) {
+			return idx[a] == breaker
+		}
+		ca, cb := cs[idx[a]], cs[idx[b]]
+		if count[ca.Rank] != count[cb.Rank] {
+			return count[ca.Rank] < count[cb.Rank]
+		}
+		return ca.Rank < cb.Rank
+	})
+	out := append([]int(nil), idx[:k]...)
+	sort.Ints(out)
+	return out
+}
+
+// redraw replaces the k discards with the first k cards of the stub.
+func redraw(cs []cards.Card, k int, stub []cards.Card) []cards.Card {
+	out := append([]cards.Card(nil), cs...)
+	for i, idx := range discardsFor(cs, k) {
+		out[idx] = stub[i]
+	}
+	return out
+}
+
+// preBucket is what a player knows about their hand before the draw.
+func preBucket(cs []cards.Card) string {
+	h := hand.Hand{Cards: cs}
+	e := hand.Evaluate(h)
+	switch e.Category {
+	case hand.HighCard:
+		if len(hand.RecommendDiscards(h, 4)) == 1 {
+			return "draw" // four to a flush or straight
+		}
+		if e.Ranks[0] == cards.Ace {
+			return "ace"
+		}
+		return "junk"
+	case hand.OnePair:
+		if e.Ranks[0] >= cards.Jack {
+			return "highpair"
+		}
+		return "lowpair"
+	case hand.TwoPair:
+		return "twopair"
+	}
+	return "trips+"
+}
+
+// postBucket is what a player knows about their hand after the draw.
+func postBucket(e hand.EvaluatedHand) string {
+	switch e.Category {
+	case hand.HighCard:
+		return "nothing"
+	case hand.OnePair:
+		if e.Ranks[0] >= cards.Jack {
+			return "highpair"
+		}
+		return "lowpair"
+	case hand.TwoPair:
+		return "twopair"
+	case hand.ThreeOfKind:
+		return "trips"
+	}
+	return "straight+"
+}
+
+// Root picks a deal.
+func (g *DrawGame) Root() State { return drawState{g: g, deal: -1} }
+
+type drawState struct {
+	g       *DrawGame
+	deal    int
+	phase   int // 0 betting before the draw, 1 drawing, 2 betting after
+	history [2]string
+	draws   [2]int
+	drawn   int // how many players have drawn
+	contrib [2]int
+	folded  int
+}
+
+func (s drawState) roundOver() bool {
+	h := s.history[s.phase/2]
+	n := len(h)
+	return n >= 2 && (h[n-1] == 'c' || h[n-1] == 'f' || h == "kk")
+}
+
+func (s drawState) Terminal() bool {
+	return s.folded > 0 || (s.phase == 2 && s.roundOver())
+}
+
+func (s drawState) Utility() float64 {
+	if s.folded > 0 {
+		if s.folded == 1 {
+			return -float64(s.contrib[0])
+		}
+		return float64(s.contrib[1])
+	}
+	switch s.g.deals[s.deal].result[s.draws[0]][s.draws[1]] {
+	case 1:
+		return float64(s.contrib[1])
+	case -1:
+		return -float64(s.contrib[0])
+	}
+	return 0
+}
+
+func (s drawState) Chance() bool { return s.deal < 0 }
+
+func (s drawState) ChanceOutcomes() []Outcome {
+	out := make([]Outcome, len(s.g.deals))
+	for i := range s.g.deals {
+		out[i] = Outcome{State: drawState{g: s.g, deal: i, contrib: [2]int{1, 1}}, Prob: 1 / float64(len(s.g.deals))}
+	}
+	return out
+}
+
+func (s drawState) Player() int {
+	if s.phase == 1 {
+		return s.drawn
+	}
+	return len(s.history[s.phase/2]) % 2
+}
+
+func (s drawState) InfoSet() string {
+	p := s.Player()
+	d := s.g.deals[s.deal]
+	switch s.phase {
+	case 0:
+		return d.pre[p] + ":" + s.history[0]
+	case 1:
+		if p == 0 {
+			return d.pre[0] + ":" + s.history[0] + "|"
+		}
+		return d.pre[1] + ":" + s.history[0] + "|" + drawActions[s.draws[0]]
+	}
+	return d.post[p][s.draws[0]][s.draws[1]] + ":" + s.history[0] + "|" +
+		drawActions[s.draws[0]] + "," + drawActions[s.draws[1]] + "|" + s.history[1]
+}
+
+func (s drawState) Actions() []string {
+	if s.phase == 1 {
+		return drawActions
+	}
+	if s.contrib[0] != s.contrib[1] {
+		return []string{"f", "c"}
+	}
+	return []string{"k", "r"}
+}
+
+func (s drawState) Next(a int) State {
+	if s.phase == 1 {
+		s.draws[s.drawn] = a
+		s.drawn++
+		if s.drawn == 2 {
+			s.phase = 2
+		}
+		return s
+	}
+	act := s.Actions()[a]
+	p := s.Player()
+	switch act {
+	case "f":
+		s.folded = p + 1
+	case "c":
+		s.contrib[p] = s.contrib[1-p]
+	case "r":
+		s.contrib[p] = s.contrib[1-p] + 1 + s.phase/2 // 1 before the draw, 2 after
+	}
+	s.history[s.phase/2] += act
+	if s.phase == 0 && s.roundOver() && s.folded == 0 {
+		s.phase = 1
+	}
+	return s
+}
+
+// Agreement reports how often a strategy discards exactly the cards that
+// hand.RecommendDiscards would, weighted by how often each draw decision is reached
+// when both players follow the strategy. ByBucket breaks it down by pre-draw bucket.
+type Agreement struct {
+	Overall  float64            `json:"overall"`
+	ByBucket map[string]float64 `json:"by_bucket"`
+}
+
+// DiscardAgreement measures s against hand.RecommendDiscards.
+func (g *DrawGame) DiscardAgreement(s Strategy) Agreement {
+	agree, reach := map[string]float64{}, map[string]float64{}
+	var walk func(st drawState, prob float64)
+	walk = func(st drawState, prob float64) {
+		switch {
+		case prob == 0 || st.Terminal():
+			return
+		case st.Chance():
+			for _, o := range st.ChanceOutcomes() {
+				walk(o.State.(drawState), prob*o.Prob)
+			}
+			return
+		}
+		probs := s.probs(st.InfoSet(), len(st.Actions()))
+		if st.phase == 1 {
+			p := st.Player()
+			d := g.deals[st.deal]
+			for k, pr := range probs {
+				if d.matches[p][k] {
+					agree[d.pre[p]] += prob * pr
+				}
+			}
+			reach[d.pre[p]] += prob
+		}
+		for a, pr := range probs {
+			walk(st.Next(a).(drawState), prob*pr)
+		}
+	}
+	walk(g.Root().(drawState), 1)
+
+	res := Agreement{ByBucket: map[string]float64{}}
+	totalAgree, totalReach := 0.0, 0.0
+	for b, r := range reach {
+		res.ByBucket[b] = agree[b] / r
+		totalAgree += agree[b]
+		totalReach += r
+	}
+	if totalReach > 0 {
+		res.Overall = totalAgree / totalReach
+	}
+	return res
+}
@@ -0,0 +1,87 @@
+package cfr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/cards"
+)
+
+func mustCards(t *testing.T, s string) []cards.Card {
+	t.Helper()
+	cs, err := cards.ParseList(s)
+	require.NoError(t, err)
+	return cs
+}
+
+func TestDiscardsFor(t *testing.T) {
+	cs := mustCards(t, "Kc 7d Kh 2s 9c")
+	assert.Empty(t, discardsFor(cs, 0))
+	assert.Equal(t, []int{3}, discardsFor(cs, 1))
+	assert.Equal(t, []int{1, 3, 4}, discardsFor(cs, 3), "keep the kings")
+}
+
+func TestBuckets(t *testing.T) {
+	tests := []struct {
+		hand, want string
+	}{
+		{"Ah Kh 9h 4h 2c", "draw"},
+		{"Ah Kd 9h 4c 2c", "ace"},
+		{"Qh Jd 9h 4c 2c", "junk"},
+		{"Qh Qd 9h 4c 2c", "highpair"},
+		{"Th Td 9h 4c 2c", "lowpair"},
+		{"Th Td 9h 9c 2c", "twopair"},
+		{"Th Td Tc 9c 2c", "trips+"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, preBucket(mustCards(t, tc.hand)), tc.hand)
+	}
+}
+
+func TestDrawDeal(t *testing.T) {
+	hands := [2][]cards.Card{mustCards(t, "Kc Kd 7h 4s 2c"), mustCards(t, "Ac Qd Jh 8s 3c")}
+	dd := newDrawDeal(hands, mustCards(t, "Kh 5d 5c 9h 9d Ad"))
+	// standing pat, kings beat ace high
+	assert.Equal(t, 1, dd.result[0][0])
+	// player 0 draws one (Kh, trips); player 1 then draws three (5d 5c 9h): still behind
+	assert.Equal(t, "trips", dd.post[0][1][3])
+	assert.Equal(t, "lowpair", dd.post[1][1][3])
+	assert.Equal(t, 1, dd.result[1][3])
+	// player 0 draws three (Kh 5d 5c: kings full); pat ace high loses
+	assert.Equal(t, "straight+", dd.post[0][3][0])
+	// RecommendDiscards keeps the pair and throws three
+	assert.Equal(t, [4]bool{false, false, false, true}, dd.matches[0])
+}
+
+func TestDrawGameSolves(t *testing.T) {
+	g, err := NewDrawGame(40, 1)
+	require.NoError(t, err)
+	start := Exploitability(g, Strategy{})
+
+	s := NewSolver(g)
+	s.Run(200)
+	strat := s.Strategy()
+	e := Exploitability(g, strat)
+	assert.Less(t, e, start/5, "solving cuts exploitability from %.3f", start)
+	assert.Less(t, e, 0.15)
+
+	agree := g.DiscardAgreement(strat)
+	assert.Greater(t, agree.Overall, 0.0)
+	assert.LessOrEqual(t, agree.Overall, 1.0)
+	assert.NotEmpty(t, agree.ByBucket)
+
+	again, err := NewDrawGame(40, 1)
+	require.NoError(t, err)
+	assert.Equal(t, g, again, "a seed builds the same deals")
+
+	_, err = NewDrawGame(0, 1)
+	assert.Error(t, err)
+}
+
+func TestDiscardsForKeepsDraws(t *testing.T) {
+	cs := mustCards(t, "2h Kh 9h 4h Qc")
+	assert.Equal(t, []int{4}, discardsFor(cs, 1), "throw the club, not the deuce")
+	assert.Equal(t, []int{0, 4}, discardsFor(cs, 2))
+}
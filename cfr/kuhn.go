@@ -0,0 +1,68 @@
+package cfr
+
+// Kuhn is Kuhn poker: a three-card deck (J, Q, K), one card each, an ante of 1 and a
+// single betting round where the only bet is 1. Its equilibrium value for the first
+// player is -1/18.
+type Kuhn struct{}
+
+var kuhnCards = [3]string{"J", "Q", "K"}
+
+// Root deals the cards.
+func (Kuhn) Root() State { return kuhnState{dealt: false} }
+
+type kuhnState struct {
+	dealt   bool
+	cards   [2]int
+	history string // "p" for pass (check or fold), "b" for bet (or call)
+}
+
+func (s kuhnState) Terminal() bool {
+	switch s.history {
+	case "pp", "bp", "bb", "pbp", "pbb":
+		return true
+	}
+	return false
+}
+
+func (s kuhnState) Utility() float64 {
+	win := 1.0
+	if s.cards[1] > s.cards[0] {
+		win = -1
+	}
+	switch s.history {
+	case "bp": // player 1 folds
+		return 1
+	case "pbp": // player 0 folds
+		return -1
+	case "pp":
+		return win
+	}
+	return 2 * win // a bet was called
+}
+
+func (s kuhnState) Chance() bool { return !s.dealt }
+
+func (s kuhnState) ChanceOutcomes() []Outcome {
+	var out []Outcome
+	for a := 0; a < 3; a++ {
+		for b := 0; b < 3; b++ {
+			if a != b {
+				out = append(out, Outcome{State: kuhnState{dealt: true, cards: [2]int{a, b}}, Prob: 1.0 / 6})
+			}
+		}
+	}
+	return out
+}
+
+func (s kuhnState) Player() int { return len(s.history) % 2 }
+
+func (s kuhnState) InfoSet() string {
+	return kuhnCards[s.cards[s.Player()]] + ":" + s.history
+}
+
+func (s kuhnState) Actions() []string { return []string{"p", "b"} }
+
+func (s kuhnState) Next(a int) State {
+	s.history += s.Actions()[a]
+	return s
+}
@@ -0,0 +1,57 @@
+package cfr
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKuhnConverges(t *testing.T) {
+	s := NewSolver(Kuhn{})
+	s.Run(2000)
+	assert.Equal(t, 2000, s.Iterations())
+	strat := s.Strategy()
+	require.Len(t, strat, 12)
+
+	assert.Less(t, Exploitability(Kuhn{}, strat), 0.005)
+	assert.InDelta(t, -1.0/18, Value(Kuhn{}, strat), 0.003)
+
+	// in equilibrium player 1 always calls with the King and never with the Jack,
+	// and player 0 bets the King three times as often as the Jack
+	assert.InDelta(t, 1, strat["K:b"].Probs[1], 0.01)
+	assert.InDelta(t, 0, strat["J:b"].Probs[1], 0.01)
+	assert.InDelta(t, 3*strat["J:"].Probs[1], strat["K:"].Probs[1], 0.05)
+}
+
+func TestExploitabilityOfUniformPlay(t *testing.T) {
+	uniform := Strategy{}
+	e := Exploitability(Kuhn{}, uniform)
+	assert.Greater(t, e, 0.1)
+	assert.InDelta(t, 0.125, Value(Kuhn{}, uniform), 1e-9)
+
+	// exploitability is never negative and the best responses bracket the game value
+	assert.GreaterOrEqual(t, BestResponseValue(Kuhn{}, uniform, 0), -1.0/18)
+	assert.GreaterOrEqual(t, BestResponseValue(Kuhn{}, uniform, 1), 1.0/18)
+}
+
+func TestStrategyWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	s := Strategy{"K:b": {Actions: []string{"p", "b"}, Probs: []float64{0, 1}}, "J:": {Actions: []string{"p", "b"}, Probs: []float64{0.75, 0.25}}}
+	require.NoError(t, s.WriteText(&buf))
+	assert.Equal(t, "J:   p 0.750  b 0.250\nK:b  p 0.000  b 1.000\n", buf.String())
+}
+
+func TestKnownEquilibriumIsUnexploitable(t *testing.T) {
+	bet := func(b float64) Policy { return Policy{Actions: []string{"p", "b"}, Probs: []float64{1 - b, b}} }
+	// the family of equilibria with alpha = 1/3
+	eq := Strategy{
+		"J:": bet(1.0 / 3), "Q:": bet(0), "K:": bet(1),
+		"J:pb": bet(0), "Q:pb": bet(2.0 / 3), "K:pb": bet(1),
+		"J:b": bet(0), "Q:b": bet(1.0 / 3), "K:b": bet(1),
+		"J:p": bet(1.0 / 3), "Q:p": bet(0), "K:p": bet(1),
+	}
+	assert.InDelta(t, 0, Exploitability(Kuhn{}, eq), 1e-12)
+	assert.InDelta(t, -1.0/18, Value(Kuhn{}, eq), 1e-12)
+}
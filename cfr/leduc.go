@@ -0,0 +1,143 @@
+package cfr
+
+// Leduc is Leduc hold'em: a six-card deck (two each of J, Q, K), one private card each,
+// an ante of 1 and two betting rounds with bets of 2 and then 4, at most two bets per
+// round. A public card is dealt between the rounds; pairing it wins, otherwise the
+// higher private card does.
+type Leduc struct{}
+
+// Root deals the private cards.
+func (Leduc) Root() State { return leducState{public: -1, contrib: [2]int{1, 1}, dealing: true} }
+
+type leducState struct {
+	dealing bool // private cards are being dealt
+	cards   [2]int
+	public  int // rank of the public card, -1 before it is dealt
+	round   int
+	history [2]string // per round: k check, c call, r bet or raise, f fold
+	contrib [2]int
+	folded  int // 1 + the player who folded, 0 if nobody
+}
+
+func (s leducState) roundOver() bool {
+	h := s.history[s.round]
+	n := len(h)
+	return n >= 2 && (h[n-1] == 'c' || h[n-2:] == "kk")
+}
+
+func (s leducState) Terminal() bool {
+	return s.folded > 0 || (s.round == 1 && s.roundOver())
+}
+
+func (s leducState) Utility() float64 {
+	if s.folded > 0 {
+		if s.folded == 1 {
+			return -float64(s.contrib[0])
+		}
+		return float64(s.contrib[1])
+	}
+	strength := func(p int) int {
+		if s.cards[p] == s.public {
+			return 10 + s.cards[p]
+		}
+		return s.cards[p]
+	}
+	switch a, b := strength(0), strength(1); {
+	case a > b:
+		return float64(s.contrib[1])
+	case a < b:
+		return -float64(s.contrib[0])
+	}
+	return 0
+}
+
+func (s leducState) Chance() bool {
+	return s.dealing || (s.round == 0 && s.roundOver() && s.public < 0)
+}
+
+// ChanceOutcomes weights each rank by how many of its two cards are still in the deck.
+func (s leducState) ChanceOutcomes() []Outcome {
+	var out []Outcome
+	if s.dealing {
+		for a := 0; a < 3; a++ {
+			for b := 0; b < 3; b++ {
+				ways := 2.0 * 2
+				if a == b {
+					ways = 2 * 1
+				}
+				n := s
+				n.dealing, n.cards = false, [2]int{a, b}
+				out = append(out, Outcome{State: n, Prob: ways / 30})
+			}
+		}
+		return out
+	}
+	for r := 0; r < 3; r++ {
+		left := 2
+		for _, c := range s.cards {
+			if c == r {
+				left--
+			}
+		}
+		if left == 0 {
+			continue
+		}
+		n := s
+		n.public, n.round = r, 1
+		out = append(out, Outcome{State: n, Prob: float64(left) / 4})
+	}
+	return out
+}
+
+func (s leducState) Player() int { return len(s.history[s.round]) % 2 }
+
+func (s leducState) InfoSet() string {
+	info := kuhnCards[s.cards[s.Player()]]
+	if s.public >= 0 {
+		info += kuhnCards[s.public]
+	}
+	return info + ":" + s.history[0] + "/" + s.history[1]
+}
+
+func (s leducState) raises() int {
+	n := 0
+	for _, c := range s.history[s.round] {
+		if c == 'r' {
+			n++
+		}
+	}
+	return n
+}
+
+func (s leducState) Actions() []string {
+	facing := s.contrib[0] != s.contrib[1]
+	var acts []string
+	if facing {
+		acts = []string{"f", "c"}
+	} else {
+		acts = []string{"k"}
+	}
+	if s.raises() < 2 {
+		acts = append(acts, "r")
+	}
+	return acts
+}
+
+func (s leducState) Next(a int) State {
+	act := s.Actions()[a]
+	p := s.Player()
+	bet := 2
+	if s.round == 1 {
+		bet = 4
+	}
+	switch act {
+	case "f":
+		s.folded = p + 1
+	case "c":
+		s.contrib[p] = s.contrib[1-p]
+	case "r":
+		s.contrib[p] = s.contrib[1-p] + bet
+	}
+	s.history[s.round] += act
+	return s
+}
@@ -0,0 +1,50 @@
+package cfr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeducChanceIsADistribution(t *testing.T) {
+	root := Leduc{}.Root()
+	total := 0.0
+	for _, o := range root.ChanceOutcomes() {
+		total += o.Prob
+		// check-check reaches the public card
+		st := o.State.Next(0).Next(0)
+		assert.True(t, st.Chance())
+		sub := 0.0
+		for _, p := range st.ChanceOutcomes() {
+			sub += p.Prob
+		}
+		assert.InDelta(t, 1, sub, 1e-12)
+	}
+	assert.InDelta(t, 1, total, 1e-12)
+}
+
+func TestLeducBetting(t *testing.T) {
+	var st State = leducState{cards: [2]int{2, 0}, public: -1, contrib: [2]int{1, 1}}
+	assert.Equal(t, []string{"k", "r"}, st.Actions())
+	st = st.Next(1) // bet 2
+	assert.Equal(t, []string{"f", "c", "r"}, st.Actions())
+	st = st.Next(2) // raise
+	assert.Equal(t, []string{"f", "c"}, st.Actions(), "at most two bets a round")
+	assert.Equal(t, "K:rr/", st.InfoSet(), "back to player 0")
+	st = st.Next(0) // player 0 folds the King
+	assert.True(t, st.Terminal())
+	assert.Equal(t, -3.0, st.Utility())
+}
+
+func TestLeducConverges(t *testing.T) {
+	if testing.Short() {
+		t.Skip("solves Leduc")
+	}
+	s := NewSolver(Leduc{})
+	s.Run(1000)
+	strat := s.Strategy()
+	assert.Len(t, strat, 288)
+	assert.Less(t, Exploitability(Leduc{}, strat), 0.05)
+	// the first player's equilibrium value is about -0.0856
+	assert.InDelta(t, -0.0856, Value(Leduc{}, strat), 0.02)
+}
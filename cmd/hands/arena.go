@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dangogh/GoPoker/arena"
+	"github.com/dangogh/GoPoker/bot"
+	"github.com/dangogh/GoPoker/game"
+)
+
+// runArena implements "hands arena": a round robin and ring game between bot styles.
+func runArena(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("arena", flag.ContinueOnError)
+	fs.SetOutput(out)
+	styles := fs.String("bots", "basic,random,tight-passive,loose-aggressive", "comma-separated bot styles to compare: "+strings.Join(bot.Kinds(), ", "))
+	deals := fs.Int("deals", 500, "decks dealt to each heads-up pairing (0 = skip)")
+	ring := fs.Int("ring", 0, "decks dealt to a ring game with every bot (0 = skip)")
+	duplicate := fs.Bool("duplicate", true, "replay every deck with the seats rotated")
+	stack := fs.Int("stack", 0, "stack at the start of each hand (0 = 100 big blinds)")
+	ante := fs.Int("ante", 0, "ante per player")
+	sb := fs.Int("sb", 1, "small blind")
+	bb := fs.Int("bb", 2, "big blind")
+	seed := fs.Int64("seed", 1, "random seed")
+	format := fs.String("format", "text", "output format: text or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q (valid options: text, json)", *format)
+	}
+
+	entrants, err := parseEntrants(*styles)
+	if err != nil {
+		return err
+	}
+	rep, err := arena.Run(arena.Config{
+		Entrants:  entrants,
+		Deals:     *deals,
+		RingDeals: *ring,
+		Duplicate: *duplicate,
+		Stack:     *stack,
+		Game:      game.Config{Ante: *ante, SmallBlind: *sb, BigBlind: *bb},
+		Seed:      *seed,
+	})
+	if err != nil {
+		return err
+	}
+	if *format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rep)
+	}
+	return rep.WriteText(out)
+}
+
+// parseEntrants turns a comma-separated list of bot styles into arena entrants. A
+// style listed more than once gets numbered names ("basic", "basic#2"), which is handy
+// for measuring noise.
+func parseEntrants(styles string) ([]arena.Entrant, error) {
+	var entrants []arena.Entrant
+	count := map[string]int{}
+	for _, kind := range strings.Split(styles, ",") {
+		kind = strings.TrimSpace(kind)
+		if _, err := bot.New(kind, 0); err != nil {
+			return nil, err
+		}
+		count[kind]++
+		name := kind
+		if count[kind] > 1 {
+			name = fmt.Sprintf("%s#%d", kind, count[kind])
+		}
+		entrants = append(entrants, arena.Entrant{Name: name, New: func(seed int64) game.Player {
+			p, _ := bot.New(kind, seed) // checked above
+			return p
+		}})
+	}
+	return entrants, nil
+}
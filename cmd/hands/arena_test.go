@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/arena"
+)
+
+func TestRunArena(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, runArena([]string{"-bots", "basic,random,basic", "-deals", "50", "-ring", "20"}, &buf))
+	out := buf.String()
+	assert.Contains(t, out, "Heads-up ladder")
+	assert.Contains(t, out, "basic#2")
+	assert.Contains(t, out, "Ring game")
+
+	buf.Reset()
+	require.NoError(t, runArena([]string{"-bots", "tp,lag", "-deals", "30", "-format", "json"}, &buf))
+	var rep arena.Report
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &rep))
+	require.Len(t, rep.Matchups, 1)
+	assert.Equal(t, 60, rep.Matchups[0].Hands, "duplicate by default")
+	assert.Empty(t, rep.Ring)
+}
+
+func TestRunArenaRejectsBadFlags(t *testing.T) {
+	var buf bytes.Buffer
+	assert.ErrorContains(t, runArena([]string{"-bots", "basic,shark"}, &buf), "unknown bot")
+	assert.Error(t, runArena([]string{"-bots", "basic"}, &buf))
+	assert.Error(t, runArena([]string{"-format", "xml"}, &buf))
+	assert.ErrorContains(t, runArena([]string{"-bots", "basic,tp", "-ring", "5"}, &buf), "ring game")
+}
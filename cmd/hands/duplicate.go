@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dangogh/GoPoker/arena"
+	"github.com/dangogh/GoPoker/bot"
+	"github.com/dangogh/GoPoker/game"
+)
+
+// runDuplicate implements "hands duplicate": every shuffled deck is replayed with the
+// bots rotated through all the seats, and the per-deck differentials are printed.
+func runDuplicate(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("duplicate", flag.ContinueOnError)
+	fs.SetOutput(out)
+	styles := fs.String("bots", "basic,tight-passive", "comma-separated bot styles at the table: "+strings.Join(bot.Kinds(), ", "))
+	decks := fs.Int("decks", 20, "number of shuffled decks to replay")
+	stack := fs.Int("stack", 0, "stack at the start of each hand (0 = 100 big blinds)")
+	ante := fs.Int("ante", 0, "ante per player")
+	sb := fs.Int("sb", 1, "small blind")
+	bb := fs.Int("bb", 2, "big blind")
+	seed := fs.Int64("seed", 1, "random seed for the shuffles")
+	quiet := fs.Bool("q", false, "print only the totals, not every deck")
+	format := fs.String("format", "text", "output format: text or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q (valid options: text, json)", *format)
+	}
+	entrants, err := parseEntrants(*styles)
+	if err != nil {
+		return err
+	}
+
+	rep, err := arena.Duplicate(arena.Config{
+		Entrants: entrants,
+		Deals:    *decks,
+		Stack:    *stack,
+		Game:     game.Config{Ante: *ante, SmallBlind: *sb, BigBlind: *bb},
+		Seed:     *seed,
+	})
+	if err != nil {
+		return err
+	}
+	if *format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rep)
+	}
+	return rep.WriteText(out, !*quiet)
+}
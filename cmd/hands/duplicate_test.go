@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/arena"
+)
+
+func TestRunDuplicate(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, runDuplicate([]string{"-bots", "basic,tp,lag", "-decks", "4", "-seed", "3"}, &buf))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Regexp(t, `^\s*Deck\s+First cards\s+basic\s+tp\s+lag$`, lines[0])
+	assert.Len(t, lines, 1+4+1+1+3, "header, decks, blank line, totals header, totals")
+
+	buf.Reset()
+	require.NoError(t, runDuplicate([]string{"-decks", "3", "-q"}, &buf))
+	assert.NotContains(t, buf.String(), "First cards")
+
+	buf.Reset()
+	require.NoError(t, runDuplicate([]string{"-decks", "3", "-format", "json"}, &buf))
+	var rep arena.DuplicateReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &rep))
+	assert.Len(t, rep.Decks, 3)
+	assert.Equal(t, []string{"basic", "tight-passive"}, rep.Entrants)
+}
+
+func TestRunDuplicateRejectsBadFlags(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Error(t, runDuplicate([]string{"-decks", "0"}, &buf))
+	assert.Error(t, runDuplicate([]string{"-bots", "basic"}, &buf))
+	assert.Error(t, runDuplicate([]string{"-format", "csv"}, &buf))
+}
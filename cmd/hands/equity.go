@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/equity"
+)
+
+// rangeList collects a repeatable -villain flag, one range per opponent.
+type rangeList []string
+
+func (r *rangeList) String() string { return strings.Join(*r, " | ") }
+
+func (r *rangeList) Set(s string) error {
+	*r = append(*r, s)
+	return nil
+}
+
+// equityFlags registers the options shared by the equity and heatmap subcommands.
+type equityFlags struct {
+	villains rangeList
+	board    *string
+	dead     *string
+	trials   *int
+	seed     *int64
+}
+
+func addEquityFlags(fs *flag.FlagSet, defaultTrials int) *equityFlags {
+	ef := &equityFlags{}
+	fs.Var(&ef.villains, "villain", "villain range, e.g. \"QQ+,AKs\" (repeat for multiway)")
+	ef.board = fs.String("board", "", "known board cards, e.g. \"AsKd7c\"")
+	ef.dead = fs.String("dead", "", "dead cards removed from the deck")
+	ef.trials = fs.Int("trials", defaultTrials, "Monte Carlo deals per calculation")
+	ef.seed = fs.Int64("seed", 0, "random seed for reproducible results (0 = random)")
+	return ef
+}
+
+func (ef *equityFlags) parse() ([]equity.Range, equity.Options, error) {
+	if len(ef.villains) == 0 {
+		return nil, equity.Options{}, fmt.Errorf("at least one -villain range is required")
+	}
+	villains := make([]equity.Range, len(ef.villains))
+	for i, s := range ef.villains {
+		r, err := equity.ParseRange(s)
+		if err != nil {
+			return nil, equity.Options{}, fmt.Errorf("villain %d: %w", i+1, err)
+		}
+		villains[i] = r
+	}
+	board, err := cards.ParseList(*ef.board)
+	if err != nil {
+		return nil, equity.Options{}, fmt.Errorf("board: %w", err)
+	}
+	dead, err := cards.ParseList(*ef.dead)
+	if err != nil {
+		return nil, equity.Options{}, fmt.Errorf("dead: %w", err)
+	}
+	return villains, equity.Options{Board: board, Dead: dead, Trials: *ef.trials, Seed: *ef.seed}, nil
+}
+
+// runEquity implements "hands equity": hero range versus one or more villain ranges.
+func runEquity(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("equity", flag.ContinueOnError)
+	fs.SetOutput(out)
+	hero := fs.String("hero", "", "hero range, e.g. \"AKs\" or \"AsKs\"")
+	ef := addEquityFlags(fs, equity.DefaultTrials)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *hero == "" {
+		return fmt.Errorf("-hero range is required")
+	}
+	heroRange, err := equity.ParseRange(*hero)
+	if err != nil {
+		return fmt.Errorf("hero: %w", err)
+	}
+	villains, opts, err := ef.parse()
+	if err != nil {
+		return err
+	}
+
+	res, err := equity.Compute(heroRange, villains, opts)
+	if err != nil {
+		return err
+	}
+
+	names := append([]string{*hero}, ef.villains...)
+	fmt.Fprintf(out, "Equity over %d trials:\n", res.Trials)
+	for i, name := range names {
+		label := "Hero"
+		if i > 0 {
+			label = fmt.Sprintf("Villain %d", i)
+		}
+		fmt.Fprintf(out, "%-10s %-20s equity %5.1f%%  win %5.1f%%  tie %5.1f%%\n",
+			label, name, 100*res.Equity[i], 100*res.Win[i], 100*res.Tie[i])
+	}
+	return nil
+}
+
+// runHeatmap implements "hands heatmap": the 13×13 grid of class equities versus the villains.
+func runHeatmap(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("heatmap", flag.ContinueOnError)
+	fs.SetOutput(out)
+	format := fs.String("format", "text", "output format: text or json")
+	// 169 simulations per grid, so default to fewer deals per cell than a single matchup
+	ef := addEquityFlags(fs, 500)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q (valid options: text, json)", *format)
+	}
+
+	villains, opts, err := ef.parse()
+	if err != nil {
+		return err
+	}
+	grid, err := equity.Heatmap(villains, opts)
+	if err != nil {
+		return err
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(grid)
+	}
+	fmt.Fprintf(out, "Equity (%%) vs %s\n", ef.villains.String())
+	return grid.WriteText(out)
+}
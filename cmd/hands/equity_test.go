@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/equity"
+)
+
+func TestRunEquity(t *testing.T) {
+	var buf bytes.Buffer
+	err := runEquity([]string{"-hero", "AsAh", "-villain", "KK", "-villain", "random", "-trials", "200", "-seed", "1"}, &buf)
+	require.NoError(t, err)
+	out := buf.String()
+	assert.Contains(t, out, "Equity over 200 trials")
+	assert.Contains(t, out, "Hero")
+	assert.Contains(t, out, "Villain 1")
+	assert.Contains(t, out, "Villain 2")
+}
+
+func TestRunEquityErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		msg  string
+	}{
+		{"missing hero", []string{"-villain", "KK"}, "-hero"},
+		{"missing villain", []string{"-hero", "AA"}, "-villain"},
+		{"bad hero", []string{"-hero", "AX", "-villain", "KK"}, "hero"},
+		{"bad board", []string{"-hero", "AA", "-villain", "KK", "-board", "Zz"}, "board"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := runEquity(tc.args, &buf)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.msg)
+		})
+	}
+}
+
+func TestRunHeatmapText(t *testing.T) {
+	var buf bytes.Buffer
+	err := runHeatmap([]string{"-villain", "AA", "-trials", "5", "-seed", "2"}, &buf)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Contains(t, lines[0], "vs AA")
+	assert.Len(t, lines, 16, "title, header, 13 rows and legend")
+}
+
+func TestRunHeatmapJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := runHeatmap([]string{"-villain", "AA", "-trials", "5", "-seed", "2", "-format", "json"}, &buf)
+	require.NoError(t, err)
+
+	var g equity.Grid
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &g))
+	assert.Equal(t, "AKs", g.Cells[0][1].Class)
+	assert.Equal(t, 5, g.Trials)
+}
+
+func TestRunHeatmapBadFormat(t *testing.T) {
+	var buf bytes.Buffer
+	err := runHeatmap([]string{"-villain", "AA", "-format", "xml"}, &buf)
+	assert.Error(t, err)
+}
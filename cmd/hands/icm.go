@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dangogh/GoPoker/equity"
+	"github.com/dangogh/GoPoker/icm"
+)
+
+// parseInts reads a comma separated list such as "5000,3000,2000".
+func parseInts(s string) ([]int, error) {
+	var out []int
+	for _, f := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", f)
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+func parseFloats(s string) ([]float64, error) {
+	var out []float64
+	for _, f := range strings.Split(s, ",") {
+		x, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", f)
+		}
+		out = append(out, x)
+	}
+	return out, nil
+}
+
+// runICM implements "hands icm": prize equity for each stack.
+func runICM(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("icm", flag.ContinueOnError)
+	fs.SetOutput(out)
+	stacksFlag := fs.String("stacks", "", "chip stacks, e.g. \"5000,3000,2000\"")
+	payoutsFlag := fs.String("payouts", "", "prizes by place, e.g. \"50,30,20\"")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *stacksFlag == "" || *payoutsFlag == "" {
+		return fmt.Errorf("-stacks and -payouts are required")
+	}
+	stacks, err := parseInts(*stacksFlag)
+	if err != nil {
+		return fmt.Errorf("stacks: %w", err)
+	}
+	payouts, err := parseFloats(*payoutsFlag)
+	if err != nil {
+		return fmt.Errorf("payouts: %w", err)
+	}
+	eq, err := icm.Equities(stacks, payouts)
+	if err != nil {
+		return err
+	}
+
+	chips, pool := 0, 0.0
+	for _, s := range stacks {
+		chips += s
+	}
+	for _, p := range payouts[:min(len(payouts), len(stacks))] {
+		pool += p
+	}
+	fmt.Fprintf(out, "%-8s %10s %8s %10s %8s\n", "Player", "Chips", "Chips%", "Equity", "Equity%")
+	for i, s := range stacks {
+		fmt.Fprintf(out, "%-8d %10d %7.2f%% %10.2f %7.2f%%\n", i+1, s, 100*float64(s)/float64(chips), eq[i], 100*eq[i]/pool)
+	}
+	return nil
+}
+
+// runPushFold implements "hands pushfold": which hands to shove into one caller.
+func runPushFold(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("pushfold", flag.ContinueOnError)
+	fs.SetOutput(out)
+	stacksFlag := fs.String("stacks", "", "chip stacks at the start of the hand, e.g. \"3000,2500,1500\"")
+	postedFlag := fs.String("posted", "", "blinds and antes already posted per player (default none)")
+	payoutsFlag := fs.String("payouts", "", "prizes by place, e.g. \"50,30,20\"")
+	hero := fs.Int("hero", 1, "the shoving player (1-based)")
+	caller := fs.Int("caller", 2, "the player left to act (1-based)")
+	callRange := fs.String("call", "", "the caller's calling range, e.g. \"77+,ATs+,AJo+\"")
+	trials := fs.Int("trials", 500, "Monte Carlo deals per starting hand")
+	seed := fs.Int64("seed", 0, "random seed for reproducible results (0 = random)")
+	format := fs.String("format", "text", "output format: text or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q (valid options: text, json)", *format)
+	}
+	if *stacksFlag == "" || *payoutsFlag == "" || *callRange == "" {
+		return fmt.Errorf("-stacks, -payouts and -call are required")
+	}
+
+	spot := icm.Spot{Hero: *hero - 1, Caller: *caller - 1}
+	var err error
+	if spot.Stacks, err = parseInts(*stacksFlag); err != nil {
+		return fmt.Errorf("stacks: %w", err)
+	}
+	if *postedFlag != "" {
+		if spot.Posted, err = parseInts(*postedFlag); err != nil {
+			return fmt.Errorf("posted: %w", err)
+		}
+	}
+	if spot.Payouts, err = parseFloats(*payoutsFlag); err != nil {
+		return fmt.Errorf("payouts: %w", err)
+	}
+	if spot.CallRange, err = equity.ParseRange(*callRange); err != nil {
+		return fmt.Errorf("call: %w", err)
+	}
+
+	chart, err := icm.PushFold(spot, equity.Options{Trials: *trials, Seed: *seed})
+	if err != nil {
+		return err
+	}
+	if *format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(chart)
+	}
+	fmt.Fprintf(out, "Player %d shoves into player %d calling %s (fold EV %.3f)\n", *hero, *caller, *callRange, chart.FoldEV)
+	return chart.WriteText(out)
+}
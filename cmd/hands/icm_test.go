@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/icm"
+)
+
+func TestRunICM(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, runICM([]string{"-stacks", "5000,3000,2000", "-payouts", "50,30,20"}, &buf))
+	out := buf.String()
+	assert.Regexp(t, `1\s+5000\s+50\.00%\s+38\.39\s+38\.39%`, out)
+	assert.Contains(t, out, "Equity%")
+}
+
+func TestRunICMErrors(t *testing.T) {
+	tests := [][]string{
+		{"-stacks", "100,200"},
+		{"-stacks", "100,abc", "-payouts", "1"},
+		{"-stacks", "100,200", "-payouts", "1,x"},
+		{"-stacks", "100,-5", "-payouts", "1"},
+	}
+	for _, args := range tests {
+		var buf bytes.Buffer
+		assert.Error(t, runICM(args, &buf), "%v", args)
+	}
+}
+
+func TestRunPushFold(t *testing.T) {
+	args := []string{"-stacks", "300,300", "-posted", "10,20", "-payouts", "1", "-call", "QQ+", "-trials", "50", "-seed", "2"}
+	var buf bytes.Buffer
+	require.NoError(t, runPushFold(args, &buf))
+	assert.Contains(t, buf.String(), "Player 1 shoves into player 2 calling QQ+")
+	assert.Contains(t, buf.String(), "AA")
+
+	buf.Reset()
+	require.NoError(t, runPushFold(append(args, "-format", "json"), &buf))
+	var c icm.Chart
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &c))
+	assert.True(t, c.Cells[0][0].Push)
+	assert.Equal(t, 50, c.Trials)
+}
+
+func TestRunPushFoldErrors(t *testing.T) {
+	tests := [][]string{
+		{"-stacks", "300,300", "-payouts", "1"},
+		{"-stacks", "300,300", "-payouts", "1", "-call", "QX"},
+		{"-stacks", "300,300", "-payouts", "1", "-call", "QQ", "-hero", "2", "-caller", "2"},
+		{"-stacks", "300,300", "-payouts", "1", "-call", "QQ", "-format", "xml"},
+		{"-stacks", "300,300", "-posted", "a", "-payouts", "1", "-call", "QQ"},
+	}
+	for _, args := range tests {
+		var buf bytes.Buffer
+		assert.Error(t, runPushFold(args, &buf), "%v", args)
+	}
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 
@@ -11,31 +13,6 @@ import (
 	"github.com/dangogh/GoPoker/hand"
 )
 
-func categoryName(c hand.Category) string {
-	switch c {
-	case hand.HighCard:
-		return "High Card"
-	case hand.OnePair:
-		return "One Pair"
-	case hand.TwoPair:
-		return "Two Pair"
-	case hand.ThreeOfKind:
-		return "Three of a Kind"
-	case hand.Straight:
-		return "Straight"
-	case hand.Flush:
-		return "Flush"
-	case hand.FullHouse:
-		return "Full House"
-	case hand.FourOfKind:
-		return "Four of a Kind"
-	case hand.StraightFlush:
-		return "Straight Flush"
-	default:
-		return "Unknown"
-	}
-}
-
 // performDraw takes current cards, asks hand.RecommendDiscards for up to maxDiscard indices,
 // draws replacements from the deck and returns the updated cards, the cards that were discarded,
 // and the cards that were drawn.
@@ -67,6 +44,16 @@ func performDraw(d *deck.Deck, cs []cards.Card, maxDiscard int) ([]cards.Card, [
 	return cs, discarded, repl, nil
 }
 
+// strengthNote explains how strong a final hand is, e.g. " (rank 3326 of 7462, beats 92.4%
+// of hands)". It is empty if the hand cannot be ranked.
+func strengthNote(e hand.EvaluatedHand) string {
+	st, err := hand.StrengthOf(e)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf(" (rank %d of %d, beats %.1f%% of hands)", st.Rank, hand.DistinctHands, 100*st.Beats)
+}
+
 func printCards(cs []cards.Card) {
 	for i, c := range cs {
 		if i > 0 {
@@ -142,7 +129,7 @@ func run(players int) error {
 	fmt.Println()
 	fmt.Println("Final hands:")
 	for i := 0; i < players; i++ {
-		fmt.Printf("Player %d: %s\n", i+1, categoryName(evals[i].Category))
+		fmt.Printf("Player %d: %s%s\n", i+1, hand.Describe(evals[i]), strengthNote(evals[i]))
 		printCards(hands[i])
 	}
 
@@ -170,7 +157,35 @@ func run(players int) error {
 	return nil
 }
 
+// subcommands maps the first argument to a handler; with no subcommand the
+// original draw simulation runs so existing invocations keep working.
+var subcommands = map[string]func(args []string, out io.Writer) error{
+	"arena":     runArena,
+	"duplicate": runDuplicate,
+	"equity":    runEquity,
+	"heatmap":   runHeatmap,
+	"icm":       runICM,
+	"outs":      runOuts,
+	"play":      runPlay,
+	"pushfold":  runPushFold,
+	"session":   runSession,
+	"solve":     runSolve,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := subcommands[os.Args[1]]; ok {
+			if err := cmd(os.Args[2:], os.Stdout); err != nil {
+				if errors.Is(err, flag.ErrHelp) {
+					os.Exit(0)
+				}
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	players := flag.Int("players", 5, "number of players (each dealt 5 cards)")
 	flag.Parse()
 
@@ -14,24 +14,6 @@ import (
 	"github.com/dangogh/GoPoker/hand"
 )
 
-func TestCategoryName(t *testing.T) {
-	tests := map[hand.Category]string{
-		hand.HighCard:      "High Card",
-		hand.OnePair:       "One Pair",
-		hand.TwoPair:       "Two Pair",
-		hand.ThreeOfKind:   "Three of a Kind",
-		hand.Straight:      "Straight",
-		hand.Flush:         "Flush",
-		hand.FullHouse:     "Full House",
-		hand.FourOfKind:    "Four of a Kind",
-		hand.StraightFlush: "Straight Flush",
-	}
-	for cat, want := range tests {
-		got := categoryName(cat)
-		assert.Equal(t, want, got, "categoryName(%v)", cat)
-	}
-}
-
 func TestPerformDraw_NoDiscard(t *testing.T) {
 	// Full house: keep (no discards)
 	cs := []cards.Card{
@@ -265,3 +247,29 @@ func TestRunTieBetweenPlayers(t *testing.T) {
 		t.Log("Note: No tie occurred in 10 attempts (expected with random shuffling)")
 	}
 }
+
+func TestStrengthNote(t *testing.T) {
+	cs, err := cards.ParseList("Ac Ad Kh Qs Jc")
+	assert.NoError(t, err)
+	note := strengthNote(hand.Evaluate(hand.Hand{Cards: cs}))
+	assert.Equal(t, " (rank 3326 of 7462, beats 92.4% of hands)", note)
+
+	assert.Empty(t, strengthNote(hand.EvaluatedHand{Category: hand.Flush}))
+}
+
+func TestRunDescribesFinalHands(t *testing.T) {
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := run(2)
+
+	w.Close()
+	os.Stdout = old
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	assert.NoError(t, err)
+	// every final hand is described in words with its strength, never as a bare category
+	assert.Regexp(t, `Player 1: [A-Z][a-z]+.* \(rank \d+ of 7462, beats`, buf.String())
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/hand"
+	"github.com/dangogh/GoPoker/outs"
+)
+
+// runOuts implements "hands outs": the outs and draw probabilities for a hand.
+func runOuts(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("outs", flag.ContinueOnError)
+	fs.SetOutput(out)
+	held := fs.String("hand", "", "cards held, e.g. \"As Ad Kc 7h 2d\"")
+	discard := fs.String("discard", "", "cards to throw away before drawing, or \"auto\" for the recommended discards")
+	board := fs.String("board", "", "community cards")
+	dead := fs.String("dead", "", "other cards that cannot be drawn")
+	draw := fs.Int("draw", -1, "cards to draw (default: one per discard)")
+	format := fs.String("format", "text", "output format: text or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q (valid options: text, json)", *format)
+	}
+
+	cs, err := cards.ParseList(*held)
+	if err != nil {
+		return fmt.Errorf("hand: %w", err)
+	}
+	if len(cs) == 0 {
+		return fmt.Errorf("-hand is required")
+	}
+	opts := outs.Options{}
+	if opts.Board, err = cards.ParseList(*board); err != nil {
+		return fmt.Errorf("board: %w", err)
+	}
+	if opts.Dead, err = cards.ParseList(*dead); err != nil {
+		return fmt.Errorf("dead: %w", err)
+	}
+
+	thrown, err := discardCards(cs, *discard)
+	if err != nil {
+		return err
+	}
+	keep := make([]cards.Card, 0, len(cs))
+	drop := make(map[cards.Card]bool, len(thrown))
+	for _, c := range thrown {
+		drop[c] = true
+	}
+	for _, c := range cs {
+		if !drop[c] {
+			keep = append(keep, c)
+		}
+	}
+	opts.Dead = append(opts.Dead, thrown...)
+	opts.Draw = *draw
+	if opts.Draw < 0 {
+		opts.Draw = len(thrown)
+	}
+
+	a, err := outs.Analyze(keep, opts)
+	if err != nil {
+		return err
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(a)
+	}
+	printAnalysis(out, keep, a)
+	return nil
+}
+
+// discardCards resolves the -discard flag to the cards being thrown away.
+func discardCards(cs []cards.Card, spec string) ([]cards.Card, error) {
+	if strings.EqualFold(spec, "auto") {
+		h := hand.Hand{Cards: cs}
+		var thrown []cards.Card
+		for _, idx := range hand.RecommendDiscards(h, hand.ComputeMaxDiscard(h)) {
+			thrown = append(thrown, cs[idx])
+		}
+		return thrown, nil
+	}
+	thrown, err := cards.ParseList(spec)
+	if err != nil {
+		return nil, fmt.Errorf("discard: %w", err)
+	}
+	held := make(map[cards.Card]bool, len(cs))
+	for _, c := range cs {
+		held[c] = true
+	}
+	for _, c := range thrown {
+		if !held[c] {
+			return nil, fmt.Errorf("discard: %s is not in the hand", c)
+		}
+	}
+	return thrown, nil
+}
+
+func printAnalysis(out io.Writer, kept []cards.Card, a outs.Analysis) {
+	fmt.Fprint(out, "Keeping: ")
+	for i, c := range kept {
+		if i > 0 {
+			fmt.Fprint(out, " ")
+		}
+		fmt.Fprint(out, c.String())
+	}
+	fmt.Fprintf(out, " (%s)\n", a.CurrentName)
+	fmt.Fprintf(out, "Drawing %d from %d unseen cards (%d combinations)\n", a.Draw, a.Unseen, a.Combinations)
+	for _, t := range a.Targets {
+		if t.Probability == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "%-16s %6.2f%% (exactly %6.2f%%)  %d outs: %s\n",
+			t.Name, 100*t.Probability, 100*t.Exact, len(t.Outs), strings.Join(t.Outs, " "))
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/outs"
+)
+
+func TestRunOutsText(t *testing.T) {
+	var buf bytes.Buffer
+	err := runOuts([]string{"-hand", "Ah Kh 9h 4h 2c", "-discard", "2c"}, &buf)
+	require.NoError(t, err)
+	out := buf.String()
+	assert.Contains(t, out, "Keeping: A♥ K♥ 9♥ 4♥ (High Card)")
+	assert.Contains(t, out, "Drawing 1 from 47 unseen cards")
+	assert.Regexp(t, `Flush\s+19\.15%.*9 outs`, out)
+	assert.NotContains(t, out, "Four of a Kind", "unreachable categories are omitted")
+}
+
+func TestRunOutsAutoJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := runOuts([]string{"-hand", "As Ad Kc 7h 2d", "-discard", "auto", "-format", "json"}, &buf)
+	require.NoError(t, err)
+
+	var a outs.Analysis
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &a))
+	assert.Equal(t, "One Pair", a.CurrentName)
+	assert.Equal(t, 3, a.Draw)
+	assert.Equal(t, 16215, a.Combinations)
+}
+
+func TestRunOutsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"missing hand", []string{}},
+		{"discard not held", []string{"-hand", "As Ad", "-discard", "Kc"}},
+		{"bad board", []string{"-hand", "As Ad", "-board", "Zz"}},
+		{"bad format", []string{"-hand", "As Ad", "-format", "xml"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			assert.Error(t, runOuts(tc.args, &buf))
+		})
+	}
+}
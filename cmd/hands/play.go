@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dangogh/GoPoker/bot"
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/game"
+	"github.com/dangogh/GoPoker/hand"
+	"github.com/dangogh/GoPoker/table"
+)
+
+// stdin is where "hands play" reads the human's decisions; tests replace it.
+var stdin io.Reader = os.Stdin
+
+// errQuit ends an interactive session at the human's request (or at end of input).
+var errQuit = errors.New("quit")
+
+// humanPlayer asks the person at the terminal for each decision.
+type humanPlayer struct {
+	in  *bufio.Scanner
+	out io.Writer
+}
+
+func (h *humanPlayer) readLine(prompt string) (string, error) {
+	fmt.Fprint(h.out, prompt)
+	if !h.in.Scan() {
+		return "", errQuit
+	}
+	line := strings.TrimSpace(h.in.Text())
+	if strings.EqualFold(line, "q") || strings.EqualFold(line, "quit") {
+		return "", errQuit
+	}
+	return line, nil
+}
+
+func (h *humanPlayer) showHand(v game.View) {
+	fmt.Fprintf(h.out, "Your cards:")
+	for i, c := range v.Hand {
+		fmt.Fprintf(h.out, " %d:%s", i+1, c)
+	}
+	fmt.Fprintf(h.out, "  (%s)\n", hand.Describe(hand.Evaluate(hand.Hand{Cards: v.Hand})))
+}
+
+func (h *humanPlayer) Act(v game.View) (game.Action, error) {
+	h.showHand(v)
+	fmt.Fprintf(h.out, "Pot %d, your stack %d, to call %d.\n", v.Pot, v.Stack, v.ToCall)
+	options := "[c]heck"
+	if v.ToCall > 0 {
+		options = "[f]old, [c]all"
+	}
+	if v.MinRaise > 0 {
+		verb := "[r]aise"
+		if v.ToCall == 0 && v.Bets[v.Seat] == 0 {
+			verb = "[b]et"
+		}
+		options += fmt.Sprintf(", %s <total %d-%d>", verb, v.MinRaise, v.Bets[v.Seat]+v.Stack)
+	}
+	for {
+		line, err := h.readLine(options + ": ")
+		if err != nil {
+			return game.Action{}, err
+		}
+		if a, ok := parseAction(line, v); ok {
+			return a, nil
+		}
+		fmt.Fprintln(h.out, "Sorry, I didn't understand that.")
+	}
+}
+
+// parseAction reads "f", "c", "k", "call", "b 40", "r 60", "raise 60" or "allin".
+func parseAction(line string, v game.View) (game.Action, bool) {
+	fields := strings.Fields(strings.ToLower(line))
+	if len(fields) == 0 {
+		return game.Action{}, false
+	}
+	amount := 0
+	if len(fields) > 1 {
+		n, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return game.Action{}, false
+		}
+		amount = n
+	}
+	switch fields[0] {
+	case "f", "fold":
+		return game.Action{Kind: game.Fold}, true
+	case "c", "k", "call", "check":
+		if v.ToCall == 0 {
+			return game.Action{Kind: game.Check}, true
+		}
+		return game.Action{Kind: game.Call}, true
+	case "b", "bet", "r", "raise":
+		if v.MinRaise == 0 || len(fields) != 2 {
+			return game.Action{}, false
+		}
+		return game.Action{Kind: game.Raise, Amount: amount}, true
+	case "a", "allin", "all-in":
+		if v.MinRaise == 0 {
+			return game.Action{Kind: game.Call}, true
+		}
+		return game.Action{Kind: game.Raise, Amount: v.Bets[v.Seat] + v.Stack}, true
+	}
+	return game.Action{}, false
+}
+
+func (h *humanPlayer) Discard(v game.View) ([]int, error) {
+	h.showHand(v)
+	limit := hand.ComputeMaxDiscard(hand.Hand{Cards: v.Hand})
+	for {
+		line, err := h.readLine(fmt.Sprintf("Discard up to %d (positions like \"1 3\" or cards like \"Kd 7h\", blank to stand pat): ", limit))
+		if err != nil {
+			return nil, err
+		}
+		idxs, err := parseDiscards(line, v.Hand)
+		switch {
+		case err != nil:
+		case len(idxs) > limit:
+			err = fmt.Errorf("you may discard at most %d", limit)
+		case len(idxs) > 3 && !hand.KeepsAce(hand.Hand{Cards: v.Hand}, idxs):
+			err = fmt.Errorf("you may discard four only when keeping an ace")
+		default:
+			return idxs, nil
+		}
+		fmt.Fprintln(h.out, err)
+	}
+}
+
+// parseDiscards accepts 1-based positions or card names and returns 0-based indexes.
+func parseDiscards(line string, held []cards.Card) ([]int, error) {
+	var idxs []int
+	seen := map[int]bool{}
+	for _, f := range strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == ' ' }) {
+		idx := -1
+		if n, err := strconv.Atoi(f); err == nil {
+			idx = n - 1
+		} else if c, err := cards.Parse(f); err == nil {
+			for i, hc := range held {
+				if hc == c {
+					idx = i
+				}
+			}
+		}
+		if idx < 0 || idx >= len(held) {
+			return nil, fmt.Errorf("%q is not one of your cards", f)
+		}
+		if !seen[idx] {
+			seen[idx] = true
+			idxs = append(idxs, idx)
+		}
+	}
+	return idxs, nil
+}
+
+// runPlay implements "hands play": the user takes seat 0 against bot opponents.
+// Stacks carry over from hand to hand until the user quits or someone wins every chip.
+func runPlay(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("play", flag.ContinueOnError)
+	fs.SetOutput(out)
+	bots := fs.Int("bots", 3, "number of bot opponents")
+	style := fs.String("style", "basic", "comma-separated bot styles, assigned to seats in turn: "+strings.Join(bot.Kinds(), ", "))
+	stack := fs.Int("stack", 200, "starting chips per player")
+	ante := fs.Int("ante", 0, "ante per player")
+	sb := fs.Int("sb", 1, "small blind")
+	bb := fs.Int("bb", 2, "big blind")
+	name := fs.String("name", "Hero", "your name at the table")
+	seed := fs.Int64("seed", 0, "random seed for reproducible shuffles (0 = random)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *bots < 1 || *bots > game.MaxSeats-1 {
+		return fmt.Errorf("bots must be between 1 and %d", game.MaxSeats-1)
+	}
+	if *stack <= 0 {
+		return fmt.Errorf("stack must be > 0")
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	opponents, err := newBots(*style, *bots, *seed)
+	if err != nil {
+		return err
+	}
+
+	human := &humanPlayer{in: bufio.NewScanner(stdin), out: out}
+	tb := table.New(game.Config{Ante: *ante, SmallBlind: *sb, BigBlind: *bb, Observer: func(e game.Event) {
+		// the human reads their own cards at each prompt; opponents' cards stay hidden
+		if e.Kind != game.EventDeal {
+			fmt.Fprintln(out, e.String())
+		}
+	}}, rand.New(rand.NewSource(*seed)))
+	if _, err := tb.Sit(0, *name, *stack, human); err != nil {
+		return err
+	}
+	for i, p := range opponents {
+		if _, err := tb.Sit(i+1, fmt.Sprintf("Bot %d", i+1), *stack, p); err != nil {
+			return err
+		}
+	}
+
+	for {
+		fmt.Fprintf(out, "\n=== Hand %d ===\n", tb.Hands()+1)
+		_, err := tb.PlayHand()
+		if errors.Is(err, errQuit) {
+			fmt.Fprintln(out, "\nHand abandoned; bets are returned.")
+			break
+		}
+		if err != nil {
+			return err
+		}
+		printStacks(out, tb.History()[tb.Hands()-1])
+
+		if tb.Seat(*name) < 0 {
+			fmt.Fprintln(out, "You are out of chips.")
+			break
+		}
+		if tb.Active() < 2 {
+			fmt.Fprintln(out, "You won every chip!")
+			break
+		}
+		line, err := human.readLine("Deal another hand? [Y/n] ")
+		if err != nil || strings.HasPrefix(strings.ToLower(line), "n") {
+			break
+		}
+	}
+	if tb.Hands() > 0 {
+		fmt.Fprintln(out, "\nStacks by hand:")
+		return tb.WriteHistory(out)
+	}
+	return nil
+}
+
+// printStacks lists the stacks after a hand by player name.
+func printStacks(out io.Writer, snap table.Snapshot) {
+	names := make([]string, 0, len(snap.Stacks))
+	for name := range snap.Stacks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "  %-6s %d\n", name, snap.Stacks[name])
+	}
+}
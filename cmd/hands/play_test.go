@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/game"
+)
+
+func TestParseAction(t *testing.T) {
+	facing := game.View{Seat: 0, ToCall: 2, MinRaise: 4, Stack: 100, Bets: []int{0, 2}}
+	open := game.View{Seat: 0, MinRaise: 2, Stack: 100, Bets: []int{0, 0}}
+	capped := game.View{Seat: 0, ToCall: 50, Stack: 40, Bets: []int{0, 50}}
+	tests := []struct {
+		line string
+		v    game.View
+		want game.Action
+		ok   bool
+	}{
+		{"f", facing, game.Action{Kind: game.Fold}, true},
+		{"call", facing, game.Action{Kind: game.Call}, true},
+		{"k", open, game.Action{Kind: game.Check}, true},
+		{"c", open, game.Action{Kind: game.Check}, true},
+		{"r 10", facing, game.Action{Kind: game.Raise, Amount: 10}, true},
+		{"bet 6", open, game.Action{Kind: game.Raise, Amount: 6}, true},
+		{"allin", facing, game.Action{Kind: game.Raise, Amount: 100}, true},
+		{"allin", capped, game.Action{Kind: game.Call}, true},
+		{"r", facing, game.Action{}, false},
+		{"r ten", facing, game.Action{}, false},
+		{"r 60", capped, game.Action{}, false},
+		{"dance", facing, game.Action{}, false},
+		{"", facing, game.Action{}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.line, func(t *testing.T) {
+			got, ok := parseAction(tc.line, tc.v)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestHumanDiscardLimits(t *testing.T) {
+	held, err := cards.ParseList("Ah Kd 9c 5s 2c")
+	require.NoError(t, err)
+	var out bytes.Buffer
+	h := &humanPlayer{in: bufio.NewScanner(strings.NewReader("1 2 3 4 5\n1 2 3 4\n2 3 4 5\n")), out: &out}
+	got, err := h.Discard(game.View{Hand: held})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4}, got)
+	assert.Contains(t, out.String(), "you may discard at most 4")
+	assert.Contains(t, out.String(), "you may discard four only when keeping an ace")
+}
+
+func TestParseDiscards(t *testing.T) {
+	held, err := cards.ParseList("As Kd 7h 7c 2s")
+	require.NoError(t, err)
+
+	got, err := parseDiscards("1 3", held)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 2}, got)
+
+	got, err = parseDiscards("Kd,2s,2", held)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 4}, got, "duplicates are dropped")
+
+	got, err = parseDiscards("", held)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = parseDiscards("6", held)
+	assert.Error(t, err)
+	_, err = parseDiscards("Qh", held)
+	assert.Error(t, err)
+}
+
+func TestRunPlayFoldsAndQuits(t *testing.T) {
+	old := stdin
+	defer func() { stdin = old }()
+	// fold the first hand, then decline another
+	stdin = strings.NewReader("f\nn\n")
+
+	var buf bytes.Buffer
+	require.NoError(t, runPlay([]string{"-bots", "2", "-stack", "50", "-seed", "7"}, &buf))
+	out := buf.String()
+	assert.Contains(t, out, "=== Hand 1")
+	assert.Contains(t, out, "Your cards: 1:")
+	assert.Contains(t, out, "posts blind")
+	assert.Contains(t, out, "Stacks by hand:")
+	assert.NotContains(t, out, "=== Hand 2")
+
+	// the history row ends with one stack per player
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	fields := strings.Fields(lines[len(lines)-1])
+	total := 0
+	for _, f := range fields[len(fields)-3:] {
+		chips, err := strconv.Atoi(f)
+		require.NoError(t, err)
+		total += chips
+	}
+	assert.Equal(t, 150, total, "chips are conserved")
+}
+
+func TestRunPlayEndOfInputQuits(t *testing.T) {
+	old := stdin
+	defer func() { stdin = old }()
+	stdin = strings.NewReader("")
+
+	var buf bytes.Buffer
+	require.NoError(t, runPlay([]string{"-bots", "1", "-seed", "3"}, &buf))
+	assert.NotContains(t, buf.String(), "Stacks by hand:", "no hand was finished")
+}
+
+func TestRunPlayRejectsBadFlags(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Error(t, runPlay([]string{"-bots", "0"}, &buf))
+	assert.Error(t, runPlay([]string{"-bots", "8"}, &buf))
+	assert.Error(t, runPlay([]string{"-stack", "0"}, &buf))
+}
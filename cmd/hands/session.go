@@ -0,0 +1,89 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/dangogh/GoPoker/bot"
+	"github.com/dangogh/GoPoker/game"
+	"github.com/dangogh/GoPoker/table"
+)
+
+// runSession implements "hands session": bots play consecutive hands at one table,
+// with the button moving and stacks carried over, and the stack history is printed.
+func runSession(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("session", flag.ContinueOnError)
+	fs.SetOutput(out)
+	players := fs.Int("players", 4, "number of bots at the table")
+	style := fs.String("style", "basic", "comma-separated bot styles, assigned to seats in turn: "+strings.Join(bot.Kinds(), ", "))
+	hands := fs.Int("hands", 20, "hands to play (0 = until one player has every chip)")
+	stack := fs.Int("stack", 200, "starting chips per player")
+	ante := fs.Int("ante", 0, "ante per player")
+	sb := fs.Int("sb", 1, "small blind")
+	bb := fs.Int("bb", 2, "big blind")
+	seed := fs.Int64("seed", 0, "random seed for reproducible sessions (0 = random)")
+	verbose := fs.Bool("v", false, "print every hand's action")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *players < 2 || *players > game.MaxSeats {
+		return fmt.Errorf("players must be between 2 and %d", game.MaxSeats)
+	}
+	if *stack <= 0 {
+		return fmt.Errorf("stack must be > 0")
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	bots, err := newBots(*style, *players, *seed)
+	if err != nil {
+		return err
+	}
+
+	cfg := game.Config{Ante: *ante, SmallBlind: *sb, BigBlind: *bb}
+	if *verbose {
+		cfg.Observer = func(e game.Event) {
+			if e.Kind == game.EventDeal {
+				return
+			}
+			fmt.Fprintln(out, e.String())
+		}
+	}
+	tb := table.New(cfg, rand.New(rand.NewSource(*seed)))
+	for i, p := range bots {
+		if _, err := tb.Sit(-1, fmt.Sprintf("Bot%d", i+1), *stack, p); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tb.Run(*hands); err != nil {
+		return err
+	}
+	if err := tb.WriteHistory(out); err != nil {
+		return err
+	}
+	for _, b := range tb.Busts() {
+		fmt.Fprintf(out, "%s busted on hand %d\n", b.Name, b.Hand)
+	}
+	return nil
+}
+
+// newBots builds n bots from a comma-separated list of styles, repeating the list as
+// needed. Each bot gets its own seed so randomized styles do not play in lockstep.
+func newBots(styles string, n int, seed int64) ([]bot.Player, error) {
+	kinds := strings.Split(styles, ",")
+	out := make([]bot.Player, n)
+	for i := range out {
+		p, err := bot.New(strings.TrimSpace(kinds[i%len(kinds)]), seed+int64(i))
+		if err != nil {
+			return nil, err
+		}
+		out[i] = p
+	}
+	return out, nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSession(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, runSession([]string{"-players", "3", "-hands", "10", "-stack", "100", "-seed", "5"}, &buf))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Regexp(t, `^\s*Hand\s+Button\s+Bot1\s+Bot2\s+Bot3$`, lines[0])
+
+	rows := 0
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) != 5 {
+			continue // bust notes
+		}
+		rows++
+		total := 0
+		for _, f := range fields[2:] {
+			if n, err := strconv.Atoi(f); err == nil {
+				total += n
+			}
+		}
+		assert.Equal(t, 300, total, "chips are conserved: %s", line)
+	}
+	assert.LessOrEqual(t, rows, 10)
+	assert.Positive(t, rows)
+
+	var again bytes.Buffer
+	require.NoError(t, runSession([]string{"-players", "3", "-hands", "10", "-stack", "100", "-seed", "5"}, &again))
+	assert.Equal(t, buf.String(), again.String(), "a seed replays the session")
+}
+
+func TestRunSessionVerbose(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, runSession([]string{"-players", "2", "-hands", "1", "-seed", "1", "-v"}, &buf))
+	assert.Contains(t, buf.String(), "posts blind")
+}
+
+func TestRunSessionMixedStyles(t *testing.T) {
+	args := []string{"-players", "4", "-hands", "5", "-seed", "2", "-style", "lag, tp,equity"}
+	var buf, again bytes.Buffer
+	require.NoError(t, runSession(args, &buf))
+	require.NoError(t, runSession(args, &again))
+	assert.Equal(t, buf.String(), again.String(), "randomized styles replay from the seed")
+}
+
+func TestRunSessionRejectsBadFlags(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Error(t, runSession([]string{"-players", "1"}, &buf))
+	assert.Error(t, runSession([]string{"-players", "9"}, &buf))
+	assert.Error(t, runSession([]string{"-stack", "-5"}, &buf))
+	assert.ErrorContains(t, runSession([]string{"-style", "basic,shark"}, &buf), "unknown bot")
+}
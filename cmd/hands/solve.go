@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/dangogh/GoPoker/cfr"
+)
+
+// solveReport is the JSON output of "hands solve".
+type solveReport struct {
+	Game           string         `json:"game"`
+	Iterations     int            `json:"iterations"`
+	Exploitability float64        `json:"exploitability"`
+	Value          float64        `json:"value"`
+	Agreement      *cfr.Agreement `json:"recommend_discards_agreement,omitempty"`
+	Strategy       cfr.Strategy   `json:"strategy"`
+}
+
+// runSolve implements "hands solve": CFR on Kuhn, Leduc or the abstract draw game.
+func runSolve(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("solve", flag.ContinueOnError)
+	fs.SetOutput(out)
+	name := fs.String("game", "kuhn", "game to solve: kuhn, leduc or draw")
+	iterations := fs.Int("iterations", 1000, "CFR iterations")
+	deals := fs.Int("deals", 50, "pre-sampled deals in the draw game")
+	seed := fs.Int64("seed", 1, "seed for sampling the draw game's deals")
+	format := fs.String("format", "text", "output format: text or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q (valid options: text, json)", *format)
+	}
+	if *iterations <= 0 {
+		return fmt.Errorf("iterations must be > 0")
+	}
+
+	var g cfr.Game
+	var draw *cfr.DrawGame
+	switch *name {
+	case "kuhn":
+		g = cfr.Kuhn{}
+	case "leduc":
+		g = cfr.Leduc{}
+	case "draw":
+		var err error
+		if draw, err = cfr.NewDrawGame(*deals, *seed); err != nil {
+			return err
+		}
+		g = draw
+	default:
+		return fmt.Errorf("unknown game %q (valid options: kuhn, leduc, draw)", *name)
+	}
+
+	s := cfr.NewSolver(g)
+	s.Run(*iterations)
+	strat := s.Strategy()
+	rep := solveReport{
+		Game: *name, Iterations: s.Iterations(), Strategy: strat,
+		Exploitability: cfr.Exploitability(g, strat), Value: cfr.Value(g, strat),
+	}
+	if draw != nil {
+		a := draw.DiscardAgreement(strat)
+		rep.Agreement = &a
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rep)
+	}
+	if err := strat.WriteText(out); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "\n%s after %d iterations: exploitability %.5f, value to first player %.5f\n",
+		rep.Game, rep.Iterations, rep.Exploitability, rep.Value)
+	if rep.Agreement != nil {
+		fmt.Fprintf(out, "Draws matching hand.RecommendDiscards: %.1f%%\n", 100*rep.Agreement.Overall)
+		buckets := make([]string, 0, len(rep.Agreement.ByBucket))
+		for b := range rep.Agreement.ByBucket {
+			buckets = append(buckets, b)
+		}
+		sort.Strings(buckets)
+		for _, b := range buckets {
+			fmt.Fprintf(out, "  %-9s %5.1f%%\n", b, 100*rep.Agreement.ByBucket[b])
+		}
+	}
+	return nil
+}
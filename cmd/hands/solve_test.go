@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSolveKuhn(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, runSolve([]string{"-game", "kuhn", "-iterations", "500"}, &buf))
+	out := buf.String()
+	assert.Contains(t, out, "K:b")
+	assert.Regexp(t, `kuhn after 500 iterations: exploitability 0\.0\d+, value to first player -0\.05\d+`, out)
+}
+
+func TestRunSolveDrawJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, runSolve([]string{"-game", "draw", "-deals", "10", "-iterations", "50", "-format", "json"}, &buf))
+	var rep solveReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &rep))
+	assert.Equal(t, "draw", rep.Game)
+	require.NotNil(t, rep.Agreement)
+	assert.NotEmpty(t, rep.Strategy)
+}
+
+func TestRunSolveErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"-game", "holdem"},
+		{"-iterations", "0"},
+		{"-format", "xml"},
+		{"-game", "draw", "-deals", "0"},
+	} {
+		var buf bytes.Buffer
+		assert.Error(t, runSolve(args, &buf), "%v", args)
+	}
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// minKeyLength keeps guessable keys out of the key list.
+const minKeyLength = 16
+
+// clientIdle is how long a client's rate limiter is kept after its last request.
+const clientIdle = 10 * time.Minute
+
+// security is what guards /mcp over HTTP. The zero value checks origins only.
+type security struct {
+	// keys maps the SHA-256 of each API key to its name. Looking up the hash rather
+	// than the key means the time a lookup takes says nothing about the key.
+	// Empty disables authentication.
+	keys map[[sha256.Size]byte]string
+	// origins are the browser origins allowed to call the server. Empty allows
+	// loopback origins only; "*" allows any.
+	origins []string
+	// rate is requests per second for each key, or each client address when
+	// authentication is off. 0 is unlimited.
+	rate  rate.Limit
+	burst int
+}
+
+// loadAPIKeys reads keys from a comma-separated list and from a file with one key per
+// line, optionally preceded by a name: "alice 3f1c...". Blank lines and lines starting
+// with # are skipped. Unnamed keys are called key-1, key-2 and so on.
+func loadAPIKeys(list, file string) (map[[sha256.Size]byte]string, error) {
+	keys := map[[sha256.Size]byte]string{}
+	names := map[string]bool{}
+	add := func(name, key string) error {
+		if name == "" {
+			name = fmt.Sprintf("key-%d", len(keys)+1)
+		}
+		if len(key) < minKeyLength {
+			return fmt.Errorf("api key %q is shorter than %d characters", name, minKeyLength)
+		}
+		sum := sha256.Sum256([]byte(key))
+		if _, dup := keys[sum]; dup {
+			return fmt.Errorf("api key %q is listed twice", name)
+		}
+		if names[name] {
+			return fmt.Errorf("api key name %q is used twice", name)
+		}
+		keys[sum], names[name] = name, true
+		return nil
+	}
+
+	for _, key := range strings.Split(list, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			if err := add("", key); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if file == "" {
+		return keys, nil
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("api keys: %w", err)
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		var err error
+		switch len(fields) {
+		case 1:
+			err = add("", fields[0])
+		case 2:
+			err = add(fields[0], fields[1])
+		default:
+			err = fmt.Errorf("want a key or a name and a key")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", file, line, err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// guard applies a security policy to a handler and keeps the per-client limiters.
+type guard struct {
+	security
+	next http.Handler
+	now  func() time.Time
+
+	mu        sync.Mutex
+	clients   map[string]*client
+	lastSweep time.Time
+}
+
+type client struct {
+	limiter *rate.Limiter
+	seen    time.Time
+}
+
+// keyNameHeader carries the name of the caller's API key to the tool handlers, which
+// see the request's headers but not its context.
+const keyNameHeader = "X-Gopoker-Key-Name"
+
+// protect wraps next in sec. Checks run cheapest first, and the origin check comes
+// before authentication because a browser's CORS preflight carries no credentials.
+func (sec security) protect(next http.Handler) http.Handler {
+	return &guard{security: sec, next: next, now: time.Now, clients: map[string]*client{}}
+}
+
+func (g *guard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// A page on a rebound DNS name reaches a loopback server as same-origin, but the
+	// browser still sends its Origin on the POSTs that carry MCP messages, and a stream
+	// cannot be opened without the session ID those return. Clients that are not
+	// browsers send no Origin at all.
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if !g.allowOrigin(origin) {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+		h := w.Header()
+		h.Set("Access-Control-Allow-Origin", origin)
+		h.Add("Vary", "Origin")
+		h.Set("Access-Control-Expose-Headers", "Mcp-Session-Id")
+		if r.Method == http.MethodOptions {
+			h.Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+			h.Set("Access-Control-Allow-Headers", "Authorization, X-API-Key, Content-Type, Accept, Mcp-Session-Id, Mcp-Protocol-Version, Last-Event-ID")
+			h.Set("Access-Control-Max-Age", "600")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	id := clientAddr(r)
+	r.Header.Del(keyNameHeader) // only the guard may say who the caller is
+	if len(g.keys) > 0 {
+		name, ok := g.keys[sha256.Sum256([]byte(presentedKey(r)))]
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="gopoker-mcp"`)
+			http.Error(w, "missing or invalid API key", http.StatusUnauthorized)
+			return
+		}
+		id = "key:" + name
+		r.Header.Set(keyNameHeader, name)
+	}
+
+	if wait, ok := g.allow(id); !ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+	g.next.ServeHTTP(w, r)
+}
+
+// presentedKey reads the key from "Authorization: Bearer" or X-API-Key.
+func presentedKey(r *http.Request) string {
+	if scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return r.Header.Get("X-API-Key")
+}
+
+func clientAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func (g *guard) allowOrigin(origin string) bool {
+	if len(g.origins) == 0 {
+		u, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+		switch u.Hostname() {
+		case "localhost", "127.0.0.1", "::1":
+			return true
+		}
+		return false
+	}
+	for _, o := range g.origins {
+		if o == "*" || strings.EqualFold(strings.TrimSuffix(o, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// allow takes a token from id's limiter, or says how long until one is available.
+func (g *guard) allow(id string) (time.Duration, bool) {
+	if g.rate <= 0 {
+		return 0, true
+	}
+	now := g.now()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if now.Sub(g.lastSweep) > clientIdle {
+		for k, c := range g.clients {
+			if now.Sub(c.seen) > clientIdle {
+				delete(g.clients, k)
+			}
+		}
+		g.lastSweep = now
+	}
+	c, ok := g.clients[id]
+	if !ok {
+		c = &client{limiter: rate.NewLimiter(g.rate, max(g.burst, 1))}
+		g.clients[id] = c
+	}
+	c.seen = now
+	res := c.limiter.ReserveN(now, 1)
+	if wait := res.DelayFrom(now); wait > 0 {
+		res.CancelAt(now)
+		return wait, false
+	}
+	return 0, true
+}
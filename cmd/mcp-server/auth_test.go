@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	aliceKey = "alice-0123456789abcdef"
+	bobKey   = "bob-0123456789abcdef"
+)
+
+func startSecureServer(t *testing.T, sec security) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(newHTTPHandler(newServer(serverOptions{}), httpOptions{sessionTimeout: time.Minute, security: sec}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func testKeys(t *testing.T) map[[sha256.Size]byte]string {
+	t.Helper()
+	keys, err := loadAPIKeys(aliceKey+","+bobKey, "")
+	require.NoError(t, err)
+	return keys
+}
+
+// send makes a request to /mcp with the given headers.
+func send(t *testing.T, url, method, body string, header map[string]string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, url+"/mcp", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestLoadAPIKeys(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "keys")
+	require.NoError(t, os.WriteFile(file, []byte("# deploy keys\n\nci "+bobKey+"\n  carol-0123456789abcdef  \n"), 0o600))
+
+	keys, err := loadAPIKeys(" "+aliceKey+", ", file)
+	require.NoError(t, err)
+	assert.Equal(t, map[[sha256.Size]byte]string{
+		sha256.Sum256([]byte(aliceKey)):                 "key-1",
+		sha256.Sum256([]byte(bobKey)):                   "ci",
+		sha256.Sum256([]byte("carol-0123456789abcdef")): "key-3",
+	}, keys)
+
+	keys, err = loadAPIKeys("", "")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	for _, tc := range []struct {
+		list, lines, want string
+	}{
+		{"short", "", "shorter than 16"},
+		{aliceKey + "," + aliceKey, "", "listed twice"},
+		{"", "ci " + aliceKey + "\nci " + bobKey, "name \"ci\" is used twice"},
+		{"", "ci " + aliceKey + " extra", "bad:1: want a key or a name and a key"},
+	} {
+		file := filepath.Join(dir, "bad")
+		require.NoError(t, os.WriteFile(file, []byte(tc.lines), 0o600))
+		_, err := loadAPIKeys(tc.list, file)
+		assert.ErrorContains(t, err, tc.want)
+	}
+	_, err = loadAPIKeys("", filepath.Join(dir, "missing"))
+	assert.ErrorContains(t, err, "api keys:")
+}
+
+func TestAuthRequiresKey(t *testing.T) {
+	srv := startSecureServer(t, security{keys: testKeys(t)})
+
+	for _, tc := range []struct {
+		name   string
+		header map[string]string
+		want   int
+	}{
+		{"no key", nil, http.StatusUnauthorized},
+		{"wrong key", map[string]string{"Authorization": "Bearer not-" + aliceKey}, http.StatusUnauthorized},
+		{"wrong scheme", map[string]string{"Authorization": "Basic " + aliceKey}, http.StatusUnauthorized},
+		{"bearer", map[string]string{"Authorization": "Bearer " + aliceKey}, http.StatusOK},
+		{"lowercase bearer", map[string]string{"Authorization": "bearer " + bobKey}, http.StatusOK},
+		{"api key header", map[string]string{"X-API-Key": bobKey}, http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := send(t, srv.URL, http.MethodPost, initializeRequest, tc.header)
+			assert.Equal(t, tc.want, resp.StatusCode)
+			if tc.want == http.StatusUnauthorized {
+				assert.Contains(t, resp.Header.Get("WWW-Authenticate"), "Bearer")
+			}
+		})
+	}
+
+	resp, err := http.Get(srv.URL + "/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "health checks need no key")
+}
+
+// bearer adds an API key to every request an MCP client makes.
+type bearer struct {
+	key  string
+	base http.RoundTripper
+}
+
+func (b bearer) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", "Bearer "+b.key)
+	return b.base.RoundTrip(r)
+}
+
+func TestAuthenticatedClient(t *testing.T) {
+	srv := startSecureServer(t, security{keys: testKeys(t)})
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	cs, err := client.Connect(context.Background(), &mcp.StreamableClientTransport{
+		Endpoint:   srv.URL + "/mcp",
+		HTTPClient: &http.Client{Transport: bearer{aliceKey, http.DefaultTransport}},
+	}, nil)
+	require.NoError(t, err)
+	defer cs.Close()
+
+	res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "evaluate_poker_hand",
+		Arguments: map[string]interface{}{"cards": []string{"As", "Ad", "Kc", "Kd", "2h"}},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, "Two Pair")
+}
+
+func TestOriginChecks(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		origins []string
+		origin  string
+		want    int
+	}{
+		{"no origin", nil, "", http.StatusOK},
+		{"localhost", nil, "http://localhost:3000", http.StatusOK},
+		{"loopback ip", nil, "http://127.0.0.1:5173", http.StatusOK},
+		{"ipv6 loopback", nil, "http://[::1]:8080", http.StatusOK},
+		{"rebound name", nil, "http://attacker.example", http.StatusForbidden},
+		{"opaque origin", nil, "null", http.StatusForbidden},
+		{"listed", []string{"https://app.example/"}, "https://APP.example", http.StatusOK},
+		{"unlisted", []string{"https://app.example"}, "http://localhost:3000", http.StatusForbidden},
+		{"wrong scheme", []string{"https://app.example"}, "http://app.example", http.StatusForbidden},
+		{"any", []string{"*"}, "https://elsewhere.example", http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := startSecureServer(t, security{origins: tc.origins})
+			header := map[string]string{}
+			if tc.origin != "" {
+				header["Origin"] = tc.origin
+			}
+			resp := send(t, srv.URL, http.MethodPost, initializeRequest, header)
+			assert.Equal(t, tc.want, resp.StatusCode)
+			if tc.want == http.StatusOK && tc.origin != "" {
+				assert.Equal(t, tc.origin, resp.Header.Get("Access-Control-Allow-Origin"))
+				assert.Equal(t, "Mcp-Session-Id", resp.Header.Get("Access-Control-Expose-Headers"))
+			} else {
+				assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+			}
+		})
+	}
+}
+
+func TestPreflightNeedsNoKey(t *testing.T) {
+	srv := startSecureServer(t, security{keys: testKeys(t)})
+	resp := send(t, srv.URL, http.MethodOptions, "", map[string]string{
+		"Origin":                         "http://localhost:3000",
+		"Access-Control-Request-Method":  "POST",
+		"Access-Control-Request-Headers": "authorization, content-type",
+	})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "http://localhost:3000", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, resp.Header.Get("Access-Control-Allow-Methods"), "POST")
+	assert.Contains(t, resp.Header.Get("Access-Control-Allow-Headers"), "Authorization")
+
+	resp = send(t, srv.URL, http.MethodOptions, "", map[string]string{"Origin": "http://attacker.example"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestRateLimitPerKey(t *testing.T) {
+	srv := startSecureServer(t, security{keys: testKeys(t), rate: 0.01, burst: 2})
+	alice := map[string]string{"Authorization": "Bearer " + aliceKey}
+
+	assert.Equal(t, http.StatusOK, send(t, srv.URL, http.MethodPost, initializeRequest, alice).StatusCode)
+	assert.Equal(t, http.StatusOK, send(t, srv.URL, http.MethodPost, initializeRequest, alice).StatusCode)
+	resp := send(t, srv.URL, http.MethodPost, initializeRequest, alice)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "100", resp.Header.Get("Retry-After"))
+
+	// the same key through the other header shares the limit; another key does not
+	assert.Equal(t, http.StatusTooManyRequests, send(t, srv.URL, http.MethodPost, initializeRequest, map[string]string{"X-API-Key": aliceKey}).StatusCode)
+	assert.Equal(t, http.StatusOK, send(t, srv.URL, http.MethodPost, initializeRequest, map[string]string{"X-API-Key": bobKey}).StatusCode)
+	// rejected keys are not counted against anyone
+	assert.Equal(t, http.StatusUnauthorized, send(t, srv.URL, http.MethodPost, initializeRequest, nil).StatusCode)
+}
+
+func TestRateLimitRefills(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	g := security{rate: 2, burst: 1}.protect(http.NotFoundHandler()).(*guard)
+	g.now = func() time.Time { return now }
+
+	_, ok := g.allow("10.0.0.1")
+	assert.True(t, ok)
+	wait, ok := g.allow("10.0.0.1")
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+	_, ok = g.allow("10.0.0.2")
+	assert.True(t, ok, "addresses are limited separately")
+
+	now = now.Add(500 * time.Millisecond)
+	_, ok = g.allow("10.0.0.1")
+	assert.True(t, ok, "a token comes back after 1/rate")
+
+	// idle clients are forgotten on the next sweep
+	now = now.Add(clientIdle + time.Second)
+	_, ok = g.allow("10.0.0.3")
+	assert.True(t, ok)
+	assert.Len(t, g.clients, 1)
+}
+
+func TestRateLimitOff(t *testing.T) {
+	g := security{}.protect(http.NotFoundHandler()).(*guard)
+	for i := 0; i < 100; i++ {
+		_, ok := g.allow("10.0.0.1")
+		require.True(t, ok)
+	}
+	assert.Empty(t, g.clients)
+}
+
+func TestTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(newHTTPHandler(newServer(serverOptions{}), httpOptions{sessionTimeout: time.Minute, security: security{keys: testKeys(t)}}))
+	defer srv.Close()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	cs, err := client.Connect(context.Background(), &mcp.StreamableClientTransport{
+		Endpoint:   srv.URL + "/mcp",
+		HTTPClient: &http.Client{Transport: bearer{bobKey, srv.Client().Transport}},
+	}, nil)
+	require.NoError(t, err)
+	defer cs.Close()
+	assert.NoError(t, cs.Ping(context.Background(), nil))
+
+	// plain HTTP to the TLS port is turned away
+	resp, err := http.Get(strings.Replace(srv.URL, "https://", "http://", 1) + "/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestIsLoopback(t *testing.T) {
+	for host, want := range map[string]bool{
+		"localhost": true, "127.0.0.1": true, "::1": true, "127.0.0.2": true,
+		"0.0.0.0": false, "": false, "192.168.1.10": false, "example.com": false,
+	} {
+		assert.Equal(t, want, isLoopback(host), host)
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// maxCompareHands keeps a comparison within one deck: ten five-card hands use 50 cards.
+const maxCompareHands = 10
+
+// CompareHandsParams are the arguments of the compare_poker_hands tool.
+type CompareHandsParams struct {
+	Hands []NamedHandParam `json:"hands"`
+}
+
+// NamedHandParam is one player's five cards.
+type NamedHandParam struct {
+	Name  string   `json:"name"`
+	Cards []string `json:"cards"`
+}
+
+// HandPlacing is one hand's result, as returned in the tool's structured output.
+type HandPlacing struct {
+	Name        string   `json:"name"`
+	Cards       []string `json:"cards"`
+	Category    string   `json:"category"`
+	Description string   `json:"description"`
+	// Place is 1 for the winners; tied hands share a place and the next one is skipped,
+	// so three hands with two tied for first place 1, 1 and 3.
+	Place int `json:"place"`
+}
+
+// Comparison is the structured output of compare_poker_hands, strongest hand first.
+type Comparison struct {
+	Results []HandPlacing `json:"results"`
+	Winners []string      `json:"winners"`
+}
+
+var compareTool = &mcp.Tool{
+	Name:        "compare_poker_hands",
+	Description: "Settle a showdown: rank two or more named 5-card hands from best to worst and name the winner, or the players who split the pot. Returns each hand's category, description and place (tied hands share a place).",
+	InputSchema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"hands": map[string]interface{}{
+				"type":     "array",
+				"minItems": 2,
+				"maxItems": maxCompareHands,
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"name": map[string]interface{}{
+							"type":        "string",
+							"description": "Player name; defaults to 'Player N'",
+						},
+						"cards": cardListSchema,
+					},
+					"required": []string{"cards"},
+				},
+			},
+		},
+		"required": []string{"hands"},
+	},
+}
+
+func handleCompareHands(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params CompareHandsParams
+	if err := json.Unmarshal(req.Params.Arguments, &params); err != nil {
+		return nil, err
+	}
+	c, err := compareHands(params.Hands)
+	if err != nil {
+		return nil, err
+	}
+	return structuredResult(formatComparison(c), c)
+}
+
+// compareHands orders the hands with hand.Compare. Cards may not repeat across hands,
+// since no real showdown could produce that.
+func compareHands(in []NamedHandParam) (Comparison, error) {
+	if len(in) < 2 || len(in) > maxCompareHands {
+		return Comparison{}, fmt.Errorf("2 to %d hands are required, got %d", maxCompareHands, len(in))
+	}
+	seen := map[cards.Card]string{}
+	names := map[string]bool{}
+	evals := make([]hand.EvaluatedHand, len(in))
+	placings := make([]HandPlacing, len(in))
+	for i, h := range in {
+		name := strings.TrimSpace(h.Name)
+		if name == "" {
+			name = fmt.Sprintf("Player %d", i+1)
+		}
+		if names[name] {
+			return Comparison{}, fmt.Errorf("duplicate player name %q", name)
+		}
+		names[name] = true
+		if len(h.Cards) != 5 {
+			return Comparison{}, fmt.Errorf("%s: must provide exactly 5 cards, got %d", name, len(h.Cards))
+		}
+		cs, err := parseCards(name+" card", h.Cards)
+		if err != nil {
+			return Comparison{}, err
+		}
+		strs := make([]string, len(cs))
+		for j, c := range cs {
+			if other, ok := seen[c]; ok {
+				return Comparison{}, fmt.Errorf("%s appears in both %s's and %s's hands", c, other, name)
+			}
+			seen[c] = name
+			strs[j] = c.String()
+		}
+		evals[i] = hand.Evaluate(hand.Hand{Cards: cs})
+		placings[i] = HandPlacing{Name: name, Cards: strs, Category: evals[i].Category.String(), Description: hand.Describe(evals[i])}
+	}
+
+	places := hand.Places(evals)
+	for i := range placings {
+		placings[i].Place = places[i]
+	}
+	sort.SliceStable(placings, func(a, b int) bool { return placings[a].Place < placings[b].Place })
+	c := Comparison{Results: placings}
+	for _, p := range placings {
+		if p.Place == 1 {
+			c.Winners = append(c.Winners, p.Name)
+		}
+	}
+	return c, nil
+}
+
+func formatComparison(c Comparison) string {
+	var b strings.Builder
+	for _, p := range c.Results {
+		fmt.Fprintf(&b, "%d. %s: %s (%s)\n", p.Place, p.Name, p.Description, strings.Join(p.Cards, " "))
+	}
+	if len(c.Winners) == 1 {
+		fmt.Fprintf(&b, "Winner: %s", c.Winners[0])
+	} else {
+		fmt.Fprintf(&b, "Split pot: %s", strings.Join(c.Winners, ", "))
+	}
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func callCompare(t *testing.T, hands []map[string]interface{}) (*mcp.CallToolResult, error) {
+	t.Helper()
+	cs := connectTestClient(t)
+	return cs.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "compare_poker_hands",
+		Arguments: map[string]interface{}{"hands": hands},
+	})
+}
+
+func TestCompareHandsWinner(t *testing.T) {
+	res, err := callCompare(t, []map[string]interface{}{
+		{"name": "alice", "cards": []string{"2c", "3d", "4h", "5s", "7c"}},
+		{"name": "bob", "cards": []string{"K spades", "K hearts", "9c", "9d", "2h"}},
+		{"name": "carol", "cards": []string{"Qs", "Qh", "Qc", "8d", "3h"}},
+	})
+	require.NoError(t, err)
+	require.False(t, res.IsError)
+
+	c := decodeStructured[Comparison](t, res)
+	assert.Equal(t, []string{"carol"}, c.Winners)
+	require.Len(t, c.Results, 3)
+	assert.Equal(t, HandPlacing{
+		Name: "carol", Cards: []string{"Q♠", "Q♥", "Q♣", "8♦", "3♥"},
+		Category: "Three of a Kind", Description: "Three Queens, 8-3 kickers", Place: 1,
+	}, c.Results[0])
+	assert.Equal(t, "bob", c.Results[1].Name)
+	assert.Equal(t, 3, c.Results[2].Place)
+
+	text := res.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "1. carol: Three Queens")
+	assert.Contains(t, text, "Winner: carol")
+}
+
+func TestCompareHandsTies(t *testing.T) {
+	res, err := callCompare(t, []map[string]interface{}{
+		{"cards": []string{"2c", "3d", "4h", "5s", "7c"}},
+		{"cards": []string{"As", "Ks", "Qs", "Js", "9d"}},
+		{"cards": []string{"Ah", "Kh", "Qh", "Jh", "9c"}},
+	})
+	require.NoError(t, err)
+	c := decodeStructured[Comparison](t, res)
+	assert.Equal(t, []string{"Player 2", "Player 3"}, c.Winners)
+	var places []int
+	for _, p := range c.Results {
+		places = append(places, p.Place)
+	}
+	assert.Equal(t, []int{1, 1, 3}, places)
+	assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, "Split pot: Player 2, Player 3")
+}
+
+func TestCompareHandsErrors(t *testing.T) {
+	five := []string{"2c", "3d", "4h", "5s", "7c"}
+	tests := []struct {
+		name  string
+		hands []map[string]interface{}
+		want  string
+	}{
+		{"one hand", []map[string]interface{}{{"cards": five}}, "2 to 10 hands"},
+		{"short hand", []map[string]interface{}{{"cards": five}, {"cards": []string{"As"}}}, "exactly 5 cards"},
+		{"shared card", []map[string]interface{}{{"name": "a", "cards": five}, {"name": "b", "cards": []string{"2c", "Kd", "Qh", "Js", "9c"}}}, "both a's and b's"},
+		{"same name", []map[string]interface{}{{"name": "a", "cards": five}, {"name": "a", "cards": []string{"Ac", "Kd", "Qh", "Js", "9c"}}}, "duplicate player name"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := compareHands(decodeParams(t, tc.hands))
+			assert.ErrorContains(t, err, tc.want)
+			res, err := callCompare(t, tc.hands)
+			if err == nil {
+				assert.True(t, res.IsError)
+			}
+		})
+	}
+}
+
+func decodeParams(t *testing.T, hands []map[string]interface{}) []NamedHandParam {
+	t.Helper()
+	data, err := json.Marshal(hands)
+	require.NoError(t, err)
+	var out []NamedHandParam
+	require.NoError(t, json.Unmarshal(data, &out))
+	return out
+}
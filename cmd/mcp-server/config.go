@@ -0,0 +1,258 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envPrefix starts the environment variable for each flag: -session-timeout is
+// GOPOKER_MCP_SESSION_TIMEOUT.
+const envPrefix = "GOPOKER_MCP_"
+
+// Config is everything the server can be told. Settings come from the defaults, then a
+// YAML or JSON file, then environment variables, then flags, each overriding the last.
+type Config struct {
+	Transport string         `json:"transport" yaml:"transport"` // stdio or streamable_http
+	Host      string         `json:"host" yaml:"host"`
+	Port      int            `json:"port" yaml:"port"`
+	Tools     list           `json:"tools" yaml:"tools"` // tools to serve; empty serves all
+	Log       LogConfig      `json:"log" yaml:"log"`
+	Auth      AuthConfig     `json:"auth" yaml:"auth"`
+	TLS       TLSConfig      `json:"tls" yaml:"tls"`
+	Limits    LimitsConfig   `json:"limits" yaml:"limits"`
+	Timeouts  TimeoutsConfig `json:"timeouts" yaml:"timeouts"`
+}
+
+// LogConfig sets what is logged to stderr.
+type LogConfig struct {
+	Level  string `json:"level" yaml:"level"`
+	Format string `json:"format" yaml:"format"`
+}
+
+// AuthConfig says who may use the HTTP transport.
+type AuthConfig struct {
+	APIKeys        list   `json:"api_keys" yaml:"api_keys"`
+	APIKeysFile    string `json:"api_keys_file" yaml:"api_keys_file"`
+	AllowedOrigins list   `json:"allowed_origins" yaml:"allowed_origins"`
+}
+
+// TLSConfig serves HTTPS when both files are given.
+type TLSConfig struct {
+	Cert string `json:"cert" yaml:"cert"`
+	Key  string `json:"key" yaml:"key"`
+}
+
+// LimitsConfig bound what clients can hold and how fast they can ask.
+type LimitsConfig struct {
+	Rate            float64  `json:"rate" yaml:"rate"` // per API key or client address; 0 is unlimited
+	Burst           int      `json:"burst" yaml:"burst"`
+	SessionTimeout  duration `json:"session_timeout" yaml:"session_timeout"`
+	MaxGames        int      `json:"max_games" yaml:"max_games"`
+	GameTTL         duration `json:"game_ttl" yaml:"game_ttl"`
+	MaxRequestBytes int64    `json:"max_request_bytes" yaml:"max_request_bytes"`
+}
+
+// TimeoutsConfig bound the HTTP server. The write timeout covers a POST's response,
+// tool calls included; the server's SSE stream on GET is exempt.
+type TimeoutsConfig struct {
+	ReadHeader duration `json:"read_header" yaml:"read_header"`
+	Read       duration `json:"read" yaml:"read"`
+	Write      duration `json:"write" yaml:"write"`
+	Idle       duration `json:"idle" yaml:"idle"`
+	Shutdown   duration `json:"shutdown" yaml:"shutdown"` // to drain tool calls on SIGINT or SIGTERM
+}
+
+func defaultConfig() Config {
+	return Config{
+		Transport: "stdio",
+		Host:      "127.0.0.1",
+		Port:      8080,
+		Log:       LogConfig{Level: "info", Format: "text"},
+		Limits: LimitsConfig{
+			Rate:            10,
+			Burst:           20,
+			SessionTimeout:  duration(defaultSessionTimeout),
+			MaxGames:        maxGames,
+			GameTTL:         duration(gameTTL),
+			MaxRequestBytes: 1 << 20,
+		},
+		Timeouts: TimeoutsConfig{
+			ReadHeader: duration(10 * time.Second),
+			Read:       duration(30 * time.Second),
+			Write:      duration(2 * time.Minute),
+			Idle:       duration(2 * time.Minute),
+			Shutdown:   duration(30 * time.Second),
+		},
+	}
+}
+
+// bind registers a flag for every setting, writing straight into c.
+func (c *Config) bind(fs *flag.FlagSet) {
+	fs.StringVar(&c.Transport, "transport", c.Transport, "MCP transport protocol: stdio or streamable_http")
+	fs.StringVar(&c.Host, "host", c.Host, "Interface for streamable_http transport; 0.0.0.0 for all")
+	fs.IntVar(&c.Port, "port", c.Port, "Port for streamable_http transport")
+	fs.Var(&c.Tools, "tools", "comma-separated tools to serve (default all)")
+	fs.StringVar(&c.Log.Level, "log-level", c.Log.Level, "log level: debug, info, warn or error")
+	fs.StringVar(&c.Log.Format, "log-format", c.Log.Format, "log format: text or json")
+	fs.Var(&c.Auth.APIKeys, "api-keys", "comma-separated API keys accepted as bearer tokens or X-API-Key (prefer -api-keys-file: flags show up in ps)")
+	fs.StringVar(&c.Auth.APIKeysFile, "api-keys-file", c.Auth.APIKeysFile, "file of API keys, one per line as 'key' or 'name key'")
+	fs.Var(&c.Auth.AllowedOrigins, "allowed-origins", "comma-separated browser origins allowed to connect, or * for any (default loopback only)")
+	fs.StringVar(&c.TLS.Cert, "tls-cert", c.TLS.Cert, "TLS certificate file; serves HTTPS together with -tls-key")
+	fs.StringVar(&c.TLS.Key, "tls-key", c.TLS.Key, "TLS private key file")
+	fs.Float64Var(&c.Limits.Rate, "rate", c.Limits.Rate, "requests per second allowed per API key, or per client address without keys (0 = unlimited)")
+	fs.IntVar(&c.Limits.Burst, "burst", c.Limits.Burst, "requests a client may make at once before -rate applies")
+	fs.Var(&c.Limits.SessionTimeout, "session-timeout", "close streamable_http sessions idle for this long (0 = never)")
+	fs.IntVar(&c.Limits.MaxGames, "max-games", c.Limits.MaxGames, "games that may be in progress at once")
+	fs.Var(&c.Limits.GameTTL, "game-ttl", "end games idle for this long")
+	fs.Int64Var(&c.Limits.MaxRequestBytes, "max-request-bytes", c.Limits.MaxRequestBytes, "largest HTTP request body accepted")
+	fs.Var(&c.Timeouts.ReadHeader, "read-header-timeout", "time allowed to read a request's headers")
+	fs.Var(&c.Timeouts.Read, "read-timeout", "time allowed to read a whole request")
+	fs.Var(&c.Timeouts.Write, "write-timeout", "time allowed to write a response, except the server's event stream (0 = none)")
+	fs.Var(&c.Timeouts.Idle, "idle-timeout", "how long a keep-alive connection may wait for its next request")
+	fs.Var(&c.Timeouts.Shutdown, "shutdown-timeout", "how long to wait for running tool calls on SIGINT or SIGTERM")
+}
+
+// loadConfig reads the configuration for a run with the given arguments and
+// environment. The file is named by -config or GOPOKER_MCP_CONFIG.
+func loadConfig(args []string, getenv func(string) string) (Config, error) {
+	cfg := defaultConfig()
+	fs := flag.NewFlagSet(serverName, flag.ContinueOnError)
+	path := fs.String("config", "", "YAML or JSON configuration file; environment variables and flags override it")
+	cfg.bind(fs)
+
+	// The first parse only finds the file; flags are parsed again once it is read so
+	// that they win over it.
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+	if *path == "" {
+		*path = getenv(envPrefix + "CONFIG")
+	}
+	cfg = defaultConfig()
+	if *path != "" {
+		if err := readConfigFile(*path, &cfg); err != nil {
+			return Config{}, err
+		}
+	}
+
+	var errs []error
+	fs.VisitAll(func(f *flag.Flag) {
+		if f.Name == "config" {
+			return
+		}
+		name := envPrefix + strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		if v := getenv(name); v != "" {
+			if err := f.Value.Set(v); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			}
+		}
+	})
+	if err := errors.Join(errs...); err != nil {
+		return Config{}, err
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+	return cfg, cfg.validate()
+}
+
+// readConfigFile decodes a file by its extension. Unknown keys are errors, so a
+// misspelt setting is not silently ignored.
+func readConfigFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(cfg)
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(cfg)
+	default:
+		return fmt.Errorf("config %s: want a .yaml, .yml or .json file", path)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c Config) validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	check(c.Transport == "stdio" || c.Transport == "streamable_http", "transport %q: want stdio or streamable_http", c.Transport)
+	check(c.Port > 0 && c.Port < 65536, "port %d: want 1-65535", c.Port)
+	check((c.TLS.Cert == "") == (c.TLS.Key == ""), "tls: cert and key must be given together")
+	check(c.Limits.Rate >= 0, "limits: rate must be >= 0")
+	check(c.Limits.Burst >= 0, "limits: burst must be >= 0")
+	check(c.Limits.SessionTimeout >= 0, "limits: session_timeout must be >= 0")
+	check(c.Limits.MaxGames > 0, "limits: max_games must be > 0")
+	check(c.Limits.GameTTL > 0, "limits: game_ttl must be > 0")
+	check(c.Limits.MaxRequestBytes > 0, "limits: max_request_bytes must be > 0")
+	for name, d := range map[string]duration{
+		"read_header": c.Timeouts.ReadHeader, "read": c.Timeouts.Read, "write": c.Timeouts.Write,
+		"idle": c.Timeouts.Idle, "shutdown": c.Timeouts.Shutdown,
+	} {
+		check(d >= 0, "timeouts: %s must be >= 0", name)
+	}
+	known := map[string]bool{}
+	for _, t := range toolNames() {
+		known[t] = true
+	}
+	for _, t := range c.Tools {
+		check(known[t], "tools: unknown tool %q (available: %s)", t, strings.Join(toolNames(), ", "))
+	}
+	return errors.Join(errs...)
+}
+
+// list is a comma-separated flag that reads as a list from config files.
+type list []string
+
+func (l *list) String() string {
+	if l == nil {
+		return ""
+	}
+	return strings.Join(*l, ",")
+}
+
+func (l *list) Set(s string) error {
+	*l = splitList(s)
+	return nil
+}
+
+// duration reads "30s" or "2m" from flags, environment variables and both file formats.
+type duration time.Duration
+
+func (d *duration) String() string { return time.Duration(*d).String() }
+
+func (d *duration) Set(s string) error {
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = duration(v)
+	return nil
+}
+
+func (d *duration) UnmarshalText(b []byte) error { return d.Set(string(b)) }
+
+func (d duration) MarshalText() ([]byte, error) { return []byte(time.Duration(d).String()), nil }
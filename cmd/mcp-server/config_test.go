@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func env(vars map[string]string) func(string) string {
+	return func(k string) string { return vars[k] }
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestConfigDefaults(t *testing.T) {
+	cfg, err := loadConfig(nil, env(nil))
+	require.NoError(t, err)
+	assert.Equal(t, defaultConfig(), cfg)
+	assert.Equal(t, "stdio", cfg.Transport)
+	assert.Equal(t, duration(gameTTL), cfg.Limits.GameTTL)
+}
+
+func TestConfigPrecedence(t *testing.T) {
+	file := writeFile(t, "mcp.yaml", `
+transport: streamable_http
+host: 0.0.0.0
+port: 9000
+tools: [evaluate_poker_hand, analyze_outs]
+limits:
+  rate: 5
+  session_timeout: 1h
+timeouts:
+  write: 45s
+`)
+	cfg, err := loadConfig([]string{"-config", file, "-port", "9200", "-write-timeout", "1m"}, env(map[string]string{
+		"GOPOKER_MCP_PORT":            "9100",
+		"GOPOKER_MCP_SESSION_TIMEOUT": "10m",
+		"GOPOKER_MCP_API_KEYS":        aliceKey + "," + bobKey,
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, "streamable_http", cfg.Transport, "from the file")
+	assert.Equal(t, "0.0.0.0", cfg.Host, "from the file")
+	assert.Equal(t, list{"evaluate_poker_hand", "analyze_outs"}, cfg.Tools, "from the file")
+	assert.Equal(t, 5.0, cfg.Limits.Rate, "from the file")
+	assert.Equal(t, duration(10*time.Minute), cfg.Limits.SessionTimeout, "the environment beats the file")
+	assert.Equal(t, list{aliceKey, bobKey}, cfg.Auth.APIKeys, "from the environment")
+	assert.Equal(t, 9200, cfg.Port, "flags beat everything")
+	assert.Equal(t, duration(time.Minute), cfg.Timeouts.Write, "flags beat everything")
+	assert.Equal(t, 20, cfg.Limits.Burst, "defaults fill the rest")
+	assert.Equal(t, duration(30*time.Second), cfg.Timeouts.Shutdown, "defaults fill the rest")
+}
+
+func TestConfigFileFromEnvironment(t *testing.T) {
+	file := writeFile(t, "mcp.json", `{
+  "transport": "streamable_http",
+  "port": 9300,
+  "auth": {"allowed_origins": ["https://app.example"]},
+  "limits": {"max_games": 7, "game_ttl": "5m"},
+  "timeouts": {"shutdown": "3s"}
+}`)
+	cfg, err := loadConfig(nil, env(map[string]string{"GOPOKER_MCP_CONFIG": file}))
+	require.NoError(t, err)
+	assert.Equal(t, 9300, cfg.Port)
+	assert.Equal(t, list{"https://app.example"}, cfg.Auth.AllowedOrigins)
+	assert.Equal(t, 7, cfg.Limits.MaxGames)
+	assert.Equal(t, duration(5*time.Minute), cfg.Limits.GameTTL)
+	assert.Equal(t, duration(3*time.Second), cfg.Timeouts.Shutdown)
+}
+
+func TestConfigExample(t *testing.T) {
+	cfg, err := loadConfig([]string{"-config", "config.example.yaml"}, env(nil))
+	require.NoError(t, err)
+	assert.Equal(t, "streamable_http", cfg.Transport)
+	assert.Equal(t, "/etc/gopoker/api-keys", cfg.Auth.APIKeysFile)
+	assert.Equal(t, int64(1<<20), cfg.Limits.MaxRequestBytes)
+	assert.Equal(t, duration(2*time.Minute), cfg.Timeouts.Write)
+}
+
+func TestConfigErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		args []string
+		env  map[string]string
+		want string
+	}{
+		{"unknown yaml key", []string{"-config", writeFile(t, "c.yaml", "prot: 80\n")}, nil, "field prot not found"},
+		{"unknown json key", []string{"-config", writeFile(t, "c.json", `{"limits": {"rates": 1}}`)}, nil, `unknown field "rates"`},
+		{"bad duration in file", []string{"-config", writeFile(t, "c.yaml", "limits:\n  game_ttl: soon\n")}, nil, "invalid duration"},
+		{"unknown extension", []string{"-config", writeFile(t, "c.toml", "")}, nil, "want a .yaml, .yml or .json file"},
+		{"missing file", []string{"-config", "no-such-file.yaml"}, nil, "config:"},
+		{"bad environment value", nil, map[string]string{"GOPOKER_MCP_PORT": "http"}, "GOPOKER_MCP_PORT"},
+		{"bad flag", []string{"-port", "http"}, nil, "invalid value"},
+		{"transport", []string{"-transport", "sse"}, nil, `transport "sse"`},
+		{"port", []string{"-port", "70000"}, nil, "port 70000"},
+		{"half of tls", []string{"-tls-cert", "cert.pem"}, nil, "cert and key must be given together"},
+		{"unknown tool", []string{"-tools", "evaluate_poker_hand,shuffle"}, nil, `unknown tool "shuffle"`},
+		{"negative rate", []string{"-rate", "-1"}, nil, "rate must be >= 0"},
+		{"no games", []string{"-max-games", "0"}, nil, "max_games must be > 0"},
+		{"negative timeout", []string{"-shutdown-timeout", "-1s"}, nil, "shutdown must be >= 0"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := loadConfig(tc.args, env(tc.env))
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+}
+
+func TestEnabledTools(t *testing.T) {
+	cs := connectServer(t, newServer(serverOptions{
+		tools:   []string{"evaluate_poker_hand", "start_game"},
+		gameTTL: 5 * time.Minute,
+	}))
+	res, err := cs.ListTools(context.Background(), nil)
+	require.NoError(t, err)
+	var names []string
+	var start *mcp.Tool
+	for _, tool := range res.Tools {
+		names = append(names, tool.Name)
+		if tool.Name == "start_game" {
+			start = tool
+		}
+	}
+	assert.ElementsMatch(t, []string{"evaluate_poker_hand", "start_game"}, names)
+	require.NotNil(t, start)
+	assert.Contains(t, start.Description, "Games expire after 5m0s")
+	assert.Contains(t, startGameTool.Description, "30m0s", "the shared tool is not modified")
+
+	_, err = cs.CallTool(context.Background(), &mcp.CallToolParams{Name: "analyze_outs", Arguments: map[string]interface{}{}})
+	assert.ErrorContains(t, err, "unknown tool")
+}
+
+func TestGameLimit(t *testing.T) {
+	cs := connectServer(t, newServer(serverOptions{maxGames: 1}))
+	var st GameState
+	require.Empty(t, callGame(t, cs, "start_game", map[string]interface{}{"opponents": 1}, &st))
+	assert.Contains(t, callGame(t, cs, "start_game", map[string]interface{}{"opponents": 1}, &st), "limit 1")
+}
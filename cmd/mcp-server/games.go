@@ -0,0 +1,757 @@
+package main
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/dangogh/GoPoker/bot"
+	"github.com/dangogh/GoPoker/game"
+	"github.com/dangogh/GoPoker/hand"
+	"github.com/dangogh/GoPoker/table"
+)
+
+// Games let an agent play five-card draw against bots through tool calls. Each game
+// plays its hands on its own goroutine; the agent's seat is a Player that hands every
+// decision to the next tool call and waits for it. Games are keyed by the random ID
+// start_game returns and belong to whoever started them: the API key when the HTTP
+// transport requires keys, so a client keeps its games across sessions, otherwise the
+// MCP session.
+
+const (
+	// gameTTL is how long a game survives without a tool call, unless configured.
+	gameTTL = 30 * time.Minute
+	// maxGames bounds the goroutines held by idle games, unless configured.
+	maxGames = 100
+)
+
+var errGameClosed = errors.New("game closed")
+
+// turn is where a game's goroutine stopped: a decision for the agent, or the end of a
+// hand when prompt is empty.
+type turn struct {
+	prompt string // "act" or "discard"
+	view   game.View
+	events []game.Event // the hand so far, as the agent saw it
+	result *game.Result
+	err    error // the hand was abandoned
+}
+
+type answer struct {
+	action   game.Action
+	discards []int
+}
+
+type drawGame struct {
+	id    string
+	owner any    // see gameOwner
+	name  string // the agent's name at the table
+	seat  int
+	t     *table.Table
+	hand  int // hands dealt
+
+	mu  sync.Mutex // serializes tool calls on this game
+	cur turn
+
+	lastUsed time.Time // guarded by the store's mutex
+
+	deal      chan struct{}
+	turns     chan turn
+	answers   chan answer
+	quit      chan struct{}
+	closeOnce sync.Once
+}
+
+// agentSeat is the agent's game.Player. It only runs on the game's goroutine.
+type agentSeat struct {
+	g      *drawGame
+	events []game.Event
+}
+
+func (a *agentSeat) Observe(e game.Event) { a.events = append(a.events, e) }
+
+func (a *agentSeat) decide(prompt string, v game.View) (answer, error) {
+	select {
+	case a.g.turns <- turn{prompt: prompt, view: v, events: append([]game.Event(nil), a.events...)}:
+	case <-a.g.quit:
+		return answer{}, errGameClosed
+	}
+	select {
+	case ans := <-a.g.answers:
+		return ans, nil
+	case <-a.g.quit:
+		return answer{}, errGameClosed
+	}
+}
+
+func (a *agentSeat) Act(v game.View) (game.Action, error) {
+	ans, err := a.decide("act", v)
+	return ans.action, err
+}
+
+func (a *agentSeat) Discard(v game.View) ([]int, error) {
+	ans, err := a.decide("discard", v)
+	return ans.discards, err
+}
+
+// run plays a hand each time one is requested on deal.
+func (g *drawGame) run(agent *agentSeat) {
+	for {
+		select {
+		case <-g.deal:
+		case <-g.quit:
+			return
+		}
+		agent.events = nil
+		res, err := g.t.PlayHand()
+		select {
+		case g.turns <- turn{events: agent.events, result: res, err: err}:
+		case <-g.quit:
+			return
+		}
+	}
+}
+
+// dealNext starts the next hand and waits for the agent's first decision or, if the
+// agent has none, the end of the hand. The caller holds g.mu.
+func (g *drawGame) dealNext() error {
+	select {
+	case g.deal <- struct{}{}:
+	case <-g.quit:
+		return errGameClosed
+	}
+	g.hand++
+	return g.await()
+}
+
+// respond passes the agent's decision to the engine and waits for the next one.
+func (g *drawGame) respond(a answer) error {
+	select {
+	case g.answers <- a:
+	case <-g.quit:
+		return errGameClosed
+	}
+	return g.await()
+}
+
+// await waits for the game's goroutine to stop again. A game can be expired while a
+// call holds g.mu, and the goroutine then never answers.
+func (g *drawGame) await() error {
+	select {
+	case g.cur = <-g.turns:
+		return nil
+	case <-g.quit:
+		return errGameClosed
+	}
+}
+
+func (g *drawGame) close() { g.closeOnce.Do(func() { close(g.quit) }) }
+
+// over reports whether no more hands can be dealt: the agent or every bot is broke.
+func (g *drawGame) over() bool {
+	if g.cur.prompt != "" {
+		return false
+	}
+	return g.cur.err != nil || g.t.Seat(g.name) < 0 || g.t.Active() < 2
+}
+
+// gameStore holds the games in progress and expires idle ones.
+type gameStore struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu    sync.Mutex
+	games map[string]*drawGame
+	max   int
+}
+
+func newGameStore(ttl time.Duration) *gameStore {
+	return &gameStore{ttl: ttl, now: time.Now, games: map[string]*drawGame{}, max: maxGames}
+}
+
+// sweep closes games idle for longer than the ttl. The caller holds s.mu.
+func (s *gameStore) sweep() {
+	for id, g := range s.games {
+		if s.now().Sub(g.lastUsed) > s.ttl {
+			g.close()
+			delete(s.games, id)
+		}
+	}
+}
+
+// len counts the games held, including idle ones the next sweep will close.
+func (s *gameStore) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.games)
+}
+
+// add stores g under a new random ID, so one client cannot guess another's games.
+func (s *gameStore) add(g *drawGame) error {
+	b := make([]byte, 12)
+	if _, err := crand.Read(b); err != nil {
+		return fmt.Errorf("game id: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep()
+	if len(s.games) >= s.max {
+		return fmt.Errorf("too many games in progress (limit %d); try again later", s.max)
+	}
+	g.id = "game-" + hex.EncodeToString(b)
+	g.lastUsed = s.now()
+	s.games[g.id] = g
+	return nil
+}
+
+// get finds a game started by owner. Someone else's game is reported as missing, so
+// its existence is not given away either.
+func (s *gameStore) get(id string, owner any) (*drawGame, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep()
+	g, ok := s.games[id]
+	if !ok || g.owner != owner {
+		return nil, fmt.Errorf("no game %q; it may have expired after %v without a move, call start_game", id, s.ttl)
+	}
+	g.lastUsed = s.now()
+	return g, nil
+}
+
+// gameOwner names who is calling: the API key's name when the HTTP transport checked
+// one, otherwise the MCP session itself, since only HTTP sessions have IDs.
+func gameOwner(req *mcp.CallToolRequest) any {
+	if extra := req.GetExtra(); extra != nil {
+		if name := extra.Header.Get(keyNameHeader); name != "" {
+			return name
+		}
+	}
+	return req.Session
+}
+
+// StartGameParams are the arguments of start_game.
+type StartGameParams struct {
+	Name       string `json:"name"`
+	Opponents  int    `json:"opponents"`
+	Bot        string `json:"bot"`
+	Stack      int    `json:"stack"`
+	Ante       int    `json:"ante"`
+	SmallBlind *int   `json:"small_blind"`
+	BigBlind   int    `json:"big_blind"`
+	Seed       int64  `json:"seed"`
+}
+
+// GameParams identify a game.
+type GameParams struct {
+	GameID string `json:"game_id"`
+}
+
+// ActParams are the arguments of act.
+type ActParams struct {
+	GameID   string `json:"game_id"`
+	Action   string `json:"action"`
+	Amount   int    `json:"amount"`
+	Discards []int  `json:"discards"`
+}
+
+// GameState is the game as the agent sees it.
+type GameState struct {
+	GameID   string       `json:"game_id"`
+	Hand     int          `json:"hand"`
+	Phase    string       `json:"phase"` // act, discard, hand_over or game_over
+	Cards    []string     `json:"cards,omitempty"`
+	Round    string       `json:"round,omitempty"`
+	Pot      int          `json:"pot"`
+	ToCall   int          `json:"to_call"`
+	MinRaise int          `json:"min_raise"` // smallest legal bet or raise total; 0 if none
+	Legal    []string     `json:"legal_actions"`
+	Players  []GamePlayer `json:"players"`
+	Log      []string     `json:"log"` // this hand's events
+}
+
+// GamePlayer is one seat at the table.
+type GamePlayer struct {
+	Name   string `json:"name"`
+	Seat   int    `json:"seat"`
+	Stack  int    `json:"stack"`
+	Bet    int    `json:"bet"` // this betting round
+	Folded bool   `json:"folded"`
+	Drawn  int    `json:"drawn"` // cards drawn, -1 before the draw
+	Button bool   `json:"button"`
+	You    bool   `json:"you"`
+}
+
+// HandSummary is the outcome of a finished hand.
+type HandSummary struct {
+	GameID   string       `json:"game_id"`
+	Hand     int          `json:"hand"`
+	Showdown bool         `json:"showdown"`
+	Shown    []ShownHand  `json:"shown"`
+	Winners  []PotWinner  `json:"winners"`
+	Net      int          `json:"net"` // the agent's chip change
+	Stacks   []GamePlayer `json:"stacks"`
+	Next     *GameState   `json:"next"` // the next hand, or the game over state
+}
+
+// ShownHand is a hand revealed at showdown.
+type ShownHand struct {
+	Name        string   `json:"name"`
+	Cards       []string `json:"cards"`
+	Description string   `json:"description"`
+}
+
+// PotWinner is a share of a pot.
+type PotWinner struct {
+	Name   string `json:"name"`
+	Amount int    `json:"amount"`
+	Pot    int    `json:"pot"` // 0 is the main pot
+}
+
+var gameIDSchema = map[string]interface{}{"type": "string", "description": "The game_id returned by start_game"}
+
+const startGameDescription = "Sit down at a no-limit five-card draw table against bots and deal the first hand. Returns a game_id for get_state, act and showdown. Games expire after %v without a call."
+
+var startGameTool = &mcp.Tool{
+	Name:        "start_game",
+	Description: fmt.Sprintf(startGameDescription, gameTTL),
+	InputSchema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name":      map[string]interface{}{"type": "string", "description": "Your name at the table; default 'You'"},
+			"opponents": map[string]interface{}{"type": "integer", "minimum": 1, "maximum": game.MaxSeats - 1, "description": "Number of bots; default 3"},
+			"bot": map[string]interface{}{
+				"type":        "string",
+				"enum":        builtinBots(),
+				"description": "Bot style; default basic",
+			},
+			"stack":       map[string]interface{}{"type": "integer", "minimum": 1, "description": "Starting chips for everyone; default 100 big blinds"},
+			"ante":        map[string]interface{}{"type": "integer", "minimum": 0},
+			"small_blind": map[string]interface{}{"type": "integer", "minimum": 0, "description": "Default half the big blind"},
+			"big_blind":   map[string]interface{}{"type": "integer", "minimum": 1, "description": "Default 2"},
+			"seed":        map[string]interface{}{"type": "integer", "description": "Random seed for reproducible deals and bots"},
+		},
+	},
+}
+
+var getStateTool = &mcp.Tool{
+	Name:        "get_state",
+	Description: "Show a game: your cards, the pot, the players, what happened this hand and what you may do next.",
+	InputSchema: map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"game_id": gameIDSchema},
+		"required":   []string{"game_id"},
+	},
+}
+
+var actTool = &mcp.Tool{
+	Name:        "act",
+	Description: "Make your move when the phase is act (fold, check, call, bet or raise) or discard (discard, with the indices of the cards to throw; none to stand pat). The bots then play until it is your turn again or the hand ends.",
+	InputSchema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"game_id": gameIDSchema,
+			"action": map[string]interface{}{
+				"type": "string",
+				"enum": []string{"fold", "check", "call", "bet", "raise", "discard"},
+			},
+			"amount": map[string]interface{}{"type": "integer", "minimum": 1, "description": "For bet and raise: the total your bet becomes this round"},
+			"discards": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "integer", "minimum": 0, "maximum": 4},
+				"description": "For discard: 0-based indices of the cards to throw away",
+			},
+		},
+		"required": []string{"game_id", "action"},
+	},
+}
+
+var showdownTool = &mcp.Tool{
+	Name:        "showdown",
+	Description: "Once a hand is over, show its result (hands shown, pots won, your net) and deal the next hand unless the game is over.",
+	InputSchema: map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"game_id": gameIDSchema},
+		"required":   []string{"game_id"},
+	},
+}
+
+// builtinBots are the bot styles start_game offers; rule files are left out since
+// they would read the server's disk.
+func builtinBots() []string {
+	var out []string
+	for _, k := range bot.Kinds() {
+		if !strings.HasPrefix(k, "rules:") {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+func (s *gameStore) start(p StartGameParams, owner any) (*drawGame, error) {
+	if p.Opponents == 0 {
+		p.Opponents = 3
+	}
+	if p.Opponents < 1 || p.Opponents > game.MaxSeats-1 {
+		return nil, fmt.Errorf("opponents must be between 1 and %d, got %d", game.MaxSeats-1, p.Opponents)
+	}
+	if p.Bot == "" {
+		p.Bot = "basic"
+	}
+	if strings.HasPrefix(p.Bot, "rules:") {
+		return nil, fmt.Errorf("rule file bots are not available here (valid options: %s)", strings.Join(builtinBots(), ", "))
+	}
+	if p.BigBlind == 0 {
+		p.BigBlind = 2
+	}
+	sb := p.BigBlind / 2
+	if p.SmallBlind != nil {
+		sb = *p.SmallBlind
+	}
+	if p.BigBlind < 0 || sb < 0 || p.Ante < 0 {
+		return nil, fmt.Errorf("blinds and antes must be >= 0")
+	}
+	if p.Stack == 0 {
+		p.Stack = 100 * p.BigBlind
+	}
+	if p.Stack < 0 {
+		return nil, fmt.Errorf("stack must be > 0, got %d", p.Stack)
+	}
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		p.Name = "You"
+	}
+	if p.Seed == 0 {
+		p.Seed = time.Now().UnixNano()
+	}
+
+	g := &drawGame{
+		owner:   owner,
+		name:    p.Name,
+		t:       table.New(game.Config{Ante: p.Ante, SmallBlind: sb, BigBlind: p.BigBlind}, rand.New(rand.NewSource(p.Seed))),
+		deal:    make(chan struct{}),
+		turns:   make(chan turn),
+		answers: make(chan answer),
+		quit:    make(chan struct{}),
+	}
+	agent := &agentSeat{g: g}
+	var err error
+	if g.seat, err = g.t.Sit(-1, p.Name, p.Stack, agent); err != nil {
+		return nil, err
+	}
+	for i := 1; i <= p.Opponents; i++ {
+		b, err := bot.New(p.Bot, p.Seed+int64(i))
+		if err != nil {
+			return nil, err
+		}
+		name := fmt.Sprintf("Bot %d", i)
+		if name == p.Name {
+			return nil, fmt.Errorf("name %q is taken by a bot", p.Name)
+		}
+		if _, err := g.t.Sit(-1, name, p.Stack, b); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.add(g); err != nil {
+		return nil, err
+	}
+	go g.run(agent)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if err := g.dealNext(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// state describes the game at its current turn. The caller holds g.mu.
+func (g *drawGame) state() GameState {
+	st := GameState{GameID: g.id, Hand: g.hand, Legal: []string{}}
+	for _, e := range g.cur.events {
+		st.Log = append(st.Log, e.String())
+	}
+	switch {
+	case g.cur.prompt != "":
+		v := g.cur.view
+		st.Phase = g.cur.prompt
+		st.Cards = cardStrings(v.Hand)
+		st.Round = v.Round.String()
+		st.Pot, st.ToCall, st.MinRaise = v.Pot, v.ToCall, v.MinRaise
+		st.Legal = legalActions(g.cur.prompt, v)
+		for i, name := range v.Names {
+			if !v.Dealt[i] {
+				continue
+			}
+			st.Players = append(st.Players, GamePlayer{
+				Name: name, Seat: i, Stack: v.Stacks[i], Bet: v.Bets[i], Folded: v.Folded[i],
+				Drawn: v.Drawn[i], Button: i == v.Button, You: i == g.seat,
+			})
+		}
+		return st
+	case g.over():
+		st.Phase = "game_over"
+	default:
+		st.Phase = "hand_over"
+		st.Legal = []string{"showdown"}
+	}
+	if res := g.cur.result; res != nil {
+		st.Cards = cardStrings(res.Hands[g.seat])
+	}
+	st.Players = g.stacks()
+	return st
+}
+
+// stacks lists the seated players between hands.
+func (g *drawGame) stacks() []GamePlayer {
+	var out []GamePlayer
+	for i, s := range g.t.Seats() {
+		if s.Player == nil {
+			continue
+		}
+		p := GamePlayer{Name: s.Name, Seat: i, Stack: s.Stack, Drawn: -1, Button: i == g.t.Button(), You: i == g.seat}
+		if res := g.cur.result; res != nil {
+			p.Folded = res.Folded[i]
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+func legalActions(prompt string, v game.View) []string {
+	if prompt == "discard" {
+		return []string{"discard"}
+	}
+	raise := "bet"
+	for _, b := range v.Bets {
+		if b > 0 {
+			raise = "raise"
+		}
+	}
+	out := []string{"check"}
+	if v.ToCall > 0 {
+		out = []string{"fold", "call"}
+	}
+	if v.MinRaise > 0 {
+		out = append(out, raise)
+	}
+	return out
+}
+
+// move turns the agent's request into an answer for the pending decision.
+func (g *drawGame) move(p ActParams) (answer, error) {
+	if g.cur.prompt == "" {
+		return answer{}, fmt.Errorf("the hand is over; call showdown to see the result and deal the next hand")
+	}
+	v := g.cur.view
+	if p.Action == "discard" {
+		if g.cur.prompt != "discard" {
+			return answer{}, fmt.Errorf("it is time to bet, not discard (legal actions: %s)", strings.Join(legalActions("act", v), ", "))
+		}
+		limit := hand.ComputeMaxDiscard(hand.Hand{Cards: v.Hand})
+		if len(p.Discards) > limit {
+			return answer{}, fmt.Errorf("at most %d discards are allowed with this hand, got %d", limit, len(p.Discards))
+		}
+		seen := map[int]bool{}
+		for _, idx := range p.Discards {
+			if idx < 0 || idx >= len(v.Hand) {
+				return answer{}, fmt.Errorf("discard index %d out of range (0-%d)", idx, len(v.Hand)-1)
+			}
+			if seen[idx] {
+				return answer{}, fmt.Errorf("discard index %d given twice", idx)
+			}
+			seen[idx] = true
+		}
+		if len(p.Discards) > 3 && !hand.KeepsAce(hand.Hand{Cards: v.Hand}, p.Discards) {
+			return answer{}, fmt.Errorf("four discards are allowed only when keeping an ace")
+		}
+		return answer{discards: p.Discards}, nil
+	}
+	if g.cur.prompt != "act" {
+		return answer{}, fmt.Errorf("it is time to discard; use action discard with the indices to throw (none to stand pat)")
+	}
+	legal := legalActions("act", v)
+	var a game.Action
+	switch p.Action {
+	case "fold":
+		a.Kind = game.Fold
+	case "check":
+		a.Kind = game.Check
+	case "call":
+		a.Kind = game.Call
+	case "bet", "raise":
+		a.Kind, a.Amount = game.Raise, p.Amount
+		if p.Action == "bet" {
+			a.Kind = game.Bet
+		}
+		if v.MinRaise > 0 && (p.Amount < v.MinRaise || p.Amount > v.Bets[v.Seat]+v.Stack) {
+			return answer{}, fmt.Errorf("amount must be between %d and %d (your whole stack), got %d", v.MinRaise, v.Bets[v.Seat]+v.Stack, p.Amount)
+		}
+	default:
+		return answer{}, fmt.Errorf("unknown action %q (valid options: fold, check, call, bet, raise, discard)", p.Action)
+	}
+	ok := false
+	for _, l := range legal {
+		ok = ok || l == p.Action
+	}
+	if !ok {
+		return answer{}, fmt.Errorf("cannot %s now (legal actions: %s)", p.Action, strings.Join(legal, ", "))
+	}
+	return answer{action: a}, nil
+}
+
+// summary reports the finished hand. The caller holds g.mu.
+func (g *drawGame) summary() HandSummary {
+	res := g.cur.result
+	sum := HandSummary{GameID: g.id, Hand: g.hand, Showdown: res.Showdown, Shown: []ShownHand{}, Net: res.Net[g.seat], Stacks: g.stacks()}
+	for _, e := range g.cur.events {
+		switch e.Kind {
+		case game.EventShow:
+			sum.Shown = append(sum.Shown, ShownHand{Name: e.Name, Cards: cardStrings(e.Cards), Description: hand.Describe(e.Eval)})
+		case game.EventWin:
+			sum.Winners = append(sum.Winners, PotWinner{Name: e.Name, Amount: e.Amount, Pot: e.Pot})
+		}
+	}
+	return sum
+}
+
+func (s *gameStore) handleStartGame(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params StartGameParams
+	if err := json.Unmarshal(req.Params.Arguments, &params); err != nil {
+		return nil, err
+	}
+	g, err := s.start(params, gameOwner(req))
+	if err != nil {
+		return nil, err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	st := g.state()
+	return structuredResult(formatGameState(st), st)
+}
+
+func (s *gameStore) handleGetState(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params GameParams
+	if err := json.Unmarshal(req.Params.Arguments, &params); err != nil {
+		return nil, err
+	}
+	g, err := s.get(params.GameID, gameOwner(req))
+	if err != nil {
+		return nil, err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	st := g.state()
+	return structuredResult(formatGameState(st), st)
+}
+
+func (s *gameStore) handleAct(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params ActParams
+	if err := json.Unmarshal(req.Params.Arguments, &params); err != nil {
+		return nil, err
+	}
+	g, err := s.get(params.GameID, gameOwner(req))
+	if err != nil {
+		return nil, err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	a, err := g.move(params)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.respond(a); err != nil {
+		return nil, err
+	}
+	st := g.state()
+	return structuredResult(formatGameState(st), st)
+}
+
+func (s *gameStore) handleShowdown(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params GameParams
+	if err := json.Unmarshal(req.Params.Arguments, &params); err != nil {
+		return nil, err
+	}
+	g, err := s.get(params.GameID, gameOwner(req))
+	if err != nil {
+		return nil, err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.cur.prompt != "" {
+		return nil, fmt.Errorf("the hand is still being played; it is your turn to %s", g.cur.prompt)
+	}
+	if g.cur.err != nil {
+		return nil, fmt.Errorf("hand %d was abandoned: %w", g.hand, g.cur.err)
+	}
+	sum := g.summary()
+	if g.over() {
+		g.close() // the goroutine is done; the final state stays readable until expiry
+	} else if err := g.dealNext(); err != nil {
+		return nil, err
+	}
+	next := g.state()
+	sum.Next = &next
+	return structuredResult(formatHandSummary(sum), sum)
+}
+
+func formatGameState(st GameState) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Game %s, hand %d: %s\n", st.GameID, st.Hand, strings.ReplaceAll(st.Phase, "_", " "))
+	if len(st.Cards) > 0 {
+		fmt.Fprintf(&b, "Your cards: %s\n", strings.Join(st.Cards, " "))
+	}
+	if st.Round != "" {
+		fmt.Fprintf(&b, "Round: %s, pot %d, to call %d\n", st.Round, st.Pot, st.ToCall)
+	}
+	for _, p := range st.Players {
+		marks := ""
+		if p.Button {
+			marks += " (button)"
+		}
+		if p.Folded {
+			marks += " (folded)"
+		}
+		you := ""
+		if p.You {
+			you = " <- you"
+		}
+		fmt.Fprintf(&b, "  %-12s stack %6d  bet %5d%s%s\n", p.Name, p.Stack, p.Bet, marks, you)
+	}
+	for _, line := range st.Log {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+	if len(st.Legal) > 0 {
+		fmt.Fprintf(&b, "Legal actions: %s", strings.Join(st.Legal, ", "))
+		if st.MinRaise > 0 {
+			fmt.Fprintf(&b, " (minimum bet or raise to %d)", st.MinRaise)
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func formatHandSummary(sum HandSummary) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Hand %d result:\n", sum.Hand)
+	for _, s := range sum.Shown {
+		fmt.Fprintf(&b, "  %s shows %s: %s\n", s.Name, strings.Join(s.Cards, " "), s.Description)
+	}
+	for _, w := range sum.Winners {
+		pot := "the pot"
+		if w.Pot > 0 {
+			pot = fmt.Sprintf("side pot %d", w.Pot)
+		}
+		fmt.Fprintf(&b, "  %s wins %d from %s\n", w.Name, w.Amount, pot)
+	}
+	fmt.Fprintf(&b, "Your net: %+d\n\n", sum.Net)
+	b.WriteString(formatGameState(*sum.Next))
+	return b.String()
+}
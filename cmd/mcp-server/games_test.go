@@ -0,0 +1,294 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/cards"
+)
+
+// callGame calls a game tool and decodes its structured result into out, or returns
+// the tool error's text.
+func callGame[T any](t *testing.T, cs *mcp.ClientSession, tool string, args map[string]interface{}, out *T) string {
+	t.Helper()
+	res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{Name: tool, Arguments: args})
+	if err != nil {
+		return err.Error()
+	}
+	if res.IsError {
+		return res.Content[0].(*mcp.TextContent).Text
+	}
+	*out = decodeStructured[T](t, res)
+	return ""
+}
+
+func chips(players []GamePlayer) int {
+	total := 0
+	for _, p := range players {
+		total += p.Stack
+	}
+	return total
+}
+
+func TestGamePlaysHandsToTheEnd(t *testing.T) {
+	cs := connectTestClient(t)
+	var st GameState
+	require.Empty(t, callGame(t, cs, "start_game", map[string]interface{}{
+		"opponents": 2, "bot": "loose-aggressive", "stack": 20, "seed": 5,
+	}, &st))
+	assert.Regexp(t, `^game-[0-9a-f]{24}$`, st.GameID)
+	assert.Equal(t, 1, st.Hand)
+	require.Len(t, st.Players, 3)
+
+	id := st.GameID
+	hands := 0
+	for calls := 0; st.Phase != "game_over"; calls++ {
+		require.Less(t, calls, 2000, "the game should end")
+		switch st.Phase {
+		case "act":
+			require.Len(t, st.Cards, 5)
+			action := "check"
+			if slices.Contains(st.Legal, "call") {
+				action = "call"
+			}
+			require.Empty(t, callGame(t, cs, "act", map[string]interface{}{"game_id": id, "action": action}, &st))
+		case "discard":
+			assert.Equal(t, []string{"discard"}, st.Legal)
+			require.Empty(t, callGame(t, cs, "act", map[string]interface{}{"game_id": id, "action": "discard", "discards": []int{0, 1}}, &st))
+		case "hand_over":
+			assert.Equal(t, 60, chips(st.Players), "chips are conserved")
+			var sum HandSummary
+			require.Empty(t, callGame(t, cs, "showdown", map[string]interface{}{"game_id": id}, &sum))
+			hands++
+			assert.Equal(t, hands, sum.Hand)
+			assert.NotEmpty(t, sum.Winners)
+			if sum.Showdown {
+				assert.GreaterOrEqual(t, len(sum.Shown), 2)
+			}
+			require.NotNil(t, sum.Next)
+			st = *sum.Next
+		default:
+			t.Fatalf("unexpected phase %q", st.Phase)
+		}
+	}
+	assert.Greater(t, hands, 0)
+
+	var again GameState
+	require.Empty(t, callGame(t, cs, "get_state", map[string]interface{}{"game_id": id}, &again))
+	assert.Equal(t, "game_over", again.Phase)
+	assert.Empty(t, again.Legal)
+}
+
+func TestGameRejectsIllegalMoves(t *testing.T) {
+	cs := connectTestClient(t)
+	var st GameState
+	require.Empty(t, callGame(t, cs, "start_game", map[string]interface{}{"opponents": 1, "seed": 2}, &st))
+	id := st.GameID
+	require.Equal(t, "act", st.Phase)
+	assert.Equal(t, 400, chips(st.Players)+st.Pot, "both players bought in for 100 big blinds")
+
+	tests := []struct {
+		args map[string]interface{}
+		want string
+	}{
+		{map[string]interface{}{"game_id": "game-9", "action": "call"}, `no game "game-9"`},
+		{map[string]interface{}{"game_id": id, "action": "discard"}, "time to bet, not discard"},
+		{map[string]interface{}{"game_id": id, "action": "shove"}, "unknown action"},
+		{map[string]interface{}{"game_id": id, "action": "raise", "amount": 1}, "amount must be between"},
+		{map[string]interface{}{"game_id": id, "action": "raise", "amount": 100000}, "your whole stack"},
+	}
+	if slices.Contains(st.Legal, "call") {
+		tests = append(tests, struct {
+			args map[string]interface{}
+			want string
+		}{map[string]interface{}{"game_id": id, "action": "check"}, "cannot check now"})
+	}
+	for _, tc := range tests {
+		var ignored GameState
+		assert.Contains(t, callGame(t, cs, "act", tc.args, &ignored), tc.want)
+	}
+	var sum HandSummary
+	assert.Contains(t, callGame(t, cs, "showdown", map[string]interface{}{"game_id": id}, &sum), "still being played")
+
+	// the errors left the decision pending
+	var same GameState
+	require.Empty(t, callGame(t, cs, "get_state", map[string]interface{}{"game_id": id}, &same))
+	assert.Equal(t, st, same)
+}
+
+func TestGameDiscardValidation(t *testing.T) {
+	store := newGameStore(time.Minute)
+	g, err := store.start(StartGameParams{Opponents: 1, Seed: 3}, "")
+	require.NoError(t, err)
+	defer g.close()
+	v := g.cur.view
+	v.Hand, err = cards.ParseList("Ah Kd 9c 5s 2c")
+	require.NoError(t, err)
+	g.cur = turn{prompt: "discard", view: v}
+
+	for _, tc := range []struct {
+		discards []int
+		want     string
+	}{
+		{[]int{5}, "out of range"},
+		{[]int{1, 1}, "given twice"},
+		{[]int{0, 1, 2, 3, 4}, "discards are allowed"},
+		{[]int{0, 1, 2, 3}, "only when keeping an ace"},
+	} {
+		_, err := g.move(ActParams{Action: "discard", Discards: tc.discards})
+		assert.ErrorContains(t, err, tc.want)
+	}
+	_, err = g.move(ActParams{Action: "call"})
+	assert.ErrorContains(t, err, "time to discard")
+	a, err := g.move(ActParams{Action: "discard"})
+	require.NoError(t, err)
+	assert.Empty(t, a.discards, "no indices stands pat")
+	a, err = g.move(ActParams{Action: "discard", Discards: []int{1, 2, 3, 4}})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4}, a.discards, "four keeping the ace")
+}
+
+func TestGameStartValidation(t *testing.T) {
+	store := newGameStore(time.Minute)
+	for _, tc := range []struct {
+		params StartGameParams
+		want   string
+	}{
+		{StartGameParams{Opponents: 8}, "opponents must be between 1 and 7"},
+		{StartGameParams{Bot: "rules:/etc/passwd"}, "not available here"},
+		{StartGameParams{Bot: "shark"}, "unknown bot"},
+		{StartGameParams{Ante: -1}, ">= 0"},
+		{StartGameParams{Name: "Bot 1"}, "taken by a bot"},
+	} {
+		_, err := store.start(tc.params, "")
+		assert.ErrorContains(t, err, tc.want)
+	}
+	assert.Empty(t, store.games)
+}
+
+func TestGamesExpire(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := newGameStore(10 * time.Minute)
+	store.now = func() time.Time { return now }
+
+	g, err := store.start(StartGameParams{Opponents: 1, Seed: 1}, "")
+	require.NoError(t, err)
+	now = now.Add(9 * time.Minute)
+	_, err = store.get(g.id, "")
+	require.NoError(t, err, "a call keeps the game alive")
+
+	now = now.Add(11 * time.Minute)
+	_, err = store.get(g.id, "")
+	assert.ErrorContains(t, err, "may have expired")
+	assert.Empty(t, store.games)
+	select {
+	case <-g.quit:
+	default:
+		t.Fatal("an expired game is closed")
+	}
+}
+
+func TestGamesBelongToTheirSession(t *testing.T) {
+	s := newServer(serverOptions{})
+	alice, bob := connectServer(t, s), connectServer(t, s)
+	var st GameState
+	require.Empty(t, callGame(t, alice, "start_game", map[string]interface{}{"opponents": 1, "seed": 2}, &st))
+	var other GameState
+	require.Empty(t, callGame(t, alice, "start_game", map[string]interface{}{"opponents": 1, "seed": 2}, &other))
+	assert.NotEqual(t, st.GameID, other.GameID)
+
+	id := map[string]interface{}{"game_id": st.GameID}
+	var ignored GameState
+	assert.Contains(t, callGame(t, bob, "get_state", id, &ignored), "no game")
+	assert.Contains(t, callGame(t, bob, "act", map[string]interface{}{"game_id": st.GameID, "action": "fold"}, &ignored), "no game")
+	var sum HandSummary
+	assert.Contains(t, callGame(t, bob, "showdown", id, &sum), "no game")
+
+	var same GameState
+	require.Empty(t, callGame(t, alice, "get_state", id, &same))
+	assert.Equal(t, st, same, "bob's attempts changed nothing")
+}
+
+// headers adds fixed headers to every request an MCP client makes.
+type headers map[string]string
+
+func (h headers) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	for k, v := range h {
+		r.Header.Set(k, v)
+	}
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestGamesBelongToTheirAPIKey(t *testing.T) {
+	srv := startSecureServer(t, security{keys: testKeys(t)})
+	connect := func(h headers) *mcp.ClientSession {
+		client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+		cs, err := client.Connect(context.Background(), &mcp.StreamableClientTransport{
+			Endpoint: srv.URL + "/mcp", HTTPClient: &http.Client{Transport: h},
+		}, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { cs.Close() })
+		return cs
+	}
+	var st GameState
+	require.Empty(t, callGame(t, connect(headers{"X-API-Key": aliceKey}), "start_game", map[string]interface{}{"opponents": 1, "seed": 2}, &st))
+	id := map[string]interface{}{"game_id": st.GameID}
+
+	var same GameState
+	assert.Empty(t, callGame(t, connect(headers{"Authorization": "Bearer " + aliceKey}), "get_state", id, &same), "the key owns the game, not the session")
+	assert.Equal(t, st.GameID, same.GameID)
+
+	var ignored GameState
+	assert.Contains(t, callGame(t, connect(headers{"X-API-Key": bobKey}), "get_state", id, &ignored), "no game")
+	forged := headers{"X-API-Key": bobKey, keyNameHeader: "alice"}
+	assert.Contains(t, callGame(t, connect(forged), "get_state", id, &ignored), "no game", "clients cannot claim another key")
+}
+
+func TestClosedGameDoesNotHang(t *testing.T) {
+	// a game whose goroutine has gone, as after an expiry mid-call
+	g := &drawGame{deal: make(chan struct{}), turns: make(chan turn), answers: make(chan answer), quit: make(chan struct{})}
+	g.close()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.ErrorIs(t, g.dealNext(), errGameClosed)
+		assert.ErrorIs(t, g.respond(answer{}), errGameClosed)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("a call on a closed game blocked")
+	}
+}
+
+func TestGameExpiresDuringCall(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := newGameStore(time.Minute)
+	store.now = func() time.Time { return now }
+	g, err := store.start(StartGameParams{Opponents: 1, Seed: 2}, "")
+	require.NoError(t, err)
+	require.Equal(t, "act", g.cur.prompt)
+
+	g.mu.Lock()
+	now = now.Add(2 * time.Minute)
+	_, err = store.get("game-none", "") // any store call sweeps
+	require.Error(t, err)
+	done := make(chan error, 1)
+	go func() {
+		defer g.mu.Unlock()
+		done <- g.respond(answer{})
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the call held the game's lock forever")
+	}
+}
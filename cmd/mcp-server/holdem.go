@@ -0,0 +1,291 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/equity"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// maxExactRunouts is the most board runouts holdem_equity will enumerate. The flop and
+// turn are always well under it (a three-way flop has 903); preflop has over a million,
+// which takes too long for a tool call, so it is sampled.
+const maxExactRunouts = 50000
+
+// maxHoldemPlayers leaves enough cards for a full board.
+const maxHoldemPlayers = 10
+
+// HoldemParams are the arguments of the holdem_best_hand and holdem_equity tools.
+type HoldemParams struct {
+	Players []HoldemPlayer `json:"players"`
+	Board   []string       `json:"board"`
+	Dead    []string       `json:"dead"`
+	Method  string         `json:"method"` // equity only: auto, exact or sample
+	Trials  int            `json:"trials"`
+	Seed    int64          `json:"seed"`
+}
+
+// HoldemPlayer is one player's hole cards.
+type HoldemPlayer struct {
+	Name string   `json:"name"`
+	Hole []string `json:"hole"`
+}
+
+// HoldemHand is a player's best hand on the current board.
+type HoldemHand struct {
+	Name        string   `json:"name"`
+	Hole        []string `json:"hole"`
+	Best        []string `json:"best"` // the best five cards; all of them while fewer than five are known
+	Category    string   `json:"category"`
+	Description string   `json:"description"`
+	Place       int      `json:"place"` // on the current board; tied hands share a place
+	// Equity, Win and Tie are the shares of runouts won outright or split (holdem_equity only).
+	Equity *float64 `json:"equity,omitempty"`
+	Win    *float64 `json:"win,omitempty"`
+	Tie    *float64 `json:"tie,omitempty"`
+}
+
+// HoldemResult is the structured output of both tools.
+type HoldemResult struct {
+	Board   []string     `json:"board"`
+	Players []HoldemHand `json:"players"`
+	Method  string       `json:"method,omitempty"` // exact or sample
+	Trials  int          `json:"trials,omitempty"` // runouts enumerated or sampled
+}
+
+var holdemPlayersSchema = map[string]interface{}{
+	"type":     "array",
+	"minItems": 1,
+	"maxItems": maxHoldemPlayers,
+	"items": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{"type": "string", "description": "Player name; defaults to 'Player N'"},
+			"hole": map[string]interface{}{
+				"type":        "array",
+				"items":       cardListSchema["items"],
+				"minItems":    2,
+				"maxItems":    2,
+				"description": "The player's two hole cards",
+			},
+		},
+		"required": []string{"hole"},
+	},
+}
+
+var holdemBoardSchema = map[string]interface{}{
+	"type":        "array",
+	"items":       cardListSchema["items"],
+	"maxItems":    5,
+	"description": "Community cards dealt so far: none preflop, 3 on the flop, 4 on the turn, 5 on the river",
+}
+
+var holdemBestHandTool = &mcp.Tool{
+	Name:        "holdem_best_hand",
+	Description: "Texas Hold'em: find each player's best five-card hand from their two hole cards and the board, and rank the players as the board stands.",
+	InputSchema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"players": holdemPlayersSchema,
+			"board":   holdemBoardSchema,
+		},
+		"required": []string{"players", "board"},
+	},
+}
+
+var holdemEquityTool = &mcp.Tool{
+	Name:        "holdem_equity",
+	Description: "Texas Hold'em all-in equity: each player's chance to win or tie from their hole cards and a 0-5 card board. Exact when the remaining runouts can be enumerated quickly (flop, turn and river), otherwise sampled.",
+	InputSchema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"players": holdemPlayersSchema,
+			"board":   holdemBoardSchema,
+			"dead":    cardListSchema,
+			"method": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"auto", "exact", "sample"},
+				"description": fmt.Sprintf("exact enumerates every runout (up to %d), sample deals random ones; auto picks exact when it is cheap", maxExactRunouts),
+			},
+			"trials": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     200000,
+				"description": fmt.Sprintf("Runouts to sample; default %d", equity.DefaultTrials),
+			},
+			"seed": map[string]interface{}{"type": "integer", "description": "Random seed for reproducible sampling"},
+		},
+		"required": []string{"players"},
+	},
+}
+
+// holdemDeal is the parsed input shared by both tools.
+type holdemDeal struct {
+	names []string
+	holes []equity.Combo
+	board []cards.Card
+	dead  []cards.Card
+}
+
+func parseHoldem(params HoldemParams, minPlayers int) (*holdemDeal, error) {
+	if len(params.Players) < minPlayers || len(params.Players) > maxHoldemPlayers {
+		return nil, fmt.Errorf("%d to %d players are required, got %d", minPlayers, maxHoldemPlayers, len(params.Players))
+	}
+	if len(params.Board) > 5 {
+		return nil, fmt.Errorf("the board has at most 5 cards, got %d", len(params.Board))
+	}
+	d := &holdemDeal{}
+	var err error
+	if d.board, err = parseCards("board card", params.Board); err != nil {
+		return nil, err
+	}
+	if d.dead, err = parseCards("dead card", params.Dead); err != nil {
+		return nil, err
+	}
+	known := append(append([]cards.Card(nil), d.board...), d.dead...)
+	names := map[string]bool{}
+	for i, p := range params.Players {
+		name := strings.TrimSpace(p.Name)
+		if name == "" {
+			name = fmt.Sprintf("Player %d", i+1)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("duplicate player name %q", name)
+		}
+		names[name] = true
+		if len(p.Hole) != 2 {
+			return nil, fmt.Errorf("%s: must provide exactly 2 hole cards, got %d", name, len(p.Hole))
+		}
+		hole, err := parseCards(name+" hole card", p.Hole)
+		if err != nil {
+			return nil, err
+		}
+		d.names = append(d.names, name)
+		d.holes = append(d.holes, equity.Combo{hole[0], hole[1]})
+		known = append(known, hole...)
+	}
+	if err := checkDistinct(known); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// bestHands evaluates every player on the board as it stands and places them.
+func (d *holdemDeal) bestHands() HoldemResult {
+	res := HoldemResult{Board: cardStrings(d.board)}
+	evals := make([]hand.EvaluatedHand, len(d.holes))
+	for i, h := range d.holes {
+		best, e := hand.BestHand(append([]cards.Card{h[0], h[1]}, d.board...))
+		evals[i] = e
+		res.Players = append(res.Players, HoldemHand{
+			Name: d.names[i], Hole: cardStrings(h[:]), Best: cardStrings(best.Cards),
+			Category: e.Category.String(), Description: hand.Describe(e),
+		})
+	}
+	for i, place := range hand.Places(evals) {
+		res.Players[i].Place = place
+	}
+	return res
+}
+
+func cardStrings(cs []cards.Card) []string {
+	out := make([]string, len(cs))
+	for i, c := range cs {
+		out[i] = c.String()
+	}
+	return out
+}
+
+func handleHoldemBestHand(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params HoldemParams
+	if err := json.Unmarshal(req.Params.Arguments, &params); err != nil {
+		return nil, err
+	}
+	d, err := parseHoldem(params, 1)
+	if err != nil {
+		return nil, err
+	}
+	res := d.bestHands()
+	return structuredResult(formatHoldem(res), res)
+}
+
+func handleHoldemEquity(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params HoldemParams
+	if err := json.Unmarshal(req.Params.Arguments, &params); err != nil {
+		return nil, err
+	}
+	d, err := parseHoldem(params, 2)
+	if err != nil {
+		return nil, err
+	}
+	if params.Trials < 0 || params.Trials > 200000 {
+		return nil, fmt.Errorf("trials must be between 1 and 200000, got %d", params.Trials)
+	}
+
+	runouts := equity.Runouts(len(d.holes), d.board, d.dead)
+	method := params.Method
+	switch method {
+	case "", "auto":
+		method = "sample"
+		if runouts <= maxExactRunouts {
+			method = "exact"
+		}
+	case "exact":
+		if runouts > maxExactRunouts {
+			return nil, fmt.Errorf("%d runouts are too many to enumerate (limit %d); use method sample", runouts, maxExactRunouts)
+		}
+	case "sample":
+	default:
+		return nil, fmt.Errorf("unknown method %q (valid options: auto, exact, sample)", params.Method)
+	}
+
+	var eq equity.Result
+	if method == "exact" {
+		eq, err = equity.Enumerate(d.holes, d.board, d.dead)
+	} else {
+		ranges := make([]equity.Range, len(d.holes))
+		for i, h := range d.holes {
+			ranges[i] = equity.Range{h}
+		}
+		eq, err = equity.Compute(ranges[0], ranges[1:], equity.Options{Board: d.board, Dead: d.dead, Trials: params.Trials, Seed: params.Seed})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := d.bestHands()
+	res.Method, res.Trials = method, eq.Trials
+	for i := range res.Players {
+		res.Players[i].Equity, res.Players[i].Win, res.Players[i].Tie = &eq.Equity[i], &eq.Win[i], &eq.Tie[i]
+	}
+	return structuredResult(formatHoldem(res), res)
+}
+
+func formatHoldem(res HoldemResult) string {
+	var b strings.Builder
+	board := "(none)"
+	if len(res.Board) > 0 {
+		board = strings.Join(res.Board, " ")
+	}
+	fmt.Fprintf(&b, "Board: %s", board)
+	for _, p := range res.Players {
+		fmt.Fprintf(&b, "\n%s [%s]: %s", p.Name, strings.Join(p.Hole, " "), p.Description)
+		if p.Equity != nil {
+			fmt.Fprintf(&b, " - equity %.2f%% (win %.2f%%, tie %.2f%%)", 100**p.Equity, 100**p.Win, 100**p.Tie)
+		}
+	}
+	if res.Method != "" {
+		what := "sampled runouts"
+		if res.Method == "exact" {
+			what = "runouts, exact"
+		}
+		fmt.Fprintf(&b, "\n(%d %s)", res.Trials, what)
+	}
+	return b.String()
+}
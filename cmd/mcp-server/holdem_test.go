@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func callHoldem(t *testing.T, tool string, args map[string]interface{}) (*mcp.CallToolResult, HoldemResult) {
+	t.Helper()
+	cs := connectTestClient(t)
+	res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{Name: tool, Arguments: args})
+	require.NoError(t, err)
+	require.False(t, res.IsError, "%v", res.Content)
+	return res, decodeStructured[HoldemResult](t, res)
+}
+
+func TestHoldemBestHand(t *testing.T) {
+	res, out := callHoldem(t, "holdem_best_hand", map[string]interface{}{
+		"players": []map[string]interface{}{
+			{"name": "alice", "hole": []string{"Ah", "Kh"}},
+			{"name": "bob", "hole": []string{"9c", "9d"}},
+			{"hole": []string{"2s", "3d"}},
+		},
+		"board": []string{"Qh", "Jh", "2h", "9s", "4c"},
+	})
+	require.Len(t, out.Players, 3)
+	alice := out.Players[0]
+	assert.Equal(t, "Flush", alice.Category)
+	assert.ElementsMatch(t, []string{"A♥", "K♥", "Q♥", "J♥", "2♥"}, alice.Best)
+	assert.Equal(t, 1, alice.Place)
+	assert.Equal(t, "Three of a Kind", out.Players[1].Category)
+	assert.Equal(t, 2, out.Players[1].Place)
+	assert.Equal(t, "Player 3", out.Players[2].Name)
+	assert.Equal(t, 3, out.Players[2].Place)
+	assert.Nil(t, alice.Equity, "best hand reports no equity")
+	assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, "alice [A♥ K♥]: ")
+}
+
+func TestHoldemEquityExact(t *testing.T) {
+	args := map[string]interface{}{
+		"players": []map[string]interface{}{
+			{"name": "draw", "hole": []string{"Ah", "Kh"}},
+			{"name": "pair", "hole": []string{"Qs", "Qc"}},
+		},
+		"board": []string{"Qh", "7h", "2c", "3s"},
+	}
+	res, out := callHoldem(t, "holdem_equity", args)
+	assert.Equal(t, "exact", out.Method)
+	assert.Equal(t, 44, out.Trials)
+	// nine hearts are left, but the 2h and 3h also fill up the set of queens
+	require.NotNil(t, out.Players[0].Win)
+	assert.InDelta(t, 7.0/44, *out.Players[0].Win, 1e-9)
+	assert.InDelta(t, 1, *out.Players[0].Equity+*out.Players[1].Equity, 1e-9)
+	assert.Equal(t, "Three of a Kind", out.Players[1].Category)
+	assert.Equal(t, 1, out.Players[1].Place)
+	assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, "(44 runouts, exact)")
+}
+
+func TestHoldemEquityPreflopSamples(t *testing.T) {
+	args := map[string]interface{}{
+		"players": []map[string]interface{}{
+			{"hole": []string{"As", "Ah"}},
+			{"hole": []string{"Ks", "Kh"}},
+		},
+		"trials": 4000,
+		"seed":   7,
+	}
+	_, out := callHoldem(t, "holdem_equity", args)
+	assert.Equal(t, "sample", out.Method)
+	assert.Equal(t, 4000, out.Trials)
+	assert.InDelta(t, 0.82, *out.Players[0].Equity, 0.03)
+	assert.Empty(t, out.Players[0].Best[2:], "only the hole cards are known preflop")
+
+	_, again := callHoldem(t, "holdem_equity", args)
+	assert.Equal(t, out, again, "a seed fixes the result")
+}
+
+func TestHoldemErrors(t *testing.T) {
+	two := func(a, b string) map[string]interface{} { return map[string]interface{}{"hole": []string{a, b}} }
+	tests := []struct {
+		name string
+		tool string
+		args map[string]interface{}
+		want string
+	}{
+		{"one player equity", "holdem_equity", map[string]interface{}{"players": []map[string]interface{}{two("As", "Ah")}}, "2 to 10 players"},
+		{"three hole cards", "holdem_best_hand", map[string]interface{}{"players": []map[string]interface{}{{"hole": []string{"As", "Ah", "Ad"}}}, "board": []string{}}, "exactly 2 hole cards"},
+		{"six board cards", "holdem_best_hand", map[string]interface{}{"players": []map[string]interface{}{two("As", "Ah")}, "board": []string{"2c", "3c", "4c", "5c", "6c", "7c"}}, "at most 5 cards"},
+		{"shared card", "holdem_equity", map[string]interface{}{"players": []map[string]interface{}{two("As", "Ah"), two("As", "Kd")}}, "appears more than once"},
+		{"exact preflop", "holdem_equity", map[string]interface{}{"players": []map[string]interface{}{two("As", "Ah"), two("Ks", "Kd")}, "method": "exact"}, "use method sample"},
+		{"bad method", "holdem_equity", map[string]interface{}{"players": []map[string]interface{}{two("As", "Ah"), two("Ks", "Kd")}, "method": "guess"}, "unknown method"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := connectTestClient(t)
+			res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{Name: tc.tool, Arguments: tc.args})
+			if err != nil {
+				assert.ErrorContains(t, err, tc.want)
+				return
+			}
+			require.True(t, res.IsError)
+			assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, tc.want)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startHTTPServer(t *testing.T, sessionTimeout time.Duration) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(newHTTPHandler(newServer(serverOptions{}), httpOptions{sessionTimeout: sessionTimeout}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func connectHTTPClient(t *testing.T, url string) *mcp.ClientSession {
+	t.Helper()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	cs, err := client.Connect(context.Background(), &mcp.StreamableClientTransport{Endpoint: url + "/mcp"}, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { cs.Close() })
+	return cs
+}
+
+// postMCP sends one JSON-RPC message the way a streamable HTTP client does.
+func postMCP(t *testing.T, url, session, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url+"/mcp", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	if session != "" {
+		req.Header.Set("Mcp-Session-Id", session)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+const initializeRequest = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"raw","version":"1"}}}`
+
+// initialize opens a session by hand and returns its ID.
+func initialize(t *testing.T, url string) string {
+	t.Helper()
+	resp := postMCP(t, url, "", initializeRequest)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	id := resp.Header.Get("Mcp-Session-Id")
+	require.NotEmpty(t, id)
+	resp = postMCP(t, url, id, `{"jsonrpc":"2.0","method":"notifications/initialized","params":{}}`)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	return id
+}
+
+func ping(t *testing.T, url, session string) int {
+	t.Helper()
+	return postMCP(t, url, session, `{"jsonrpc":"2.0","id":2,"method":"ping"}`).StatusCode
+}
+
+func TestHTTPConcurrentSessions(t *testing.T) {
+	srv := startHTTPServer(t, time.Minute)
+
+	const clients = 5
+	sessions := make([]*mcp.ClientSession, clients)
+	for i := range sessions {
+		sessions[i] = connectHTTPClient(t, srv.URL)
+	}
+	var wg sync.WaitGroup
+	for i, cs := range sessions {
+		wg.Add(1)
+		go func(i int, cs *mcp.ClientSession) {
+			defer wg.Done()
+			// each session plays its own game alongside the others
+			var st GameState
+			assert.Empty(t, callGame(t, cs, "start_game", map[string]interface{}{"opponents": 1, "seed": i + 1}, &st))
+			var again GameState
+			assert.Empty(t, callGame(t, cs, "get_state", map[string]interface{}{"game_id": st.GameID}, &again))
+			assert.Equal(t, st, again)
+
+			res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+				Name:      "evaluate_poker_hand",
+				Arguments: map[string]interface{}{"cards": []string{"As", "Ad", "Kc", "Kd", fmt.Sprintf("%dh", i+2)}},
+			})
+			if assert.NoError(t, err) {
+				assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, "Two Pair")
+			}
+		}(i, cs)
+	}
+	wg.Wait()
+
+	seen := map[string]bool{}
+	for _, cs := range sessions {
+		assert.NotEmpty(t, cs.ID())
+		assert.False(t, seen[cs.ID()], "session IDs are unique")
+		seen[cs.ID()] = true
+	}
+}
+
+func TestHTTPStreamsSSE(t *testing.T) {
+	srv := startHTTPServer(t, time.Minute)
+	resp := postMCP(t, srv.URL, "", initializeRequest)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// an SSE event carries an ID, which is what a client resumes from
+	var lines []string
+	sc := bufio.NewScanner(resp.Body)
+	for sc.Scan() && sc.Text() != "" {
+		lines = append(lines, sc.Text())
+	}
+	text := strings.Join(lines, "\n")
+	assert.Contains(t, text, "id: ")
+	assert.Contains(t, text, `"serverInfo":{"name":"gopoker-mcp-server"`)
+}
+
+func TestHTTPServerStream(t *testing.T) {
+	srv := startHTTPServer(t, time.Minute)
+	id := initialize(t, srv.URL)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/mcp", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Mcp-Session-Id", id)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+}
+
+func TestHTTPDeleteEndsSession(t *testing.T) {
+	srv := startHTTPServer(t, time.Minute)
+	id := initialize(t, srv.URL)
+	require.Equal(t, http.StatusOK, ping(t, srv.URL, id))
+
+	req, err := http.NewRequest(http.MethodDelete, srv.URL+"/mcp", nil)
+	require.NoError(t, err)
+	req.Header.Set("Mcp-Session-Id", id)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Less(t, resp.StatusCode, 300)
+
+	assert.Equal(t, http.StatusNotFound, ping(t, srv.URL, id))
+}
+
+func TestHTTPIdleSessionsExpire(t *testing.T) {
+	srv := startHTTPServer(t, 100*time.Millisecond)
+	id := initialize(t, srv.URL)
+	require.Equal(t, http.StatusOK, ping(t, srv.URL, id))
+
+	// polling would keep the session alive, so stay quiet for a while and ask once
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, http.StatusNotFound, ping(t, srv.URL, id))
+}
+
+func TestHTTPUnknownSession(t *testing.T) {
+	srv := startHTTPServer(t, time.Minute)
+	assert.Equal(t, http.StatusNotFound, ping(t, srv.URL, "no-such-session"))
+}
+
+func TestHTTPHealth(t *testing.T) {
+	srv := startHTTPServer(t, time.Minute)
+	resp, err := http.Get(srv.URL + "/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -3,15 +3,22 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
-	"io"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
 	"strings"
-	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/time/rate"
 
 	"github.com/dangogh/GoPoker/cards"
 	"github.com/dangogh/GoPoker/hand"
@@ -26,6 +33,32 @@ type EvaluateHandParams struct {
 	Cards []string `json:"cards"`
 }
 
+// EvaluateHandResult is the structured output of evaluate_poker_hand, so clients need
+// not parse the text. evaluateOutputSchema describes it.
+type EvaluateHandResult struct {
+	Cards        []string      `json:"cards"`
+	Category     string        `json:"category"`
+	CategoryRank int           `json:"category_rank"` // 0 for high card up to 8 for a straight flush
+	Ranks        []string      `json:"ranks"`         // tiebreak ranks, most significant first
+	Description  string        `json:"description"`
+	Discards     []DiscardCard `json:"discards"`
+	MaxDiscards  int           `json:"max_discards"`
+	Strength     HandStrength  `json:"strength"`
+}
+
+// DiscardCard is a recommended discard.
+type DiscardCard struct {
+	Index int    `json:"index"`
+	Card  string `json:"card"`
+}
+
+// HandStrength places the hand among all five-card hands.
+type HandStrength struct {
+	Rank       int     `json:"rank"` // 1 (royal flush) to hand.DistinctHands
+	Percentile float64 `json:"percentile"`
+	Beats      float64 `json:"beats"` // fraction of dealt hands strictly weaker
+}
+
 var stringToSuit = map[string]cards.Suit{
 	"clubs":    cards.Clubs,
 	"diamonds": cards.Diamonds,
@@ -57,8 +90,15 @@ var stringToRank = map[string]cards.Rank{
 	"ace":   cards.Ace,
 }
 
+// parseCard accepts the spelled-out "rank suit" form ("A spades") and, for clients that
+// already speak it, compact notation ("As", "10h").
 func parseCard(cardStr string) (cards.Card, error) {
 	parts := strings.Fields(cardStr)
+	if len(parts) == 1 {
+		if c, err := cards.Parse(parts[0]); err == nil {
+			return c, nil
+		}
+	}
 	if len(parts) != 2 {
 		return cards.Card{}, fmt.Errorf("invalid card format: %s (expected 'rank suit')", cardStr)
 	}
@@ -76,235 +116,436 @@ func parseCard(cardStr string) (cards.Card, error) {
 	return cards.NewCard(suit, rank), nil
 }
 
+// checkDistinct rejects a hand that repeats a card.
+func checkDistinct(cs []cards.Card) error {
+	seen := map[cards.Card]bool{}
+	for _, c := range cs {
+		if seen[c] {
+			return fmt.Errorf("card %s appears more than once", c)
+		}
+		seen[c] = true
+	}
+	return nil
+}
+
 func main() {
-	transport := flag.String("transport", "stdio", "MCP transport protocol: stdio or streamable_http")
-	port := flag.String("port", "8080", "Port for streamable_http transport")
-	flag.Parse()
+	cfg, err := loadConfig(os.Args[1:], os.Getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	logger, err := newLogger(cfg.Log.Level, cfg.Log.Format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	slog.SetDefault(logger)
 
-	// Create MCP server
-	impl := &mcp.Implementation{
-		Name:    "gopoker-mcp-server",
-		Version: "1.0.0",
+	// SIGINT and SIGTERM start a graceful shutdown; a second signal kills the process.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	tracer, shutdownTracing, err := setupTracing(context.Background())
+	if err != nil {
+		logger.Error("tracing setup failed", "error", err)
+		os.Exit(1)
 	}
 
-	s := mcp.NewServer(impl, &mcp.ServerOptions{
-		Instructions: "Poker hand evaluation server for 5-card draw",
+	obs := newObserver(logger, tracer)
+	d := &drain{}
+	s := newServer(serverOptions{
+		tools:    cfg.Tools,
+		gameTTL:  time.Duration(cfg.Limits.GameTTL),
+		maxGames: cfg.Limits.MaxGames,
+		obs:      obs,
+		drain:    d,
 	})
 
-	// Define and add the tool
-	tool := &mcp.Tool{
-		Name:        "evaluate_poker_hand",
-		Description: "Evaluate a 5-card poker hand and get recommended discards for 5-card draw. Returns the hand category (e.g., Pair, Flush, Full House) and suggests which cards to discard to improve the hand. Each card is a string with rank and suit separated by space (e.g., 'A spades', 'K hearts', '10 clubs').",
-		InputSchema: map[string]interface{}{
-			"type": "object",
-			"properties": map[string]interface{}{
-				"cards": map[string]interface{}{
-					"type": "array",
-					"items": map[string]interface{}{
-						"type":        "string",
-						"description": "Card as 'rank suit', e.g., 'A spades', 'K hearts', '10 clubs'",
-						"pattern":     "^(2|3|4|5|6|7|8|9|10|J|Q|K|A) (clubs|diamonds|hearts|spades|♣|♦|♥|♠)$",
-					},
-					"minItems":    5,
-					"maxItems":    5,
-					"description": "Array of 5 cards, each as 'rank suit'",
-				},
-			},
-			"required": []string{"cards"},
-		},
+	switch cfg.Transport {
+	case "stdio":
+		logger.Info("starting GoPoker MCP server", "transport", "stdio")
+		err = serveStdio(ctx, s, &mcp.StdioTransport{}, d, time.Duration(cfg.Timeouts.Shutdown))
+	case "streamable_http":
+		err = runHTTP(ctx, cfg, s, obs, d, logger)
 	}
+	stop()
 
-	handler := func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		var params EvaluateHandParams
-		if err := json.Unmarshal(req.Params.Arguments, &params); err != nil {
-			return nil, err
-		}
+	flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if terr := shutdownTracing(flushCtx); terr != nil {
+		logger.Warn("flushing traces failed", "error", terr)
+	}
+	if err != nil {
+		logger.Error("server failed", "error", err)
+		os.Exit(1)
+	}
+	logger.Info("server stopped")
+}
 
-		if len(params.Cards) != 5 {
-			return nil, fmt.Errorf("must provide exactly 5 cards, got %d", len(params.Cards))
-		}
+// runHTTP serves the streamable HTTP transport until ctx ends.
+func runHTTP(ctx context.Context, cfg Config, s *mcp.Server, obs *observer, d *drain, logger *slog.Logger) error {
+	keys, err := loadAPIKeys(strings.Join(cfg.Auth.APIKeys, ","), cfg.Auth.APIKeysFile)
+	if err != nil {
+		return err
+	}
+	sec := security{keys: keys, origins: cfg.Auth.AllowedOrigins, rate: rate.Limit(cfg.Limits.Rate), burst: cfg.Limits.Burst}
+	if len(keys) == 0 && !isLoopback(cfg.Host) {
+		logger.Warn("listening without API keys; anyone who can reach the server can use it", "host", cfg.Host)
+	}
 
-		// Parse cards
-		cardList := make([]cards.Card, 5)
-		for i, cardStr := range params.Cards {
-			parsed, err := parseCard(cardStr)
-			if err != nil {
-				return nil, fmt.Errorf("card %d: %w", i, err)
-			}
-			cardList[i] = parsed
+	// The first strength lookup enumerates every five-card hand, so build the
+	// tables before reporting ready rather than on some client's first call.
+	var serving atomic.Bool
+	serving.Store(true)
+	ready := readyAfter(&serving, func() {
+		hand.Categories()
+		if serving.Load() {
+			logger.Info("ready")
 		}
+	})
 
-		// Evaluate hand
-		h := hand.Hand{Cards: cardList}
-		eval := hand.Evaluate(h)
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	srv := newHTTPServer(cfg, newHTTPHandler(s, httpOptions{
+		sessionTimeout:  time.Duration(cfg.Limits.SessionTimeout),
+		security:        sec,
+		maxRequestBytes: cfg.Limits.MaxRequestBytes,
+		obs:             obs,
+		ready:           ready,
+	}))
+	scheme := "http"
+	if cfg.TLS.Cert != "" {
+		scheme = "https"
+	}
+	logger.Info("starting GoPoker MCP server", "transport", "streamable_http", "url", scheme+"://"+addr+"/mcp")
+	err = serveHTTP(ctx, srv, ln, cfg.TLS.Cert, cfg.TLS.Key, s, d, &serving, time.Duration(cfg.Timeouts.Shutdown))
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
 
-		// Get recommended discards
-		maxDiscard := hand.ComputeMaxDiscard(h)
-		discardIdxs := hand.RecommendDiscards(h, maxDiscard)
+// isLoopback reports whether host only accepts local connections.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
 
-		// Format discarded cards
-		discards := make([]string, len(discardIdxs))
-		for i, idx := range discardIdxs {
-			discards[i] = cardList[idx].String()
+// defaultSessionTimeout matches gameTTL, so a session outlives none of its games.
+const defaultSessionTimeout = gameTTL
+
+// httpOptions configure newHTTPHandler. The zero value keeps sessions forever, checks
+// origins only and records nothing.
+type httpOptions struct {
+	sessionTimeout  time.Duration
+	security        security
+	maxRequestBytes int64       // 0 is unlimited
+	obs             *observer   // nil records nothing and serves no /metrics
+	ready           func() bool // nil is always ready
+}
+
+// newHTTPHandler serves MCP's streamable HTTP transport on /mcp. The SDK's handler does
+// the protocol: POST for messages with JSON or SSE responses, GET for the server's SSE
+// stream, DELETE to end a session, and the Mcp-Session-Id header to tell sessions
+// apart. Sent events are kept in memory so a client whose stream drops can resume it
+// with Last-Event-ID.
+//
+// The security policy guards /mcp only, so orchestrators can probe /livez (or /health)
+// to see the process is up, /readyz to see it should get traffic, and scrape /metrics
+// without a key.
+func newHTTPHandler(s *mcp.Server, opts httpOptions) http.Handler {
+	streamable := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return s }, &mcp.StreamableHTTPOptions{
+		EventStore:     mcp.NewMemoryEventStore(nil),
+		SessionTimeout: opts.sessionTimeout,
+	})
+	var h http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			// the server's event stream is open for as long as the session
+			http.NewResponseController(w).SetWriteDeadline(time.Time{})
+		}
+		if opts.maxRequestBytes > 0 {
+			r.Body = http.MaxBytesReader(w, r.Body, opts.maxRequestBytes)
 		}
+		streamable.ServeHTTP(w, r)
+	})
+	h = opts.security.protect(h)
+	mux := http.NewServeMux()
+	if opts.obs != nil {
+		h = opts.obs.handler(h)
+		mux.Handle("/metrics", opts.obs.metricsHandler())
+	}
+	mux.Handle("/mcp", h)
+	live := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "OK")
+	}
+	mux.HandleFunc("/health", live)
+	mux.HandleFunc("/livez", live)
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if opts.ready != nil && !opts.ready() {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ready")
+	})
+	return mux
+}
 
-		// Build response text
-		resultText := fmt.Sprintf(`Hand Category: %s
-Recommended Discards: %v
-Max Discards Allowed: %d
-Hand Strength: %s with ranks %v`,
-			eval.Category.String(),
-			discards,
-			maxDiscard,
-			eval.Category.String(),
-			eval.Ranks,
-		)
-
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{
-					Text: resultText,
+var evaluateTool = &mcp.Tool{
+	Name:        "evaluate_poker_hand",
+	Description: "Evaluate a 5-card poker hand and get recommended discards for 5-card draw. Returns the hand category (e.g., Pair, Flush, Full House) and suggests which cards to discard to improve the hand. Each card is a string with rank and suit separated by space (e.g., 'A spades', 'K hearts', '10 clubs') or in compact notation (e.g., 'As', '10h').",
+	InputSchema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"cards": map[string]interface{}{
+				"type":        "array",
+				"items":       cardListSchema["items"],
+				"minItems":    5,
+				"maxItems":    5,
+				"description": "Array of 5 distinct cards",
+			},
+		},
+		"required": []string{"cards"},
+	},
+	OutputSchema: evaluateOutputSchema,
+}
+
+var evaluateOutputSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"cards":         map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "The hand, e.g. 'A♠'"},
+		"category":      map[string]interface{}{"type": "string", "description": "Hand category, e.g. 'Full House'"},
+		"category_rank": map[string]interface{}{"type": "integer", "minimum": 0, "maximum": 8, "description": "0 for high card up to 8 for a straight flush"},
+		"ranks": map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string", "enum": []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}},
+			"description": "Tiebreak ranks, most significant first",
+		},
+		"description": map[string]interface{}{"type": "string", "description": "e.g. 'Aces full of Kings'"},
+		"discards": map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"index": map[string]interface{}{"type": "integer", "minimum": 0, "maximum": 4},
+					"card":  map[string]interface{}{"type": "string"},
 				},
+				"required":             []string{"index", "card"},
+				"additionalProperties": false,
 			},
-		}, nil
+			"description": "Recommended discards with their 0-based positions in the hand",
+		},
+		"max_discards": map[string]interface{}{"type": "integer", "minimum": 0, "maximum": 5},
+		"strength": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"rank":       map[string]interface{}{"type": "integer", "minimum": 1, "maximum": hand.DistinctHands},
+				"percentile": map[string]interface{}{"type": "number", "minimum": 0, "maximum": 100},
+				"beats":      map[string]interface{}{"type": "number", "minimum": 0, "maximum": 1},
+			},
+			"required":             []string{"rank", "percentile", "beats"},
+			"additionalProperties": false,
+		},
+	},
+	"required":             []string{"cards", "category", "category_rank", "ranks", "description", "discards", "max_discards", "strength"},
+	"additionalProperties": false,
+}
+
+// serverName identifies the server to clients and in traces.
+const serverName = "gopoker-mcp-server"
+
+// serverOptions configure newServer. The zero value serves every tool with the default
+// game limits and records nothing.
+type serverOptions struct {
+	tools    []string // tools to serve; empty serves all
+	gameTTL  time.Duration
+	maxGames int
+	obs      *observer
+	drain    *drain // lets shutdown wait for running tool calls
+}
+
+type serverTool struct {
+	tool    *mcp.Tool
+	handler mcp.ToolHandler
+}
+
+// serverTools pairs every tool with its handler, the game tools bound to games.
+func serverTools(games *gameStore) []serverTool {
+	return []serverTool{
+		{evaluateTool, handleEvaluateHand},
+		{outsTool, handleAnalyzeOuts},
+		{compareTool, handleCompareHands},
+		{holdemBestHandTool, handleHoldemBestHand},
+		{holdemEquityTool, handleHoldemEquity},
+		{startGameTool, games.handleStartGame},
+		{getStateTool, games.handleGetState},
+		{actTool, games.handleAct},
+		{showdownTool, games.handleShowdown},
 	}
+}
 
-	s.AddTool(tool, handler)
+// toolNames lists the tools newServer can serve. The handlers are never called, so they
+// need no game store.
+func toolNames() []string {
+	var names []string
+	for _, t := range serverTools(nil) {
+		names = append(names, t.tool.Name)
+	}
+	return names
+}
 
-	// Create transport based on flag
-	switch *transport {
-	case "stdio":
-		mcpTransport := &mcp.StdioTransport{}
-		log.Println("Starting GoPoker MCP server (stdio transport)")
-		if err := s.Run(context.Background(), mcpTransport); err != nil {
-			log.Fatalf("Server failed: %v", err)
-		}
+// newServer builds the MCP server with the poker tools registered, so tests can exercise
+// exactly what main serves. With an observer, every request is logged, measured and
+// traced.
+func newServer(opts serverOptions) *mcp.Server {
+	// Create MCP server
+	impl := &mcp.Implementation{
+		Name:    serverName,
+		Version: "1.0.0",
+	}
 
-	case "streamable_http":
-		log.Printf("Starting GoPoker MCP server on port %s (streamable_http transport)", *port)
+	s := mcp.NewServer(impl, &mcp.ServerOptions{
+		Instructions: "Poker hand evaluation server for 5-card draw. To play, call start_game, then act whenever the phase is act or discard and showdown when the hand is over.",
+	})
 
-		// Track active sessions
-		sessions := &sessionManager{
-			sessions: make(map[string]*sessionInfo),
+	games := newGameStore(gameTTL)
+	if opts.gameTTL > 0 {
+		games.ttl = opts.gameTTL
+	}
+	if opts.maxGames > 0 {
+		games.max = opts.maxGames
+	}
+	enabled := map[string]bool{}
+	for _, name := range opts.tools {
+		enabled[name] = true
+	}
+	names := map[string]bool{}
+	for _, t := range serverTools(games) {
+		if len(enabled) > 0 && !enabled[t.tool.Name] {
+			continue
 		}
-
-		http.HandleFunc("/mcp", func(w http.ResponseWriter, r *http.Request) {
-			if r.Method != http.MethodPost {
-				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-				return
-			}
-
-			// Get or create session ID
-			sessionID := r.Header.Get("Mcp-Session-Id")
-			if sessionID == "" {
-				// New session
-				sessionID = sessions.newSession()
-				log.Printf("New session: %s", sessionID)
-			}
-
-			// Set response headers
-			w.Header().Set("Content-Type", "application/json")
-			w.Header().Set("Mcp-Session-Id", sessionID)
-			w.Header().Set("Cache-Control", "no-cache")
-
-			// Create a bidirectional pipe for this request
-			pr, pw := io.Pipe()
-
-			// Wrap the response writer to capture writes
-			respWriter := &responseCapture{
-				ResponseWriter: w,
-				pipe:           pw,
-			}
-
-			// Create IOTransport for this request/response
-			transport := &mcp.IOTransport{
-				Reader: r.Body,
-				Writer: respWriter,
-			}
-
-			// Handle the connection
-			ctx := r.Context()
-			_, err := s.Connect(ctx, transport, nil)
-			if err != nil {
-				log.Printf("Connection error for session %s: %v", sessionID, err)
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-
-			// Read from pipe and write to response
-			io.Copy(w, pr)
-		})
-
-		http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-			fmt.Fprintln(w, "OK")
-		})
-
-		// Bind to all interfaces for remote access
-		addr := "0.0.0.0:" + *port
-		if err := http.ListenAndServe(addr, nil); err != nil {
-			log.Fatalf("HTTP server failed: %v", err)
+		tool := t.tool
+		if tool == startGameTool && games.ttl != gameTTL {
+			tool = &mcp.Tool{}
+			*tool = *startGameTool
+			tool.Description = fmt.Sprintf(startGameDescription, games.ttl)
 		}
+		s.AddTool(tool, t.handler)
+		names[tool.Name] = true
+	}
 
-	default:
-		log.Fatalf("Unknown transport: %s (valid options: stdio, streamable_http)", *transport)
+	addResources(s)
+	addPrompts(s)
+
+	if opts.drain != nil {
+		s.AddReceivingMiddleware(opts.drain.middleware)
+	}
+	if opts.obs != nil {
+		s.AddReceivingMiddleware(opts.obs.middleware(names))
+		opts.obs.watch(s, games)
 	}
+	return s
 }
 
-// responseCapture captures writes and pipes them through for streaming
-type responseCapture struct {
-	http.ResponseWriter
-	pipe *io.PipeWriter
-	once sync.Once
-}
+func handleEvaluateHand(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params EvaluateHandParams
+	if err := json.Unmarshal(req.Params.Arguments, &params); err != nil {
+		return nil, err
+	}
 
-func (r *responseCapture) Write(p []byte) (n int, err error) {
-	// Write to both the HTTP response and the pipe
-	n, err = r.ResponseWriter.Write(p)
-	if err != nil {
-		return n, err
+	if len(params.Cards) != 5 {
+		return nil, fmt.Errorf("must provide exactly 5 cards, got %d", len(params.Cards))
 	}
 
-	// Flush if possible
-	if f, ok := r.ResponseWriter.(http.Flusher); ok {
-		f.Flush()
+	// Parse cards
+	cardList := make([]cards.Card, 5)
+	for i, cardStr := range params.Cards {
+		parsed, err := parseCard(cardStr)
+		if err != nil {
+			return nil, fmt.Errorf("card %d: %w", i, err)
+		}
+		cardList[i] = parsed
+	}
+	if err := checkDistinct(cardList); err != nil {
+		return nil, err
 	}
 
-	// Also write to pipe for coordination
-	r.pipe.Write(p)
+	// Evaluate hand
+	h := hand.Hand{Cards: cardList}
+	eval := hand.Evaluate(h)
 
-	return n, err
-}
+	// Get recommended discards
+	maxDiscard := hand.ComputeMaxDiscard(h)
+	discardIdxs := hand.RecommendDiscards(h, maxDiscard)
 
-func (r *responseCapture) Close() error {
-	r.once.Do(func() {
-		r.pipe.Close()
-	})
-	return nil
-}
+	strength, err := hand.StrengthOf(eval)
+	if err != nil {
+		return nil, err
+	}
 
-// sessionManager tracks active MCP sessions
-type sessionManager struct {
-	mu       sync.Mutex
-	sessions map[string]*sessionInfo
-	counter  int
-}
+	result := EvaluateHandResult{
+		Cards:        cardStrings(cardList),
+		Category:     eval.Category.String(),
+		CategoryRank: int(eval.Category),
+		Description:  hand.Describe(eval),
+		Discards:     []DiscardCard{},
+		MaxDiscards:  maxDiscard,
+		Strength: HandStrength{
+			Rank:       strength.Rank,
+			Percentile: strength.Percentile,
+			Beats:      strength.Beats,
+		},
+	}
+	for _, r := range eval.Ranks {
+		result.Ranks = append(result.Ranks, r.String())
+	}
 
-type sessionInfo struct {
-	id      string
-	created int64
-}
+	// Format discarded cards
+	discards := make([]string, len(discardIdxs))
+	for i, idx := range discardIdxs {
+		discards[i] = cardList[idx].String()
+		result.Discards = append(result.Discards, DiscardCard{Index: idx, Card: discards[i]})
+	}
 
-func (sm *sessionManager) newSession() string {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
+	// Build response text
+	resultText := fmt.Sprintf(`Hand Category: %s
+Recommended Discards: %v
+Max Discards Allowed: %d
+Hand Strength: %s (rank %d of %d, %.1f percentile, beats %.1f%% of hands)`,
+		eval.Category.String(),
+		discards,
+		maxDiscard,
+		strength.Description,
+		strength.Rank,
+		hand.DistinctHands,
+		strength.Percentile,
+		100*strength.Beats,
+	)
+
+	return structuredResult(resultText, result)
+}
 
-	sm.counter++
-	id := fmt.Sprintf("session-%d", sm.counter)
-	sm.sessions[id] = &sessionInfo{
-		id:      id,
-		created: 0, // Could use time.Now().Unix()
+// structuredResult returns v as structured content, with text for people and the
+// JSON encoding for clients that only read text content.
+func structuredResult(text string, v any) (*mcp.CallToolResult, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
 	}
-
-	return id
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: text},
+			&mcp.TextContent{Text: string(data)},
+		},
+		StructuredContent: v,
+	}, nil
 }
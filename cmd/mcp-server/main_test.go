@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/dangogh/GoPoker/cards"
 )
@@ -64,3 +69,160 @@ func TestParseCard(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCardCompact(t *testing.T) {
+	card, err := parseCard("Td")
+	assert.NoError(t, err)
+	assert.Equal(t, cards.NewCard(cards.Diamonds, cards.Ten), card)
+}
+
+func TestEvaluateHandCardInput(t *testing.T) {
+	cs := connectTestClient(t)
+	res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "evaluate_poker_hand",
+		Arguments: map[string]interface{}{"cards": []string{"As", "Ah", "10c", "9 spades", "4h"}},
+	})
+	require.NoError(t, err, "compact notation matches the input schema")
+	assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, "Hand Category: One Pair")
+
+	_, err = cs.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "evaluate_poker_hand",
+		Arguments: map[string]interface{}{"cards": []string{"As", "A spades", "Kc", "9s", "4h"}},
+	})
+	assert.ErrorContains(t, err, "appears more than once")
+}
+
+// connectTestClient serves newServer over in-memory transports and returns a connected
+// client session that is closed when the test ends.
+func connectTestClient(t *testing.T) *mcp.ClientSession {
+	t.Helper()
+	return connectServer(t, newServer(serverOptions{}))
+}
+
+// connectServer connects a new client session to s, so tests can share one server
+// between sessions.
+func connectServer(t *testing.T, s *mcp.Server) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	ss, err := s.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { ss.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	cs, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { cs.Close() })
+	return cs
+}
+
+// decodeStructured decodes a tool result's structured content the way a client
+// receives it, through JSON.
+func decodeStructured[T any](t *testing.T, res *mcp.CallToolResult) T {
+	t.Helper()
+	data, err := json.Marshal(res.StructuredContent)
+	require.NoError(t, err)
+	var v T
+	require.NoError(t, json.Unmarshal(data, &v))
+	return v
+}
+
+func TestEvaluateHandDescribesStrength(t *testing.T) {
+	cs := connectTestClient(t)
+	res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "evaluate_poker_hand",
+		Arguments: map[string]interface{}{"cards": []string{"A spades", "A hearts", "A clubs", "K spades", "K hearts"}},
+	})
+	require.NoError(t, err)
+	text := res.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "Hand Strength: Aces full of Kings (rank 167 of 7462")
+	assert.NotContains(t, text, "with ranks")
+}
+
+func TestEvaluateHandDescribesKickers(t *testing.T) {
+	cs := connectTestClient(t)
+	res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "evaluate_poker_hand",
+		Arguments: map[string]interface{}{"cards": []string{"A spades", "A hearts", "K clubs", "9 spades", "4 hearts"}},
+	})
+	require.NoError(t, err)
+	text := res.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "Hand Category: One Pair")
+	assert.Contains(t, text, "Hand Strength: Pair of Aces, K-9-4 kickers")
+}
+
+// outputSchema fetches a tool's declared output schema the way a client would.
+func outputSchema(t *testing.T, cs *mcp.ClientSession, name string) *jsonschema.Resolved {
+	t.Helper()
+	tools, err := cs.ListTools(context.Background(), nil)
+	require.NoError(t, err)
+	for _, tool := range tools.Tools {
+		if tool.Name != name {
+			continue
+		}
+		require.NotNil(t, tool.OutputSchema, "%s declares no output schema", name)
+		data, err := json.Marshal(tool.OutputSchema)
+		require.NoError(t, err)
+		var schema jsonschema.Schema
+		require.NoError(t, json.Unmarshal(data, &schema))
+		resolved, err := schema.Resolve(nil)
+		require.NoError(t, err)
+		return resolved
+	}
+	t.Fatalf("no tool %s", name)
+	return nil
+}
+
+func TestEvaluateHandStructuredOutput(t *testing.T) {
+	cs := connectTestClient(t)
+	schema := outputSchema(t, cs, "evaluate_poker_hand")
+
+	hands := map[string][]string{
+		"pair":           {"9 clubs", "9 diamonds", "A hearts", "5 spades", "2 clubs"},
+		"royal flush":    {"As", "Ks", "Qs", "Js", "10s"},
+		"high card":      {"2c", "4d", "7h", "9s", "Jc"},
+		"four of a kind": {"8c", "8d", "8h", "8s", "K clubs"},
+	}
+	for name, hand := range hands {
+		t.Run(name, func(t *testing.T) {
+			res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+				Name:      "evaluate_poker_hand",
+				Arguments: map[string]interface{}{"cards": hand},
+			})
+			require.NoError(t, err)
+			require.Len(t, res.Content, 2)
+
+			data, err := json.Marshal(res.StructuredContent)
+			require.NoError(t, err)
+			var instance map[string]any
+			require.NoError(t, json.Unmarshal(data, &instance))
+			assert.NoError(t, schema.Validate(instance))
+			assert.JSONEq(t, string(data), res.Content[1].(*mcp.TextContent).Text)
+		})
+	}
+}
+
+func TestEvaluateHandStructuredFields(t *testing.T) {
+	cs := connectTestClient(t)
+	res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "evaluate_poker_hand",
+		Arguments: map[string]interface{}{"cards": []string{"9 clubs", "9 diamonds", "A hearts", "5 spades", "2 clubs"}},
+	})
+	require.NoError(t, err)
+	got := decodeStructured[EvaluateHandResult](t, res)
+
+	assert.Equal(t, []string{"9♣", "9♦", "A♥", "5♠", "2♣"}, got.Cards)
+	assert.Equal(t, "One Pair", got.Category)
+	assert.Equal(t, 1, got.CategoryRank)
+	assert.Equal(t, []string{"9", "A", "5", "2"}, got.Ranks)
+	assert.Equal(t, "Pair of Nines, A-5-2 kickers", got.Description)
+	assert.Equal(t, []DiscardCard{{2, "A♥"}, {3, "5♠"}, {4, "2♣"}}, got.Discards)
+	assert.Equal(t, 4, got.MaxDiscards)
+	assert.Greater(t, got.Strength.Rank, 1)
+	assert.InDelta(t, 0.75, got.Strength.Beats, 0.01)
+}
+
+func TestEvaluateOutputSchemaRejectsMissingFields(t *testing.T) {
+	schema := outputSchema(t, connectTestClient(t), "evaluate_poker_hand")
+	assert.Error(t, schema.Validate(map[string]any{"category": "One Pair"}))
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// observer logs, measures and traces what the server does. Every MCP request passes
+// through its middleware, and every HTTP request to /mcp through its handler.
+type observer struct {
+	log        *slog.Logger
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	reg        *prometheus.Registry
+
+	toolCalls    *prometheus.CounterVec
+	toolDuration *prometheus.HistogramVec
+	errors       *prometheus.CounterVec
+	httpRequests *prometheus.CounterVec
+}
+
+func newObserver(log *slog.Logger, tracer trace.Tracer) *observer {
+	o := &observer{
+		log:        log,
+		tracer:     tracer,
+		propagator: propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+		reg:        prometheus.NewRegistry(),
+		toolCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gopoker_mcp_tool_calls_total",
+			Help: "Tool calls by tool and outcome (ok or error).",
+		}, []string{"tool", "outcome"}),
+		toolDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "gopoker_mcp_tool_call_duration_seconds",
+			Help: "How long tool calls take.",
+			// equity enumeration and sampling run to seconds; everything else is sub-millisecond
+			Buckets: []float64{.0005, .001, .005, .01, .05, .1, .5, 1, 2.5, 5, 10},
+		}, []string{"tool"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gopoker_mcp_errors_total",
+			Help: "Failed requests by type: tool, unknown_tool, canceled, timeout, method, unauthorized, forbidden, rate_limited.",
+		}, []string{"type"}),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gopoker_mcp_http_requests_total",
+			Help: "HTTP requests to the MCP endpoint by status code.",
+		}, []string{"code"}),
+	}
+	o.reg.MustRegister(o.toolCalls, o.toolDuration, o.errors, o.httpRequests,
+		collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	return o
+}
+
+// watch exports gauges for the server's sessions and games. Call it once per observer.
+func (o *observer) watch(s *mcp.Server, games *gameStore) {
+	o.reg.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "gopoker_mcp_active_sessions",
+			Help: "Open MCP sessions.",
+		}, func() float64 {
+			n := 0
+			for range s.Sessions() {
+				n++
+			}
+			return float64(n)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "gopoker_mcp_active_games",
+			Help: "Games started with start_game that have not ended or expired.",
+		}, func() float64 { return float64(games.len()) }),
+	)
+}
+
+func (o *observer) metricsHandler() http.Handler {
+	return promhttp.HandlerFor(o.reg, promhttp.HandlerOpts{})
+}
+
+// middleware traces, times and logs each MCP request. tools are the registered tool
+// names: metrics label any other name "unknown" so clients cannot grow the label set.
+func (o *observer) middleware(tools map[string]bool) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			var header http.Header
+			if extra := req.GetExtra(); extra != nil {
+				header = extra.Header
+			}
+			// continue a trace the client started, if it sent traceparent
+			ctx = o.propagator.Extract(ctx, propagation.HeaderCarrier(header))
+
+			log := o.log.With("method", method)
+			attrs := []attribute.KeyValue{attribute.String("mcp.method.name", method)}
+			if id := req.GetSession().ID(); id != "" {
+				log = log.With("session_id", id)
+				attrs = append(attrs, attribute.String("mcp.session.id", id))
+			}
+			if id := header.Get(requestIDHeader); id != "" {
+				log = log.With("request_id", id)
+			}
+			spanName, tool := method, ""
+			if call, ok := req.(*mcp.CallToolRequest); ok && call.Params != nil {
+				tool = call.Params.Name
+				spanName += " " + tool
+				log = log.With("tool", tool)
+				attrs = append(attrs, attribute.String("gen_ai.tool.name", tool))
+			}
+
+			ctx, span := o.tracer.Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+			defer span.End()
+			start := time.Now()
+			res, err := next(ctx, method, req)
+			elapsed := time.Since(start)
+
+			var errType string
+			if err != nil {
+				errType = errorType(ctx, err, method, tool == "" || tools[tool])
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				o.errors.WithLabelValues(errType).Inc()
+			} else if r, ok := res.(*mcp.CallToolResult); ok && r.IsError {
+				errType = "tool"
+				span.SetStatus(codes.Error, "tool returned an error result")
+				o.errors.WithLabelValues(errType).Inc()
+			}
+
+			if method != "tools/call" {
+				if err != nil {
+					log.Warn("request failed", "duration", elapsed, "error", err, "error_type", errType)
+				} else {
+					log.Debug("request", "duration", elapsed)
+				}
+				return res, err
+			}
+			label := tool
+			if !tools[tool] {
+				label = "unknown"
+			}
+			outcome := "ok"
+			if errType != "" {
+				outcome = "error"
+			}
+			o.toolCalls.WithLabelValues(label, outcome).Inc()
+			o.toolDuration.WithLabelValues(label).Observe(elapsed.Seconds())
+			if err != nil {
+				log.Warn("tool call failed", "duration", elapsed, "error", err, "error_type", errType)
+			} else {
+				log.Info("tool call", "duration", elapsed, "outcome", outcome)
+			}
+			return res, err
+		}
+	}
+}
+
+// errorType sorts failed requests for the errors metric.
+func errorType(ctx context.Context, err error, method string, knownTool bool) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled) || ctx.Err() != nil:
+		return "canceled"
+	case method != "tools/call":
+		return "method"
+	case !knownTool:
+		return "unknown_tool"
+	}
+	return "tool"
+}
+
+// statusErrors are the HTTP rejections counted as errors; the rest are MCP's business.
+var statusErrors = map[int]string{
+	http.StatusUnauthorized:    "unauthorized",
+	http.StatusForbidden:       "forbidden",
+	http.StatusTooManyRequests: "rate_limited",
+}
+
+// handler gives each HTTP request an ID, counts it and logs it when it completes.
+// The ID is put on the request too, so the MCP middleware logs it with the session.
+func (o *observer) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+			r.Header.Set(requestIDHeader, id)
+		}
+		w.Header().Set(requestIDHeader, id)
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		next.ServeHTTP(rec, r)
+
+		o.httpRequests.WithLabelValues(strconv.Itoa(rec.status)).Inc()
+		if t, ok := statusErrors[rec.status]; ok {
+			o.errors.WithLabelValues(t).Inc()
+		}
+		session := r.Header.Get("Mcp-Session-Id")
+		if session == "" {
+			session = w.Header().Get("Mcp-Session-Id")
+		}
+		level := slog.LevelInfo
+		if rec.status >= 400 {
+			level = slog.LevelWarn
+		}
+		o.log.Log(r.Context(), level, "http request",
+			"request_id", id, "session_id", session, "http_method", r.Method, "status", rec.status,
+			"duration", time.Since(start), "remote", clientAddr(r))
+	})
+}
+
+// validRequestID accepts a caller's ID only if it is safe to log and echo back.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	return strings.IndexFunc(id, func(r rune) bool { return r <= ' ' || r > '~' }) < 0
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// statusRecorder remembers the status written. It must still flush, or SSE streams
+// would stall behind it.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter { return r.ResponseWriter }
+
+// newLogger writes to stderr, which keeps stdout free for the stdio transport.
+func newLogger(level, format string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("log level: %w", err)
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch format {
+	case "text":
+		return slog.New(slog.NewTextHandler(os.Stderr, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(os.Stderr, opts)), nil
+	}
+	return nil, fmt.Errorf("log format %q: want text or json", format)
+}
+
+// setupTracing exports spans over OTLP/HTTP when the standard OTEL_EXPORTER_OTLP_ENDPOINT
+// or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT variable is set; the exporter reads the rest of
+// its configuration from the environment too. Otherwise spans cost nothing and go
+// nowhere. The returned function flushes pending spans.
+func setupTracing(ctx context.Context) (trace.Tracer, func(context.Context) error, error) {
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return otel.Tracer(serverName), func(context.Context) error { return nil }, nil
+	}
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tracing: %w", err)
+	}
+	// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the defaults
+	res, err := sdkresource.New(ctx,
+		sdkresource.WithAttributes(attribute.String("service.name", serverName)),
+		sdkresource.WithTelemetrySDK(), sdkresource.WithFromEnv())
+	if err != nil {
+		return nil, nil, fmt.Errorf("tracing: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(tp)
+	return tp.Tracer(serverName), tp.Shutdown, nil
+}
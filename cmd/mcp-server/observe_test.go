@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// syncBuffer collects log output written from the server's goroutines.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// records decodes the JSON log lines written so far.
+func (b *syncBuffer) records(t *testing.T) []map[string]any {
+	t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var out []map[string]any
+	sc := bufio.NewScanner(bytes.NewReader(b.buf.Bytes()))
+	for sc.Scan() {
+		var rec map[string]any
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &rec))
+		out = append(out, rec)
+	}
+	return out
+}
+
+// find returns the first log record with the message and attributes.
+func find(records []map[string]any, msg string, attrs map[string]any) map[string]any {
+next:
+	for _, r := range records {
+		if r["msg"] != msg {
+			continue
+		}
+		for k, v := range attrs {
+			if r[k] != v {
+				continue next
+			}
+		}
+		return r
+	}
+	return nil
+}
+
+type testObserver struct {
+	*observer
+	logs  *syncBuffer
+	spans *tracetest.SpanRecorder
+}
+
+func newTestObserver(t *testing.T) testObserver {
+	t.Helper()
+	logs := &syncBuffer{}
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	t.Cleanup(func() { tp.Shutdown(context.Background()) })
+	log := slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return testObserver{newObserver(log, tp.Tracer("test")), logs, spans}
+}
+
+// gauge reads a gauge from the observer's registry.
+func (o testObserver) gauge(t *testing.T, name string) float64 {
+	t.Helper()
+	families, err := o.reg.Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() == name {
+			return f.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	t.Fatalf("no metric %s", name)
+	return 0
+}
+
+func TestObserverToolCalls(t *testing.T) {
+	o := newTestObserver(t)
+	cs := connectServer(t, newServer(serverOptions{obs: o.observer}))
+	ctx := context.Background()
+
+	_, err := cs.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "evaluate_poker_hand",
+		Arguments: map[string]interface{}{"cards": []string{"As", "Ad", "Kc", "Kd", "2h"}},
+	})
+	require.NoError(t, err)
+	_, err = cs.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "evaluate_poker_hand",
+		Arguments: map[string]interface{}{"cards": []string{"As", "As"}},
+	})
+	require.Error(t, err)
+	_, err = cs.CallTool(ctx, &mcp.CallToolParams{Name: "no_such_tool", Arguments: map[string]interface{}{}})
+	require.Error(t, err)
+	var st GameState
+	require.Empty(t, callGame(t, cs, "start_game", map[string]interface{}{"opponents": 1, "seed": 1}, &st))
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(o.toolCalls.WithLabelValues("evaluate_poker_hand", "ok")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(o.toolCalls.WithLabelValues("evaluate_poker_hand", "error")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(o.toolCalls.WithLabelValues("unknown", "error")), "client-chosen names are not labels")
+	assert.Equal(t, 1.0, testutil.ToFloat64(o.toolCalls.WithLabelValues("start_game", "ok")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(o.errors.WithLabelValues("tool")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(o.errors.WithLabelValues("unknown_tool")))
+	assert.Equal(t, 3, testutil.CollectAndCount(o.toolDuration), "one histogram per tool")
+	assert.Equal(t, 1.0, o.gauge(t, "gopoker_mcp_active_games"))
+	assert.Equal(t, 1.0, o.gauge(t, "gopoker_mcp_active_sessions"))
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, s := range o.spans.Ended() {
+		spans[s.Name()] = s
+	}
+	require.Contains(t, spans, "initialize")
+	require.Contains(t, spans, "tools/call evaluate_poker_hand")
+	require.Contains(t, spans, "tools/call no_such_tool")
+	assert.Equal(t, codes.Error, spans["tools/call no_such_tool"].Status().Code)
+	start := spans["tools/call start_game"]
+	require.NotNil(t, start)
+	assert.Equal(t, codes.Unset, start.Status().Code)
+	assert.Contains(t, start.Attributes(), attribute.String("gen_ai.tool.name", "start_game"))
+
+	logs := o.logs.records(t)
+	assert.NotNil(t, find(logs, "tool call", map[string]any{"tool": "evaluate_poker_hand", "outcome": "ok"}))
+	failed := find(logs, "tool call failed", map[string]any{"tool": "evaluate_poker_hand", "error_type": "tool"})
+	require.NotNil(t, failed)
+	assert.Equal(t, "WARN", failed["level"])
+	assert.Contains(t, failed["error"], "exactly 5 cards")
+	assert.NotNil(t, find(logs, "request", map[string]any{"method": "initialize"}))
+}
+
+func TestObserverHTTP(t *testing.T) {
+	o := newTestObserver(t)
+	srv := httptest.NewServer(newHTTPHandler(newServer(serverOptions{obs: o.observer}), httpOptions{
+		sessionTimeout: time.Minute,
+		security:       security{keys: testKeys(t)},
+		obs:            o.observer,
+	}))
+	defer srv.Close()
+
+	resp := send(t, srv.URL, http.MethodPost, initializeRequest, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Len(t, resp.Header.Get(requestIDHeader), 16, "requests get an ID")
+
+	resp = send(t, srv.URL, http.MethodPost, initializeRequest, map[string]string{
+		"Authorization": "Bearer " + aliceKey,
+		requestIDHeader: "req-42",
+		"traceparent":   "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "req-42", resp.Header.Get(requestIDHeader), "a caller's ID is kept")
+	session := resp.Header.Get("Mcp-Session-Id")
+	require.NotEmpty(t, session)
+	io.Copy(io.Discard, resp.Body)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(o.errors.WithLabelValues("unauthorized")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(o.httpRequests.WithLabelValues("401")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(o.httpRequests.WithLabelValues("200")))
+
+	logs := o.logs.records(t)
+	assert.NotNil(t, find(logs, "request", map[string]any{"method": "initialize", "request_id": "req-42", "session_id": session}),
+		"MCP requests are logged with the HTTP request's ID and the session")
+	assert.NotNil(t, find(logs, "http request", map[string]any{"request_id": "req-42", "session_id": session, "status": 200.0}))
+	assert.NotNil(t, find(logs, "http request", map[string]any{"status": 401.0, "level": "WARN"}))
+
+	var init sdktrace.ReadOnlySpan
+	for _, s := range o.spans.Ended() {
+		if s.Name() == "initialize" {
+			init = s
+		}
+	}
+	require.NotNil(t, init)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", init.SpanContext().TraceID().String(), "the client's trace is continued")
+
+	metrics, err := http.Get(srv.URL + "/metrics")
+	require.NoError(t, err)
+	defer metrics.Body.Close()
+	assert.Equal(t, http.StatusOK, metrics.StatusCode, "metrics need no key")
+	body, err := io.ReadAll(metrics.Body)
+	require.NoError(t, err)
+	for _, want := range []string{
+		"gopoker_mcp_active_sessions 1",
+		`gopoker_mcp_errors_total{type="unauthorized"} 1`,
+		`gopoker_mcp_http_requests_total{code="401"} 1`,
+		"go_goroutines",
+	} {
+		assert.Contains(t, string(body), want)
+	}
+}
+
+func TestReadiness(t *testing.T) {
+	var ready atomic.Bool
+	srv := httptest.NewServer(newHTTPHandler(newServer(serverOptions{}), httpOptions{ready: ready.Load}))
+	defer srv.Close()
+
+	status := func(path string) int {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	assert.Equal(t, http.StatusServiceUnavailable, status("/readyz"))
+	assert.Equal(t, http.StatusOK, status("/livez"), "alive while not ready")
+	assert.Equal(t, http.StatusOK, status("/health"))
+	ready.Store(true)
+	assert.Equal(t, http.StatusOK, status("/readyz"))
+	assert.Equal(t, http.StatusNotFound, status("/metrics"), "no observer, no metrics")
+}
+
+func TestValidRequestID(t *testing.T) {
+	for id, want := range map[string]bool{
+		"req-42":                    true,
+		"4bf92f35-77b3-4da6":        true,
+		"":                          false,
+		"two words":                 false,
+		"line\nbreak":               false,
+		"ünicode":                   false,
+		strings.Repeat("x", 129):    false,
+		strings.Repeat("x", 128):    true,
+		"quotes\"and=equals;are:ok": true,
+	} {
+		assert.Equal(t, want, validRequestID(id), id)
+	}
+}
+
+func TestNewLogger(t *testing.T) {
+	for _, tc := range []struct {
+		level, format, want string
+	}{
+		{"debug", "json", ""},
+		{"WARN", "text", ""},
+		{"loud", "text", "log level"},
+		{"info", "xml", `log format "xml"`},
+	} {
+		_, err := newLogger(tc.level, tc.format)
+		if tc.want == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.ErrorContains(t, err, tc.want)
+		}
+	}
+}
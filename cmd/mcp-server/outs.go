@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/hand"
+	"github.com/dangogh/GoPoker/outs"
+)
+
+// AnalyzeOutsParams are the arguments of the analyze_outs tool. A nil DiscardIndices
+// means "use the recommended discards"; an explicit empty list means standing pat.
+type AnalyzeOutsParams struct {
+	Cards          []string `json:"cards"`
+	DiscardIndices []int    `json:"discard_indices"`
+	Board          []string `json:"board"`
+	Dead           []string `json:"dead"`
+	Draw           *int     `json:"draw"`
+}
+
+var cardListSchema = map[string]interface{}{
+	"type": "array",
+	"items": map[string]interface{}{
+		"type":        "string",
+		"description": "Card as 'rank suit' (e.g., 'A spades') or compact notation (e.g., 'As', '10h')",
+	},
+}
+
+var outsTool = &mcp.Tool{
+	Name:        "analyze_outs",
+	Description: "List the outs for each stronger hand category and the exact probability of reaching it after drawing. For five-card draw pass 5 cards and optionally the indices to discard (defaults to the recommended discards); for board games pass hole cards, the board and the number of cards to come.",
+	InputSchema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"cards": cardListSchema,
+			"discard_indices": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "integer", "minimum": 0},
+				"description": "0-based indices of cards to discard; omit to use the recommended discards",
+			},
+			"board": cardListSchema,
+			"dead":  cardListSchema,
+			"draw": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     0,
+				"maximum":     outs.MaxDraw,
+				"description": "Cards still to come; defaults to the number of discards",
+			},
+		},
+		"required": []string{"cards"},
+	},
+}
+
+func parseCards(field string, strs []string) ([]cards.Card, error) {
+	out := make([]cards.Card, len(strs))
+	for i, s := range strs {
+		c, err := parseCard(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s %d: %w", field, i, err)
+		}
+		out[i] = c
+	}
+	return out, nil
+}
+
+func handleAnalyzeOuts(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params AnalyzeOutsParams
+	if err := json.Unmarshal(req.Params.Arguments, &params); err != nil {
+		return nil, err
+	}
+	cs, err := parseCards("card", params.Cards)
+	if err != nil {
+		return nil, err
+	}
+	board, err := parseCards("board card", params.Board)
+	if err != nil {
+		return nil, err
+	}
+	dead, err := parseCards("dead card", params.Dead)
+	if err != nil {
+		return nil, err
+	}
+
+	h := hand.Hand{Cards: cs}
+	discards := params.DiscardIndices
+	if discards == nil && len(board) == 0 {
+		discards = hand.RecommendDiscards(h, hand.ComputeMaxDiscard(h))
+	}
+	drop := make(map[int]bool, len(discards))
+	for _, idx := range discards {
+		if idx < 0 || idx >= len(cs) {
+			return nil, fmt.Errorf("discard index %d out of range", idx)
+		}
+		drop[idx] = true
+	}
+	var held []cards.Card
+	for i, c := range cs {
+		if drop[i] {
+			dead = append(dead, c)
+		} else {
+			held = append(held, c)
+		}
+	}
+
+	opts := outs.Options{Board: board, Dead: dead, Draw: len(drop)}
+	if params.Draw != nil {
+		opts.Draw = *params.Draw
+	}
+	a, err := outs.Analyze(held, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return structuredResult(formatAnalysis(held, a), a)
+}
+
+func formatAnalysis(held []cards.Card, a outs.Analysis) string {
+	var b strings.Builder
+	names := make([]string, len(held))
+	for i, c := range held {
+		names[i] = c.String()
+	}
+	fmt.Fprintf(&b, "Keeping: %s (%s)\n", strings.Join(names, " "), a.CurrentName)
+	fmt.Fprintf(&b, "Drawing %d of %d unseen cards (%d combinations)", a.Draw, a.Unseen, a.Combinations)
+	for _, t := range a.Targets {
+		if t.Probability == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s: %.2f%% (%d outs: %s)", t.Name, 100*t.Probability, len(t.Outs), strings.Join(t.Outs, " "))
+	}
+	return b.String()
+}
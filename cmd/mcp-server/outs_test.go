@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/outs"
+)
+
+func callAnalyzeOuts(t *testing.T, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	t.Helper()
+	cs := connectTestClient(t)
+	return cs.CallTool(context.Background(), &mcp.CallToolParams{Name: "analyze_outs", Arguments: args})
+}
+
+func TestAnalyzeOutsRecommendedDiscards(t *testing.T) {
+	res, err := callAnalyzeOuts(t, map[string]interface{}{
+		"cards": []string{"A spades", "A diamonds", "K clubs", "7 hearts", "2 diamonds"},
+	})
+	require.NoError(t, err)
+	require.False(t, res.IsError)
+
+	a := decodeStructured[outs.Analysis](t, res)
+	assert.Equal(t, "One Pair", a.CurrentName)
+	assert.Equal(t, 3, a.Draw)
+	assert.Equal(t, 16215, a.Combinations)
+	assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, "Keeping: A♠ A♦")
+}
+
+func TestAnalyzeOutsExplicitDiscards(t *testing.T) {
+	res, err := callAnalyzeOuts(t, map[string]interface{}{
+		"cards":           []string{"Ah", "Kh", "9h", "4h", "2c"},
+		"discard_indices": []int{4},
+	})
+	require.NoError(t, err)
+	a := decodeStructured[outs.Analysis](t, res)
+	require.Equal(t, 1, a.Draw)
+	for _, tg := range a.Targets {
+		if tg.Name == "Flush" {
+			assert.Len(t, tg.Outs, 9)
+			assert.InDelta(t, 9.0/47, tg.Probability, 1e-9)
+		}
+	}
+}
+
+func TestAnalyzeOutsBoard(t *testing.T) {
+	res, err := callAnalyzeOuts(t, map[string]interface{}{
+		"cards": []string{"Ah", "Kh"},
+		"board": []string{"2h", "7h", "9c"},
+		"draw":  2,
+	})
+	require.NoError(t, err)
+	a := decodeStructured[outs.Analysis](t, res)
+	assert.Equal(t, 2, a.Draw)
+	assert.Equal(t, 47, a.Unseen)
+}
+
+func TestAnalyzeOutsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]interface{}
+	}{
+		{"bad card", map[string]interface{}{"cards": []string{"Zz"}}},
+		{"index out of range", map[string]interface{}{"cards": []string{"As", "Kd"}, "discard_indices": []int{3}}},
+		{"draw too large", map[string]interface{}{"cards": []string{"As", "Kd"}, "draw": 9}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := callAnalyzeOuts(t, tc.args)
+			if err == nil {
+				assert.True(t, res.IsError)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/hand"
+	"github.com/dangogh/GoPoker/outs"
+)
+
+// Prompts start coaching conversations. Prompt arguments are plain strings, so cards
+// come as one list; the evaluator's view of the hand is filled in up front so the model
+// reasons from exact numbers rather than estimating them.
+
+var reviewDrawPrompt = &mcp.Prompt{
+	Name:        "review_draw_decision",
+	Title:       "Review my draw decision",
+	Description: "Coach a five-card draw decision: which cards to throw, with the evaluator's category, recommended discards and exact odds of improving filled in.",
+	Arguments: []*mcp.PromptArgument{
+		{Name: "cards", Description: "Your five cards, e.g. 'As Kd 9c 9d 2h' or 'A spades, K diamonds, ...'", Required: true},
+		{Name: "discards", Description: "The cards you threw or plan to throw, e.g. 'As Kd 2h', or 'none' to stand pat; omit to just ask what to do"},
+		{Name: "situation", Description: "Anything else that matters: position, pot and stack sizes, how many cards opponents drew"},
+	},
+}
+
+var explainShowdownPrompt = &mcp.Prompt{
+	Name:        "explain_showdown",
+	Title:       "Explain this showdown",
+	Description: "Explain who won a showdown and why, with every hand ranked by the evaluator.",
+	Arguments: []*mcp.PromptArgument{
+		{Name: "hands", Description: "Two or more five-card hands separated by ';' or new lines, each optionally named: 'alice: As Ad Kc Kd 2h; bob: Qs Qh Qd 7c 7d'", Required: true},
+	},
+}
+
+func addPrompts(s *mcp.Server) {
+	s.AddPrompt(reviewDrawPrompt, handleReviewDraw)
+	s.AddPrompt(explainShowdownPrompt, handleExplainShowdown)
+}
+
+// splitCards parses a card list written in compact ("As Kd") or spelled-out ("A spades,
+// K diamonds") notation, with commas or spaces between cards.
+func splitCards(s string) ([]cards.Card, error) {
+	words := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	var out []cards.Card
+	for i := 0; i < len(words); i++ {
+		c, err := parseCard(words[i])
+		if err != nil && i+1 < len(words) {
+			if c, err = parseCard(words[i] + " " + words[i+1]); err == nil {
+				i++
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid card %q", words[i])
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// discardIndexes finds the thrown cards in the hand. "none" and "pat" stand pat.
+func discardIndexes(h []cards.Card, thrown string) ([]int, error) {
+	switch strings.ToLower(strings.TrimSpace(thrown)) {
+	case "none", "pat", "stand pat":
+		return []int{}, nil
+	}
+	cs, err := splitCards(thrown)
+	if err != nil {
+		return nil, fmt.Errorf("discards: %w", err)
+	}
+	var idxs []int
+	for _, c := range cs {
+		idx := -1
+		for i, hc := range h {
+			if hc == c {
+				idx = i
+			}
+		}
+		if idx < 0 {
+			return nil, fmt.Errorf("discards: %s is not in your hand", c)
+		}
+		idxs = append(idxs, idx)
+	}
+	return idxs, nil
+}
+
+func cardNames(cs []cards.Card) string {
+	return strings.Join(cardStrings(cs), " ")
+}
+
+// drawOdds describes keeping everything but the discards, using the outs table.
+func drawOdds(h hand.Hand, discards []int) (string, error) {
+	if len(discards) == 0 {
+		return "Standing pat keeps the hand as it is.", nil
+	}
+	a, err := outs.AnalyzeDraw(h, discards)
+	if err != nil {
+		return "", err
+	}
+	drop := map[int]bool{}
+	for _, i := range discards {
+		drop[i] = true
+	}
+	var held []cards.Card
+	for i, c := range h.Cards {
+		if !drop[i] {
+			held = append(held, c)
+		}
+	}
+	return formatAnalysis(held, a), nil
+}
+
+func handleReviewDraw(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	args := req.Params.Arguments
+	cs, err := splitCards(args["cards"])
+	if err != nil {
+		return nil, fmt.Errorf("cards: %w", err)
+	}
+	if len(cs) != 5 {
+		return nil, fmt.Errorf("cards: must provide exactly 5 cards, got %d", len(cs))
+	}
+	if err := checkDistinct(cs); err != nil {
+		return nil, fmt.Errorf("cards: %w", err)
+	}
+	h := hand.Hand{Cards: cs}
+	eval := hand.Evaluate(h)
+	strength, err := hand.StrengthOf(eval)
+	if err != nil {
+		return nil, err
+	}
+	maxDiscard := hand.ComputeMaxDiscard(h)
+	recommended := hand.RecommendDiscards(h, maxDiscard)
+
+	var b strings.Builder
+	b.WriteString("I'm playing no-limit five-card draw and want a review of my draw.\n\n")
+	fmt.Fprintf(&b, "My hand: %s\n", cardNames(cs))
+	fmt.Fprintf(&b, "Evaluator: %s, %s (rank %d of %d, beats %.1f%% of hands)\n", eval.Category, strength.Description, strength.Rank, hand.DistinctHands, 100*strength.Beats)
+	fmt.Fprintf(&b, "I may discard up to %d cards.\n\n", maxDiscard)
+
+	odds, err := drawOdds(h, recommended)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(&b, "Recommended discards: %s\n%s\n", describeDiscards(cs, recommended), odds)
+
+	if thrown, ok := args["discards"]; ok && strings.TrimSpace(thrown) != "" {
+		mine, err := discardIndexes(cs, thrown)
+		if err != nil {
+			return nil, err
+		}
+		if len(mine) > maxDiscard {
+			return nil, fmt.Errorf("discards: at most %d cards may be discarded, got %d", maxDiscard, len(mine))
+		}
+		odds, err := drawOdds(h, mine)
+		if err != nil {
+			return nil, fmt.Errorf("discards: %w", err)
+		}
+		fmt.Fprintf(&b, "\nMy discards: %s\n%s\n", describeDiscards(cs, mine), odds)
+	}
+	if s := strings.TrimSpace(args["situation"]); s != "" {
+		fmt.Fprintf(&b, "\nSituation: %s\n", s)
+	}
+	b.WriteString("\nUsing these numbers, explain the best draw and why. If I chose different discards, say what my choice gains or gives up against the recommendation, and how the situation changes the answer.")
+
+	return &mcp.GetPromptResult{
+		Description: "Draw review for " + cardNames(cs),
+		Messages:    []*mcp.PromptMessage{{Role: "user", Content: &mcp.TextContent{Text: b.String()}}},
+	}, nil
+}
+
+func describeDiscards(cs []cards.Card, idxs []int) string {
+	if len(idxs) == 0 {
+		return "none (stand pat)"
+	}
+	names := make([]string, len(idxs))
+	for i, idx := range idxs {
+		names[i] = cs[idx].String()
+	}
+	return strings.Join(names, " ")
+}
+
+func handleExplainShowdown(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	entries := strings.FieldsFunc(req.Params.Arguments["hands"], func(r rune) bool { return r == ';' || r == '\n' })
+	var in []NamedHandParam
+	for _, e := range entries {
+		if strings.TrimSpace(e) == "" {
+			continue
+		}
+		var p NamedHandParam
+		if name, list, ok := strings.Cut(e, ":"); ok {
+			p.Name, e = strings.TrimSpace(name), list
+		}
+		cs, err := splitCards(e)
+		if err != nil {
+			return nil, fmt.Errorf("hands: %w", err)
+		}
+		p.Cards = cardStrings(cs)
+		in = append(in, p)
+	}
+	c, err := compareHands(in)
+	if err != nil {
+		return nil, fmt.Errorf("hands: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteString("Explain this five-card showdown to me.\n\nThe evaluator ranks the hands:\n")
+	b.WriteString(formatComparison(c))
+	b.WriteString("\n\nHow strong each hand is among all five-card hands:\n")
+	for _, r := range c.Results {
+		cs, err := parseCards(r.Name+" card", r.Cards)
+		if err != nil {
+			return nil, err
+		}
+		strength, err := hand.StrengthOf(hand.Evaluate(hand.Hand{Cards: cs}))
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&b, "- %s: rank %d of %d, beats %.1f%% of hands\n", r.Name, strength.Rank, hand.DistinctHands, 100*strength.Beats)
+	}
+	b.WriteString("\nWalk through why each hand beats the next: the category first, then the ranks that make the hand and the kickers that break ties. Point out any close calls and how rare the winning hand is.")
+
+	return &mcp.GetPromptResult{
+		Description: "Showdown between " + strings.Join(namesOf(c), ", "),
+		Messages:    []*mcp.PromptMessage{{Role: "user", Content: &mcp.TextContent{Text: b.String()}}},
+	}, nil
+}
+
+func namesOf(c Comparison) []string {
+	var names []string
+	for _, r := range c.Results {
+		names = append(names, r.Name)
+	}
+	return names
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/cards"
+)
+
+func getPrompt(t *testing.T, name string, args map[string]string) (string, error) {
+	t.Helper()
+	res, err := connectTestClient(t).GetPrompt(context.Background(), &mcp.GetPromptParams{Name: name, Arguments: args})
+	if err != nil {
+		return "", err
+	}
+	require.Len(t, res.Messages, 1)
+	assert.Equal(t, mcp.Role("user"), res.Messages[0].Role)
+	return res.Messages[0].Content.(*mcp.TextContent).Text, nil
+}
+
+func TestListPrompts(t *testing.T) {
+	res, err := connectTestClient(t).ListPrompts(context.Background(), nil)
+	require.NoError(t, err)
+	var names []string
+	for _, p := range res.Prompts {
+		names = append(names, p.Name)
+		require.NotEmpty(t, p.Arguments)
+		assert.True(t, p.Arguments[0].Required, p.Name)
+	}
+	assert.ElementsMatch(t, []string{"review_draw_decision", "explain_showdown"}, names)
+}
+
+func TestReviewDrawPrompt(t *testing.T) {
+	text, err := getPrompt(t, "review_draw_decision", map[string]string{
+		"cards":     "9c 9d A hearts, 5s 2c",
+		"discards":  "5s 2c",
+		"situation": "button, one caller who drew three",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, text, "My hand: 9♣ 9♦ A♥ 5♠ 2♣")
+	assert.Contains(t, text, "Evaluator: One Pair, Pair of Nines, A-5-2 kickers")
+	assert.Contains(t, text, "I may discard up to 4 cards.")
+	assert.Contains(t, text, "Recommended discards: A♥ 5♠ 2♣\nKeeping: 9♣ 9♦ (One Pair)\nDrawing 3 of 47 unseen cards")
+	assert.Contains(t, text, "My discards: 5♠ 2♣\nKeeping: 9♣ 9♦ A♥ (One Pair)\nDrawing 2 of 47")
+	assert.Contains(t, text, "Situation: button, one caller who drew three")
+
+	pat, err := getPrompt(t, "review_draw_decision", map[string]string{"cards": "As Ks Qs Js 9s", "discards": "none"})
+	require.NoError(t, err)
+	assert.Contains(t, pat, "Recommended discards: none (stand pat)")
+	assert.Contains(t, pat, "My discards: none (stand pat)")
+}
+
+func TestExplainShowdownPrompt(t *testing.T) {
+	text, err := getPrompt(t, "explain_showdown", map[string]string{
+		"hands": "alice: As Ad Kc Kd 2h; bob: Qs Qh Qd 7c 7d\nJ clubs, 10 clubs, 9c 8c 7h",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, text, "1. bob: Queens full of Sevens (Q♠ Q♥ Q♦ 7♣ 7♦)\n2. Player 3: Jack-high straight")
+	assert.Contains(t, text, "Winner: bob")
+	assert.Contains(t, text, "- alice: rank 2478 of 7462")
+}
+
+func TestPromptErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]string
+		want string
+	}{
+		{"review_draw_decision", map[string]string{"cards": "As Kd"}, "exactly 5 cards"},
+		{"review_draw_decision", map[string]string{"cards": "As Kd Zz 2c 3c"}, `invalid card "Zz"`},
+		{"review_draw_decision", map[string]string{"cards": "As Kd As 2c 3c"}, "more than once"},
+		{"review_draw_decision", map[string]string{"cards": "As Kd Qc 2c 3c", "discards": "4h"}, "not in your hand"},
+		{"review_draw_decision", map[string]string{"cards": "Ks Kd Qc 2c 3c", "discards": "Ks Kd Qc 2c"}, "at most 3"},
+		{"explain_showdown", map[string]string{"hands": "As Kd Qc 2c 3c"}, "2 to 10 hands"},
+		{"explain_showdown", map[string]string{"hands": "a: As Kd Qc 2c 3c; b: As Kh Qh 2h 3h"}, "appears in both"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			_, err := getPrompt(t, tc.name, tc.args)
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+}
+
+func TestSplitCards(t *testing.T) {
+	cs, err := splitCards("A spades, 10h K ♥ 2c")
+	require.NoError(t, err)
+	assert.Equal(t, []cards.Card{
+		cards.NewCard(cards.Spades, cards.Ace), cards.NewCard(cards.Hearts, cards.Ten),
+		cards.NewCard(cards.Hearts, cards.King), cards.NewCard(cards.Clubs, cards.Two),
+	}, cs)
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/game"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// Reference data for agents. Everything is generated from the hand and game packages
+// rather than written out, so it cannot drift from what the evaluator and engine do.
+
+const (
+	rulesURI         = "poker://rules/five-card-draw"
+	rankingsURI      = "poker://rankings"
+	probabilitiesURI = "poker://probabilities/5card"
+)
+
+// pokerResource is a static resource whose text is produced on every read.
+type pokerResource struct {
+	resource *mcp.Resource
+	text     func() (string, error)
+}
+
+var pokerResources = []pokerResource{
+	{
+		resource: &mcp.Resource{
+			URI:         rulesURI,
+			Name:        "five-card-draw-rules",
+			Title:       "Five-card draw rules",
+			Description: "How the hands played by start_game work: forced bets, betting rounds, the draw and the showdown.",
+			MIMEType:    "text/markdown",
+		},
+		text: rulesText,
+	},
+	{
+		resource: &mcp.Resource{
+			URI:         rankingsURI,
+			Name:        "hand-rankings",
+			Title:       "Poker hand rankings",
+			Description: "The hand categories from strongest to weakest, with the best and worst hand of each and their absolute ranks among the 7,462 distinct five-card hands.",
+			MIMEType:    "text/markdown",
+		},
+		text: rankingsText,
+	},
+	{
+		resource: &mcp.Resource{
+			URI:         probabilitiesURI,
+			Name:        "five-card-probabilities",
+			Title:       "Five-card hand probabilities",
+			Description: "Exact number of five-card hands in each category, counted by enumeration, with the probability and odds of being dealt one.",
+			MIMEType:    "application/json",
+		},
+		text: probabilitiesText,
+	},
+}
+
+func addResources(s *mcp.Server) {
+	for _, r := range pokerResources {
+		s.AddResource(r.resource, resourceHandler(r))
+	}
+}
+
+func resourceHandler(r pokerResource) mcp.ResourceHandler {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		text, err := r.text()
+		if err != nil {
+			return nil, err
+		}
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{URI: r.resource.URI, MIMEType: r.resource.MIMEType, Text: text}},
+		}, nil
+	}
+}
+
+// sample parses a hand written in compact notation.
+func sample(notation string) (hand.Hand, error) {
+	cs, err := cards.ParseList(notation)
+	return hand.Hand{Cards: cs}, err
+}
+
+func rulesText() (string, error) {
+	noAce, err := sample("Kc Jd 8h 5s 2c")
+	if err != nil {
+		return "", err
+	}
+	withAce, err := sample("Ac Jd 8h 5s 2c")
+	if err != nil {
+		return "", err
+	}
+	var ranking []string
+	for _, c := range hand.Categories() {
+		ranking = append(ranking, c.Category.String())
+	}
+
+	var b strings.Builder
+	b.WriteString("# No-limit five-card draw\n\n")
+	fmt.Fprintf(&b, "Two to %d players each get five private cards from a standard 52-card deck.\n\n", game.MaxSeats)
+	b.WriteString("## A hand\n\n")
+	b.WriteString("1. Forced bets: every player may post an ante; the two players after the button post the small and big blind. Heads-up the button posts the small blind.\n")
+	b.WriteString("2. Five cards are dealt to each player, one at a time.\n")
+	b.WriteString("3. First betting round, starting after the big blind.\n")
+	fmt.Fprintf(&b, "4. The draw: each player still in discards up to %d cards, or up to %d when holding an ace, and is dealt replacements. Keeping all five is standing pat.\n",
+		hand.ComputeMaxDiscard(noAce), hand.ComputeMaxDiscard(withAce))
+	b.WriteString("5. Second betting round, starting after the button.\n")
+	b.WriteString("6. Showdown: the best five-card hand wins. Players who are all-in can only win the side pots they contributed to.\n\n")
+	b.WriteString("## Betting\n\n")
+	b.WriteString("No limit: a bet or raise can be any amount up to the player's whole stack. A raise must be at least the size of the previous bet or raise in the round, and the first bet at least the big blind. Players fold, check (when nothing is owed), call, bet or raise.\n\n")
+	b.WriteString("## Ranking\n\n")
+	fmt.Fprintf(&b, "From strongest to weakest: %s. Within a category the ranks that make the hand decide, then the kickers; suits never break ties, and equal hands split the pot. An ace can play low only in the five-high straight (the wheel). See %s for details.\n",
+		strings.Join(ranking, ", "), rankingsURI)
+	return b.String(), nil
+}
+
+func rankingsText() (string, error) {
+	var b strings.Builder
+	b.WriteString("# Hand rankings\n\n")
+	fmt.Fprintf(&b, "%d distinct five-card hands, from rank 1 (strongest) to %d.\n\n", hand.DistinctHands, hand.DistinctHands)
+	b.WriteString("| Category | Distinct hands | Absolute ranks | Best | Worst |\n")
+	b.WriteString("|---|---:|---|---|---|\n")
+	first := 1
+	for _, c := range hand.Categories() {
+		fmt.Fprintf(&b, "| %s | %d | %d-%d | %s | %s |\n",
+			c.Category, c.Distinct, first, first+c.Distinct-1, hand.Describe(c.Best), hand.Describe(c.Worst))
+		first += c.Distinct
+	}
+	return b.String(), nil
+}
+
+// CategoryProbability is one row of the probabilities resource.
+type CategoryProbability struct {
+	Category    string  `json:"category"`
+	Hands       int     `json:"hands"`
+	Distinct    int     `json:"distinct"`
+	Probability float64 `json:"probability"`
+	// OddsAgainst is how many hands are not this category for each one that is.
+	OddsAgainst float64 `json:"odds_against"`
+	// Cumulative is the probability of this category or better.
+	Cumulative float64 `json:"cumulative"`
+}
+
+// ProbabilityTable is the probabilities resource.
+type ProbabilityTable struct {
+	TotalHands int                   `json:"total_hands"`
+	Categories []CategoryProbability `json:"categories"` // strongest first
+}
+
+func probabilitiesText() (string, error) {
+	stats := hand.Categories()
+	var table ProbabilityTable
+	for _, c := range stats {
+		table.TotalHands += c.Hands
+	}
+	cumulative := 0
+	for _, c := range stats {
+		cumulative += c.Hands
+		table.Categories = append(table.Categories, CategoryProbability{
+			Category:    c.Category.String(),
+			Hands:       c.Hands,
+			Distinct:    c.Distinct,
+			Probability: c.Probability,
+			OddsAgainst: float64(table.TotalHands-c.Hands) / float64(c.Hands),
+			Cumulative:  float64(cumulative) / float64(table.TotalHands),
+		})
+	}
+	data, err := json.MarshalIndent(table, "", "  ")
+	return string(data), err
+}
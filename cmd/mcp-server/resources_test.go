@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readResource(t *testing.T, cs *mcp.ClientSession, uri string) *mcp.ResourceContents {
+	t.Helper()
+	res, err := cs.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: uri})
+	require.NoError(t, err)
+	require.Len(t, res.Contents, 1)
+	assert.Equal(t, uri, res.Contents[0].URI)
+	return res.Contents[0]
+}
+
+func TestListResources(t *testing.T) {
+	cs := connectTestClient(t)
+	res, err := cs.ListResources(context.Background(), nil)
+	require.NoError(t, err)
+	var uris []string
+	for _, r := range res.Resources {
+		uris = append(uris, r.URI)
+		assert.NotEmpty(t, r.Description, r.URI)
+	}
+	assert.ElementsMatch(t, []string{rulesURI, rankingsURI, probabilitiesURI}, uris)
+}
+
+func TestRulesResource(t *testing.T) {
+	rules := readResource(t, connectTestClient(t), rulesURI)
+	assert.Equal(t, "text/markdown", rules.MIMEType)
+	assert.Contains(t, rules.Text, "Two to 8 players")
+	assert.Contains(t, rules.Text, "discards up to 3 cards, or up to 4 when holding an ace")
+	assert.Contains(t, rules.Text, "From strongest to weakest: Straight Flush, Four of a Kind, Full House,")
+}
+
+func TestRankingsResource(t *testing.T) {
+	rankings := readResource(t, connectTestClient(t), rankingsURI)
+	assert.Contains(t, rankings.Text, "| Straight Flush | 10 | 1-10 | Royal flush | Wheel straight flush |")
+	assert.Contains(t, rankings.Text, "| Four of a Kind | 156 | 11-166 | Four Aces, K kicker |")
+	assert.Contains(t, rankings.Text, "| High Card | 1277 | 6186-7462 |")
+}
+
+func TestProbabilitiesResource(t *testing.T) {
+	contents := readResource(t, connectTestClient(t), probabilitiesURI)
+	assert.Equal(t, "application/json", contents.MIMEType)
+	var table ProbabilityTable
+	require.NoError(t, json.Unmarshal([]byte(contents.Text), &table))
+	assert.Equal(t, 2598960, table.TotalHands)
+	require.Len(t, table.Categories, 9)
+
+	flush := table.Categories[3]
+	assert.Equal(t, "Flush", flush.Category)
+	assert.Equal(t, 5108, flush.Hands)
+	assert.InDelta(t, 507.8, flush.OddsAgainst, 0.1, "dealt once in 508.8 hands")
+
+	pair := table.Categories[7]
+	assert.Equal(t, "One Pair", pair.Category)
+	assert.InDelta(t, 0.4226, pair.Probability, 1e-4)
+	assert.InDelta(t, 1-0.5012, pair.Cumulative, 1e-4, "anything but high card")
+	assert.InDelta(t, 1, table.Categories[8].Cumulative, 1e-12)
+}
+
+func TestUnknownResource(t *testing.T) {
+	_, err := connectTestClient(t).ReadResource(context.Background(), &mcp.ReadResourceParams{URI: "poker://nothing"})
+	assert.Error(t, err)
+}
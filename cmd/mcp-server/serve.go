@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var errShuttingDown = errors.New("server is shutting down; retry against another instance or later")
+
+// drain lets shutdown wait for the tool calls already running. Once it starts, new
+// tool calls are refused; other requests still work, so clients can read the refusal
+// and end their sessions cleanly.
+type drain struct {
+	mu      sync.Mutex
+	closing bool
+	calls   sync.WaitGroup
+}
+
+func (d *drain) middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+		d.mu.Lock()
+		if d.closing {
+			d.mu.Unlock()
+			return nil, errShuttingDown
+		}
+		d.calls.Add(1)
+		d.mu.Unlock()
+		defer d.calls.Done()
+		return next(ctx, method, req)
+	}
+}
+
+// wait refuses new tool calls and returns when the running ones finish, or with an
+// error when ctx ends first.
+func (d *drain) wait(ctx context.Context) error {
+	d.mu.Lock()
+	d.closing = true
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.calls.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("tool calls still running: %w", ctx.Err())
+	}
+}
+
+// newHTTPServer applies the configured timeouts. WriteTimeout would cut off the
+// server's SSE stream, so newHTTPHandler lifts it for those requests.
+func newHTTPServer(cfg Config, h http.Handler) *http.Server {
+	return &http.Server{
+		Handler:           h,
+		ReadHeaderTimeout: time.Duration(cfg.Timeouts.ReadHeader),
+		ReadTimeout:       time.Duration(cfg.Timeouts.Read),
+		WriteTimeout:      time.Duration(cfg.Timeouts.Write),
+		IdleTimeout:       time.Duration(cfg.Timeouts.Idle),
+	}
+}
+
+// readyAfter runs warm in the background and returns a readiness check that passes once
+// warm has finished, but only while serving is set. serveHTTP clears serving when
+// shutdown starts, and a warm-up finishing after that must not report ready again.
+func readyAfter(serving *atomic.Bool, warm func()) func() bool {
+	var warmed atomic.Bool
+	go func() {
+		warm()
+		warmed.Store(true)
+	}()
+	return func() bool { return warmed.Load() && serving.Load() }
+}
+
+// serveHTTP serves on ln until ctx ends, then shuts down: it reports not ready, stops
+// accepting connections, lets running tool calls finish, and closes the MCP sessions so
+// their event streams end. Everything must finish within timeout. TLS is used when
+// certFile is set.
+func serveHTTP(ctx context.Context, srv *http.Server, ln net.Listener, certFile, keyFile string,
+	s *mcp.Server, d *drain, ready *atomic.Bool, timeout time.Duration) error {
+	errc := make(chan error, 1)
+	go func() {
+		if certFile != "" {
+			errc <- srv.ServeTLS(ln, certFile, keyFile)
+		} else {
+			errc <- srv.Serve(ln)
+		}
+	}()
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	ready.Store(false)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	stopped := make(chan error, 1)
+	go func() { stopped <- srv.Shutdown(shutdownCtx) }()
+
+	if err := d.wait(shutdownCtx); err != nil {
+		// Closing a session waits for its handlers, so give up on the stragglers.
+		srv.Close()
+		return err
+	}
+	for ss := range s.Sessions() {
+		ss.Close()
+	}
+	if err := <-stopped; err != nil {
+		srv.Close()
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	return nil
+}
+
+// serveStdio serves one client on stdin and stdout until it disconnects or ctx ends.
+// On ctx, running tool calls get timeout to finish before the session is closed.
+func serveStdio(ctx context.Context, s *mcp.Server, t mcp.Transport, d *drain, timeout time.Duration) error {
+	runCtx, stop := context.WithCancel(context.Background())
+	defer stop()
+	errc := make(chan error, 1)
+	go func() { errc <- s.Run(runCtx, t) }()
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := d.wait(drainCtx); err != nil {
+		return err
+	}
+	stop()
+	<-errc // canceled on purpose
+	return nil
+}
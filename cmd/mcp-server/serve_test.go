@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowTool adds a tool that reports when it starts and finishes when released.
+func slowTool(s *mcp.Server) (started, release chan struct{}) {
+	started, release = make(chan struct{}), make(chan struct{})
+	s.AddTool(&mcp.Tool{Name: "slow", InputSchema: map[string]interface{}{"type": "object"}},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			close(started)
+			<-release
+			return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "done"}}}, nil
+		})
+	return started, release
+}
+
+// callAsync calls a tool in the background and returns the outcome on a channel.
+func callAsync(cs *mcp.ClientSession, name string) <-chan error {
+	errc := make(chan error, 1)
+	go func() {
+		res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: map[string]interface{}{}})
+		if err == nil && res.IsError {
+			err = assert.AnError
+		}
+		errc <- err
+	}()
+	return errc
+}
+
+type httpRun struct {
+	url    string
+	cancel context.CancelFunc
+	ready  *atomic.Bool
+	done   chan error
+}
+
+// runServeHTTP serves s with serveHTTP on a free port.
+func runServeHTTP(t *testing.T, s *mcp.Server, d *drain, cfg Config) httpRun {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	r := httpRun{url: "http://" + ln.Addr().String(), cancel: cancel, ready: &atomic.Bool{}, done: make(chan error, 1)}
+	r.ready.Store(true)
+	srv := newHTTPServer(cfg, newHTTPHandler(s, httpOptions{ready: r.ready.Load}))
+	go func() {
+		r.done <- serveHTTP(ctx, srv, ln, "", "", s, d, r.ready, time.Duration(cfg.Timeouts.Shutdown))
+	}()
+	return r
+}
+
+func TestShutdownDrainsToolCalls(t *testing.T) {
+	d := &drain{}
+	s := newServer(serverOptions{drain: d})
+	started, release := slowTool(s)
+	run := runServeHTTP(t, s, d, defaultConfig())
+
+	cs := connectHTTPClient(t, run.url)
+	call := callAsync(cs, "slow")
+	<-started
+	run.cancel()
+
+	require.Eventually(t, func() bool { return !run.ready.Load() }, time.Second, 5*time.Millisecond, "not ready once shutdown starts")
+	select {
+	case err := <-run.done:
+		t.Fatalf("shut down with a tool call running: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	assert.NoError(t, <-call, "the running call completes")
+	select {
+	case err := <-run.done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("open sessions kept the server from stopping")
+	}
+	_, err := http.Get(run.url + "/livez")
+	assert.Error(t, err, "the listener is closed")
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	d := &drain{}
+	s := newServer(serverOptions{drain: d})
+	started, release := slowTool(s)
+	defer close(release)
+	cfg := defaultConfig()
+	cfg.Timeouts.Shutdown = duration(100 * time.Millisecond)
+	run := runServeHTTP(t, s, d, cfg)
+
+	cs := connectHTTPClient(t, run.url)
+	callAsync(cs, "slow")
+	<-started
+	run.cancel()
+	select {
+	case err := <-run.done:
+		assert.ErrorContains(t, err, "tool calls still running")
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown ignored its timeout")
+	}
+}
+
+func TestReadyAfter(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		shutdown bool
+		want     bool
+	}{
+		{"warm-up finishes", false, true},
+		{"shutdown during warm-up", true, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var serving atomic.Bool
+			serving.Store(true)
+			release, warmed := make(chan struct{}), make(chan struct{})
+			ready := readyAfter(&serving, func() {
+				<-release
+				close(warmed)
+			})
+			assert.False(t, ready(), "not ready while warming up")
+			if tc.shutdown {
+				serving.Store(false)
+			}
+			close(release)
+			<-warmed
+			if tc.want {
+				assert.Eventually(t, ready, time.Second, 5*time.Millisecond)
+			} else {
+				assert.Never(t, ready, 100*time.Millisecond, 5*time.Millisecond, "shutdown is not undone")
+			}
+		})
+	}
+}
+
+func TestDrainRefusesNewCalls(t *testing.T) {
+	d := &drain{}
+	cs := connectServer(t, newServer(serverOptions{drain: d}))
+	require.NoError(t, d.wait(context.Background()), "nothing running")
+
+	_, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "evaluate_poker_hand",
+		Arguments: map[string]interface{}{"cards": []string{"As", "Ad", "Kc", "Kd", "2h"}},
+	})
+	assert.ErrorContains(t, err, "shutting down")
+	_, err = cs.ListTools(context.Background(), nil)
+	assert.NoError(t, err, "other requests still work")
+}
+
+func TestServeStdioDrains(t *testing.T) {
+	d := &drain{}
+	s := newServer(serverOptions{drain: d})
+	started, release := slowTool(s)
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- serveStdio(ctx, s, serverTransport, d, time.Minute) }()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	cs, err := client.Connect(context.Background(), clientTransport, nil)
+	require.NoError(t, err)
+	defer cs.Close()
+
+	call := callAsync(cs, "slow")
+	<-started
+	cancel()
+	close(release)
+	assert.NoError(t, <-call)
+	assert.NoError(t, <-done)
+}
+
+func TestHTTPServerTimeouts(t *testing.T) {
+	cfg := defaultConfig()
+	srv := newHTTPServer(cfg, http.NotFoundHandler())
+	assert.Equal(t, 10*time.Second, srv.ReadHeaderTimeout)
+	assert.Equal(t, 30*time.Second, srv.ReadTimeout)
+	assert.Equal(t, 2*time.Minute, srv.WriteTimeout)
+	assert.Equal(t, 2*time.Minute, srv.IdleTimeout)
+}
+
+func TestEventStreamOutlivesWriteTimeout(t *testing.T) {
+	s := newServer(serverOptions{})
+	cfg := defaultConfig()
+	cfg.Timeouts.Write = duration(100 * time.Millisecond)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := newHTTPServer(cfg, newHTTPHandler(s, httpOptions{}))
+	go srv.Serve(ln)
+	t.Cleanup(func() { srv.Close() })
+	url := "http://" + ln.Addr().String()
+
+	id := initialize(t, url)
+	req, err := http.NewRequest(http.MethodGet, url+"/mcp", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Mcp-Session-Id", id)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// well past the write timeout, the stream still delivers what the server sends
+	time.Sleep(300 * time.Millisecond)
+	s.AddTool(&mcp.Tool{Name: "late", InputSchema: map[string]interface{}{"type": "object"}},
+		func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return &mcp.CallToolResult{}, nil
+		})
+	got := make(chan string, 1)
+	go func() {
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			if strings.Contains(sc.Text(), "list_changed") {
+				got <- sc.Text()
+				return
+			}
+		}
+		close(got)
+	}()
+	select {
+	case line, ok := <-got:
+		assert.True(t, ok, "the stream was cut off")
+		assert.Contains(t, line, "notifications/tools/list_changed")
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification on the stream")
+	}
+}
+
+func TestMaxRequestBytes(t *testing.T) {
+	for _, tc := range []struct {
+		limit int64
+		want  int
+	}{
+		{0, http.StatusOK},
+		{1 << 20, http.StatusOK},
+		{64, http.StatusBadRequest},
+	} {
+		srv := httptest.NewServer(newHTTPHandler(newServer(serverOptions{}), httpOptions{maxRequestBytes: tc.limit}))
+		t.Cleanup(srv.Close)
+		assert.Equal(t, tc.want, postMCP(t, srv.URL, "", initializeRequest).StatusCode, "limit %d", tc.limit)
+	}
+}
@@ -0,0 +1,381 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/equity"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// Limits on request size and work, so one request cannot tie up the server.
+const (
+	maxBody      = 64 << 10
+	maxHands     = 10
+	maxTrials    = 200000
+	defaultTrial = equity.DefaultTrials
+)
+
+// apiError is the body of every non-2xx response.
+type apiError struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Code    string `json:"code"` // invalid_json, invalid_request, not_found, method_not_allowed or internal
+	Message string `json:"message"`
+	Field   string `json:"field,omitempty"` // JSON path of the offending input, e.g. "hands[1].cards[0]"
+}
+
+// requestError is a validation failure tied to an input field.
+type requestError struct {
+	field string
+	err   error
+}
+
+func (e *requestError) Error() string { return e.field + ": " + e.err.Error() }
+func (e *requestError) Unwrap() error { return e.err }
+
+func invalid(field, format string, args ...any) error {
+	return &requestError{field: field, err: fmt.Errorf(format, args...)}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code string, err error) {
+	d := errorDetail{Code: code, Message: err.Error()}
+	var re *requestError
+	if errors.As(err, &re) {
+		d.Field, d.Message = re.field, re.err.Error()
+	}
+	writeJSON(w, status, apiError{Error: d})
+}
+
+// endpoint adapts a typed handler: it accepts only POST, decodes the body strictly
+// (unknown fields are errors, so typos don't silently fall back to defaults) and maps
+// validation errors to 400s.
+func endpoint[Req, Resp any](f func(Req) (Resp, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", fmt.Errorf("%s requires POST", r.URL.Path))
+			return
+		}
+		var req Req
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBody))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_json", err)
+			return
+		}
+		if _, err := dec.Token(); err != io.EOF {
+			writeError(w, http.StatusBadRequest, "invalid_json", fmt.Errorf("body must hold a single JSON object"))
+			return
+		}
+		resp, err := f(req)
+		var re *requestError
+		switch {
+		case errors.As(err, &re):
+			writeError(w, http.StatusBadRequest, "invalid_request", err)
+		case err != nil:
+			writeError(w, http.StatusInternalServerError, "internal", err)
+		default:
+			writeJSON(w, http.StatusOK, resp)
+		}
+	}
+}
+
+// parseCards parses a list of cards in compact notation ("As", "Td", "10h", "K♠") and
+// rejects duplicates, both within the list and against seen when it is non-nil.
+func parseCards(field string, in []string, seen map[cards.Card]string) ([]cards.Card, error) {
+	out := make([]cards.Card, len(in))
+	for i, s := range in {
+		f := fmt.Sprintf("%s[%d]", field, i)
+		c, err := cards.Parse(s)
+		if err != nil {
+			return nil, &requestError{field: f, err: err}
+		}
+		if seen != nil {
+			if prev, ok := seen[c]; ok {
+				return nil, invalid(f, "%s is already used by %s", c.Notation(), prev)
+			}
+			seen[c] = f
+		}
+		out[i] = c
+	}
+	return out, nil
+}
+
+func parseFive(field string, in []string) ([]cards.Card, error) {
+	if len(in) != 5 {
+		return nil, invalid(field, "exactly 5 cards are required, got %d", len(in))
+	}
+	return parseCards(field, in, map[cards.Card]string{})
+}
+
+func notation(cs []cards.Card) []string {
+	out := make([]string, len(cs))
+	for i, c := range cs {
+		out[i] = c.Notation()
+	}
+	return out
+}
+
+// Evaluation describes an evaluated five-card hand.
+type Evaluation struct {
+	Category    string   `json:"category"`      // e.g. "Two Pair"
+	CategoryID  int      `json:"category_rank"` // 0 for high card up to 8 for a straight flush
+	Ranks       []string `json:"ranks"`         // tiebreak ranks, most significant first
+	Description string   `json:"description"`   // e.g. "Kings and Sevens, 10 kicker"
+	Rank        int      `json:"rank"`          // 1 (royal flush) to 7462 among distinct hands
+	Beats       float64  `json:"beats"`         // fraction of dealt hands strictly weaker
+}
+
+func evaluation(e hand.EvaluatedHand) (Evaluation, error) {
+	st, err := hand.StrengthOf(e)
+	if err != nil {
+		return Evaluation{}, err
+	}
+	ranks := make([]string, len(e.Ranks))
+	for i, r := range e.Ranks {
+		ranks[i] = r.String()
+	}
+	return Evaluation{
+		Category: e.Category.String(), CategoryID: int(e.Category), Ranks: ranks,
+		Description: st.Description, Rank: st.Rank, Beats: st.Beats,
+	}, nil
+}
+
+// EvaluateRequest is the body of POST /evaluate.
+type EvaluateRequest struct {
+	Cards []string `json:"cards"`
+}
+
+// EvaluateResponse is returned by POST /evaluate.
+type EvaluateResponse struct {
+	Cards []string `json:"cards"`
+	Evaluation
+}
+
+func evaluate(req EvaluateRequest) (EvaluateResponse, error) {
+	cs, err := parseFive("cards", req.Cards)
+	if err != nil {
+		return EvaluateResponse{}, err
+	}
+	ev, err := evaluation(hand.Evaluate(hand.Hand{Cards: cs}))
+	return EvaluateResponse{Cards: notation(cs), Evaluation: ev}, err
+}
+
+// NamedHand is one player's cards.
+type NamedHand struct {
+	Name  string   `json:"name"`
+	Cards []string `json:"cards"`
+}
+
+// CompareRequest is the body of POST /compare.
+type CompareRequest struct {
+	Hands []NamedHand `json:"hands"`
+}
+
+// Placing is one hand's result in a comparison.
+type Placing struct {
+	Name  string   `json:"name"`
+	Cards []string `json:"cards"`
+	Place int      `json:"place"` // 1 for the winners; tied hands share a place
+	Evaluation
+}
+
+// CompareResponse is returned by POST /compare, strongest hand first.
+type CompareResponse struct {
+	Results []Placing `json:"results"`
+	Winners []string  `json:"winners"`
+}
+
+func compare(req CompareRequest) (CompareResponse, error) {
+	if len(req.Hands) < 2 || len(req.Hands) > maxHands {
+		return CompareResponse{}, invalid("hands", "2 to %d hands are required, got %d", maxHands, len(req.Hands))
+	}
+	seen := map[cards.Card]string{}
+	names := map[string]bool{}
+	evals := make([]hand.EvaluatedHand, len(req.Hands))
+	var resp CompareResponse
+	for i, h := range req.Hands {
+		field := fmt.Sprintf("hands[%d]", i)
+		name := h.Name
+		if name == "" {
+			name = fmt.Sprintf("hand %d", i+1)
+		}
+		if names[name] {
+			return CompareResponse{}, invalid(field+".name", "duplicate name %q", name)
+		}
+		names[name] = true
+		if len(h.Cards) != 5 {
+			return CompareResponse{}, invalid(field+".cards", "exactly 5 cards are required, got %d", len(h.Cards))
+		}
+		cs, err := parseCards(field+".cards", h.Cards, seen)
+		if err != nil {
+			return CompareResponse{}, err
+		}
+		evals[i] = hand.Evaluate(hand.Hand{Cards: cs})
+		ev, err := evaluation(evals[i])
+		if err != nil {
+			return CompareResponse{}, err
+		}
+		resp.Results = append(resp.Results, Placing{Name: name, Cards: notation(cs), Evaluation: ev})
+	}
+
+	for i, place := range hand.Places(evals) {
+		resp.Results[i].Place = place
+	}
+	sort.SliceStable(resp.Results, func(a, b int) bool { return resp.Results[a].Place < resp.Results[b].Place })
+	for _, p := range resp.Results {
+		if p.Place == 1 {
+			resp.Winners = append(resp.Winners, p.Name)
+		}
+	}
+	return resp, nil
+}
+
+// BestHandRequest is the body of POST /best-hand: five to nine cards, e.g. Hold'em hole
+// cards plus the board.
+type BestHandRequest struct {
+	Cards []string `json:"cards"`
+}
+
+// BestHandResponse is returned by POST /best-hand.
+type BestHandResponse struct {
+	Best []string `json:"best"`
+	Evaluation
+}
+
+func bestHand(req BestHandRequest) (BestHandResponse, error) {
+	if len(req.Cards) < 5 || len(req.Cards) > 9 {
+		return BestHandResponse{}, invalid("cards", "5 to 9 cards are required, got %d", len(req.Cards))
+	}
+	cs, err := parseCards("cards", req.Cards, map[cards.Card]string{})
+	if err != nil {
+		return BestHandResponse{}, err
+	}
+	best, e := hand.BestHand(cs)
+	ev, err := evaluation(e)
+	return BestHandResponse{Best: notation(best.Cards), Evaluation: ev}, err
+}
+
+// DiscardsRequest is the body of POST /discards. MaxDiscard defaults to the usual draw
+// rule: three cards, or four when keeping an ace.
+type DiscardsRequest struct {
+	Cards      []string `json:"cards"`
+	MaxDiscard *int     `json:"max_discard,omitempty"`
+}
+
+// Discard is a card to throw away.
+type Discard struct {
+	Index int    `json:"index"`
+	Card  string `json:"card"`
+}
+
+// DiscardsResponse is returned by POST /discards.
+type DiscardsResponse struct {
+	Discards   []Discard `json:"discards"` // empty to stand pat
+	Keep       []string  `json:"keep"`
+	MaxDiscard int       `json:"max_discard"`
+	Evaluation
+}
+
+func discards(req DiscardsRequest) (DiscardsResponse, error) {
+	cs, err := parseFive("cards", req.Cards)
+	if err != nil {
+		return DiscardsResponse{}, err
+	}
+	h := hand.Hand{Cards: cs}
+	max := hand.ComputeMaxDiscard(h)
+	if req.MaxDiscard != nil {
+		if *req.MaxDiscard < 0 || *req.MaxDiscard > 5 {
+			return DiscardsResponse{}, invalid("max_discard", "must be 0 to 5, got %d", *req.MaxDiscard)
+		}
+		max = *req.MaxDiscard
+	}
+	idx := hand.RecommendDiscards(h, max)
+	sort.Ints(idx)
+	resp := DiscardsResponse{Discards: []Discard{}, Keep: []string{}, MaxDiscard: max}
+	thrown := map[int]bool{}
+	for _, i := range idx {
+		thrown[i] = true
+		resp.Discards = append(resp.Discards, Discard{Index: i, Card: cs[i].Notation()})
+	}
+	for i, c := range cs {
+		if !thrown[i] {
+			resp.Keep = append(resp.Keep, c.Notation())
+		}
+	}
+	resp.Evaluation, err = evaluation(hand.Evaluate(h))
+	return resp, err
+}
+
+// EquityRequest is the body of POST /equity: Hold'em all-in equity between two or more
+// ranges ("AsKs", "QQ+,AKs"), with an optional board and dead cards.
+type EquityRequest struct {
+	Ranges []string `json:"ranges"`
+	Board  []string `json:"board,omitempty"`
+	Dead   []string `json:"dead,omitempty"`
+	Trials int      `json:"trials,omitempty"`
+	Seed   int64    `json:"seed,omitempty"` // fixes the result; random when 0
+}
+
+// EquityResponse is returned by POST /equity, one entry per range in request order.
+type EquityResponse struct {
+	Ranges []string  `json:"ranges"`
+	Equity []float64 `json:"equity"`
+	Win    []float64 `json:"win"`
+	Tie    []float64 `json:"tie"`
+	Trials int       `json:"trials"`
+}
+
+func computeEquity(req EquityRequest) (EquityResponse, error) {
+	if len(req.Ranges) < 2 || len(req.Ranges) > maxHands {
+		return EquityResponse{}, invalid("ranges", "2 to %d ranges are required, got %d", maxHands, len(req.Ranges))
+	}
+	ranges := make([]equity.Range, len(req.Ranges))
+	for i, s := range req.Ranges {
+		r, err := equity.ParseRange(s)
+		if err != nil {
+			return EquityResponse{}, &requestError{field: fmt.Sprintf("ranges[%d]", i), err: err}
+		}
+		ranges[i] = r
+	}
+	if len(req.Board) > 5 {
+		return EquityResponse{}, invalid("board", "at most 5 cards, got %d", len(req.Board))
+	}
+	seen := map[cards.Card]string{}
+	board, err := parseCards("board", req.Board, seen)
+	if err != nil {
+		return EquityResponse{}, err
+	}
+	dead, err := parseCards("dead", req.Dead, seen)
+	if err != nil {
+		return EquityResponse{}, err
+	}
+	trials := req.Trials
+	if trials == 0 {
+		trials = defaultTrial
+	}
+	if trials < 0 || trials > maxTrials {
+		return EquityResponse{}, invalid("trials", "must be 1 to %d, got %d", maxTrials, trials)
+	}
+
+	res, err := equity.Compute(ranges[0], ranges[1:], equity.Options{Board: board, Dead: dead, Trials: trials, Seed: req.Seed})
+	if err != nil {
+		// the inputs parsed, so what's left is ranges that cannot all be dealt
+		return EquityResponse{}, &requestError{field: "ranges", err: err}
+	}
+	return EquityResponse{Ranges: req.Ranges, Equity: res.Equity, Win: res.Win, Tie: res.Tie, Trials: res.Trials}, nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// post sends body to the endpoint and decodes the response into out.
+func post(t *testing.T, path, body string, out any) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	newMux().ServeHTTP(rec, req)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), out), rec.Body.String())
+	return rec.Code
+}
+
+func TestEvaluate(t *testing.T) {
+	var resp EvaluateResponse
+	code := post(t, "/evaluate", `{"cards":["Kh","Kd","7c","7♠","10h"]}`, &resp)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"Kh", "Kd", "7c", "7s", "Th"}, resp.Cards)
+	assert.Equal(t, "Two Pair", resp.Category)
+	assert.Equal(t, 2, resp.CategoryID)
+	assert.Equal(t, []string{"K", "7", "10"}, resp.Ranks)
+	assert.Equal(t, "Kings and Sevens, 10 kicker", resp.Description)
+	assert.Greater(t, resp.Beats, 0.9)
+}
+
+func TestCompare(t *testing.T) {
+	var resp CompareResponse
+	code := post(t, "/compare", `{"hands":[
+		{"name":"alice","cards":["2c","3d","4h","5s","7c"]},
+		{"name":"bob","cards":["Ac","Ad","Kh","Ks","2d"]},
+		{"cards":["Ah","As","Kc","Kd","2h"]}]}`, &resp)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"bob", "hand 3"}, resp.Winners)
+	places := map[string]int{}
+	for _, r := range resp.Results {
+		places[r.Name] = r.Place
+	}
+	assert.Equal(t, map[string]int{"bob": 1, "hand 3": 1, "alice": 3}, places)
+	assert.Equal(t, "alice", resp.Results[2].Name)
+}
+
+func TestBestHand(t *testing.T) {
+	var resp BestHandResponse
+	code := post(t, "/best-hand", `{"cards":["As","Ks","2d","Qs","Js","7h","Ts"]}`, &resp)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Straight Flush", resp.Category)
+	assert.Equal(t, 1, resp.Rank)
+	assert.ElementsMatch(t, []string{"As", "Ks", "Qs", "Js", "Ts"}, resp.Best)
+}
+
+func TestDiscards(t *testing.T) {
+	var resp DiscardsResponse
+	code := post(t, "/discards", `{"cards":["9c","9d","Ah","5s","2c"]}`, &resp)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []Discard{{2, "Ah"}, {3, "5s"}, {4, "2c"}}, resp.Discards)
+	assert.Equal(t, []string{"9c", "9d"}, resp.Keep)
+	assert.Equal(t, 4, resp.MaxDiscard)
+
+	code = post(t, "/discards", `{"cards":["9c","9d","Ah","5s","2c"],"max_discard":0}`, &resp)
+	require.Equal(t, http.StatusOK, code)
+	assert.Empty(t, resp.Discards, "max_discard 0 stands pat")
+	assert.Len(t, resp.Keep, 5)
+}
+
+func TestEquity(t *testing.T) {
+	body := `{"ranges":["AsAh","KsKh"],"trials":4000,"seed":3}`
+	var resp EquityResponse
+	require.Equal(t, http.StatusOK, post(t, "/equity", body, &resp))
+	assert.Equal(t, 4000, resp.Trials)
+	assert.InDelta(t, 0.82, resp.Equity[0], 0.03)
+	assert.InDelta(t, 1, resp.Equity[0]+resp.Equity[1], 1e-9)
+
+	var again EquityResponse
+	post(t, "/equity", body, &again)
+	assert.Equal(t, resp, again, "a seed fixes the result")
+}
+
+func TestValidationErrors(t *testing.T) {
+	tests := []struct {
+		path, body  string
+		code, field string
+		msg         string
+	}{
+		{"/evaluate", `{"cards":["As"]}`, "invalid_request", "cards", "exactly 5 cards"},
+		{"/evaluate", `{"cards":["As","Kd","Zz","2c","3c"]}`, "invalid_request", "cards[2]", `invalid card "Zz"`},
+		{"/evaluate", `{"cards":["As","Kd","As","2c","3c"]}`, "invalid_request", "cards[2]", "already used by cards[0]"},
+		{"/evaluate", `{"card":["As"]}`, "invalid_json", "", "unknown field"},
+		{"/evaluate", `{"cards":`, "invalid_json", "", "unexpected EOF"},
+		{"/evaluate", `{} {}`, "invalid_json", "", "single JSON object"},
+		{"/compare", `{"hands":[{"cards":["As","Kd","Qc","2c","3c"]}]}`, "invalid_request", "hands", "2 to 10 hands"},
+		{"/compare", `{"hands":[{"cards":["As","Kd","Qc","2c","3c"]},{"cards":["Ah","Kh","Qh","2h","As"]}]}`,
+			"invalid_request", "hands[1].cards[4]", "already used by hands[0].cards[0]"},
+		{"/compare", `{"hands":[{"name":"a","cards":["As","Kd","Qc","2c","3c"]},{"name":"a","cards":["Ah","Kh","Qh","2h","3h"]}]}`,
+			"invalid_request", "hands[1].name", "duplicate name"},
+		{"/best-hand", `{"cards":["As","Kd","Qc","2c"]}`, "invalid_request", "cards", "5 to 9 cards"},
+		{"/discards", `{"cards":["As","Kd","Qc","2c","3c"],"max_discard":6}`, "invalid_request", "max_discard", "0 to 5"},
+		{"/equity", `{"ranges":["AA"]}`, "invalid_request", "ranges", "2 to 10 ranges"},
+		{"/equity", `{"ranges":["AA","XY"]}`, "invalid_request", "ranges[1]", ""},
+		{"/equity", `{"ranges":["AA","KK"],"board":["As"],"dead":["As"]}`, "invalid_request", "dead[0]", "already used by board[0]"},
+		{"/equity", `{"ranges":["AA","KK"],"trials":1000000}`, "invalid_request", "trials", "1 to 200000"},
+		{"/equity", `{"ranges":["AsAh","AsAh"]}`, "invalid_request", "ranges", "no valid deal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path+" "+tt.body, func(t *testing.T) {
+			var resp apiError
+			assert.Equal(t, http.StatusBadRequest, post(t, tt.path, tt.body, &resp))
+			assert.Equal(t, tt.code, resp.Error.Code)
+			assert.Equal(t, tt.field, resp.Error.Field)
+			assert.Contains(t, resp.Error.Message, tt.msg)
+		})
+	}
+}
+
+func TestRoutingErrors(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newMux().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/evaluate", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, http.MethodPost, rec.Header().Get("Allow"))
+	assert.Contains(t, rec.Body.String(), `"method_not_allowed"`)
+
+	var resp apiError
+	assert.Equal(t, http.StatusNotFound, post(t, "/rank", `{}`, &resp))
+	assert.Equal(t, "not_found", resp.Error.Code)
+}
+
+// TestOpenAPISpecMatchesHandlers keeps the published spec in step with the code: every
+// endpoint is documented and every request schema lists exactly the fields decoded.
+func TestOpenAPISpecMatchesHandlers(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newMux().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var spec struct {
+		OpenAPI    string                     `json:"openapi"`
+		Paths      map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec))
+	assert.Equal(t, "3.0.3", spec.OpenAPI)
+
+	requests := map[string]any{
+		"/evaluate":  EvaluateRequest{},
+		"/compare":   CompareRequest{},
+		"/best-hand": BestHandRequest{},
+		"/discards":  DiscardsRequest{},
+		"/equity":    EquityRequest{},
+	}
+	var paths []string
+	for p := range spec.Paths {
+		paths = append(paths, p)
+	}
+	assert.ElementsMatch(t, []string{"/best-hand", "/compare", "/discards", "/equity", "/evaluate"}, paths)
+
+	for path, req := range requests {
+		typ := reflect.TypeOf(req)
+		schema, ok := spec.Components.Schemas[typ.Name()]
+		require.True(t, ok, "schema for %s", typ.Name())
+		var fields []string
+		for i := 0; i < typ.NumField(); i++ {
+			fields = append(fields, strings.Split(typ.Field(i).Tag.Get("json"), ",")[0])
+		}
+		var documented []string
+		for f := range schema.Properties {
+			documented = append(documented, f)
+		}
+		assert.ElementsMatch(t, fields, documented, path)
+		assert.Contains(t, string(spec.Paths[path]), "#/components/schemas/"+typ.Name())
+	}
+}
@@ -0,0 +1,75 @@
+// Command poker-api serves hand evaluation and equity over plain HTTP with JSON bodies,
+// for services that would rather not speak MCP.
+//
+//	poker-api -addr :8091
+//
+//	POST /evaluate   {"cards":["As","Ks","Qs","Js","Ts"]}
+//	POST /compare    {"hands":[{"name":"alice","cards":[...]},{"name":"bob","cards":[...]}]}
+//	POST /best-hand  {"cards":["As","Kd","7c","7h","2s","Ad","9c"]}
+//	POST /discards   {"cards":[...],"max_discard":3}
+//	POST /equity     {"ranges":["AsKs","QQ"],"board":["Qh","7s","2s"],"trials":20000}
+//	GET  /openapi.json
+//
+// Cards use compact notation: a rank (2-9, T or 10, J, Q, K, A) followed by a suit
+// letter (c, d, h, s) or symbol; responses always use the ASCII form, e.g. "Td". Errors
+// are JSON objects of the form {"error":{"code":...,"message":...,"field":...}}.
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+//go:embed openapi.json
+var openAPISpec []byte
+
+// newMux routes every endpoint; anything else is a structured 404.
+func newMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/evaluate", endpoint(evaluate))
+	mux.Handle("/compare", endpoint(compare))
+	mux.Handle("/best-hand", endpoint(bestHand))
+	mux.Handle("/discards", endpoint(discards))
+	mux.Handle("/equity", endpoint(computeEquity))
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(openAPISpec)
+	})
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "OK")
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not_found", fmt.Errorf("no endpoint %s", r.URL.Path))
+	})
+	return mux
+}
+
+func main() {
+	fs := flag.NewFlagSet("poker-api", flag.ContinueOnError)
+	addr := fs.String("addr", ":8091", "address to listen on")
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		os.Exit(2)
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           newMux(),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      time.Minute, // large equity requests take a few seconds
+	}
+	log.Printf("poker API listening on %s", *addr)
+	if err := srv.ListenAndServe(); err != nil {
+		log.Fatalf("HTTP server failed: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+// Command poker-grpc serves the hand evaluator over gRPC; see pokergrpc/poker.proto for
+// the service definition.
+//
+//	poker-grpc -addr :9090
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+
+	"github.com/dangogh/GoPoker/pokergrpc"
+)
+
+// serve runs the server on lis until stop is closed, then lets in-flight calls finish.
+func serve(lis net.Listener, stop <-chan struct{}) error {
+	srv := pokergrpc.NewServer()
+	go func() {
+		<-stop
+		srv.GracefulStop()
+	}()
+	if err := srv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+func main() {
+	addr := flag.String("addr", ":9090", "address to listen on")
+	flag.Parse()
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("listen: %v", err)
+	}
+	stop := make(chan struct{})
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sig
+		close(stop)
+	}()
+	log.Printf("poker gRPC server listening on %s", lis.Addr())
+	if err := serve(lis, stop); err != nil {
+		log.Fatalf("serve: %v", err)
+	}
+}
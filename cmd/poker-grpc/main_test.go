@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/pokergrpc"
+)
+
+func TestServeOverTCP(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	stop := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- serve(lis, stop) }()
+
+	client, cc, err := pokergrpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer cc.Close()
+	cs, err := cards.ParseList("9c 9d 9h 2s 2c")
+	require.NoError(t, err)
+	e, err := client.Evaluate(context.Background(), pokergrpc.HandOf("", cs))
+	require.NoError(t, err)
+	assert.Equal(t, pokergrpc.Category_CATEGORY_FULL_HOUSE, e.Category)
+	assert.Equal(t, "Nines full of Twos", e.Description)
+
+	close(stop)
+	assert.NoError(t, <-done)
+}
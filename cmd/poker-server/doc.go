@@ -0,0 +1,43 @@
+// Command poker-server hosts five-card draw tables that people play over WebSocket.
+//
+//	poker-server -addr :8090 -tables 2 -sb 1 -bb 2 -timeout 30s
+//
+// Clients connect to /ws and exchange JSON messages, one per WebSocket text frame. Every
+// message is an object with a "type" field; the other fields depend on the type. /health
+// answers "OK" for load balancers.
+//
+// # Client messages
+//
+//	{"type":"list"}                                  list the tables
+//	{"type":"join","table":"t1","name":"alice"}      watch a table; names are unique per table
+//	{"type":"sit"}  or  {"type":"sit","seat":3}      sit down with the table's buy-in
+//	{"type":"act","action":"raise","amount":40}      answer an act prompt: fold, check, call,
+//	                                                 bet or raise; amount is the total to raise to
+//	{"type":"discard","discards":[0,3]}              answer a discard prompt with card indexes;
+//	                                                 an empty list stands pat
+//	{"type":"stand"}                                 give up the seat but keep watching
+//	{"type":"leave"}                                 leave the table
+//
+// # Server messages
+//
+//	{"type":"tables","tables":[{"id":"t1","name":"t1","small_blind":1,"big_blind":2,...}]}
+//	{"type":"state","state":{"table":"t1","hand":12,"in_hand":false,"button":3,
+//	    "seats":[{"seat":0,"name":"alice","stack":212},...],"you":0}}
+//	{"type":"event","event":{"kind":"action","seat":1,"name":"bob","action":"raise",
+//	    "amount":8,"text":"bob raises to 8"}}
+//	{"type":"prompt","prompt":{"kind":"act","hand":["A♠","K♠","7♦","7♣","2♥"],
+//	    "round":"pre-draw","pot":3,"to_call":1,"min_raise":4,"stack":199,"big_blind":2,
+//	    "timeout_ms":30000}}
+//	{"type":"error","error":"it is not your turn"}
+//
+// A state message is sent whenever the seats change and at the start and end of every
+// hand. Events narrate the hand: ante, blind, deal, action, draw, show and win.
+//
+// Hole cards are private to their connection. A player's deal and draw events carry
+// their own cards; everyone else gets the same events without cards, and the only
+// other cards a client ever sees are those shown at showdown.
+//
+// A player who does not answer a prompt in time checks, or folds to a bet, and stands
+// pat at the draw. Sitting down or standing up during a hand takes effect when it ends;
+// a player who stands up or disconnects mid-hand folds.
+package main
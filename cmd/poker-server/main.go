@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/dangogh/GoPoker/lobby"
+)
+
+// options are the command-line settings.
+type options struct {
+	addr   string
+	tables int
+	table  lobby.TableConfig
+}
+
+func parseFlags(args []string, out io.Writer) (*options, error) {
+	fs := flag.NewFlagSet("poker-server", flag.ContinueOnError)
+	fs.SetOutput(out)
+	o := &options{}
+	fs.StringVar(&o.addr, "addr", ":8090", "address to listen on")
+	fs.IntVar(&o.tables, "tables", 2, "number of tables to open")
+	fs.IntVar(&o.table.Game.Ante, "ante", 0, "ante")
+	fs.IntVar(&o.table.Game.SmallBlind, "sb", 1, "small blind")
+	fs.IntVar(&o.table.Game.BigBlind, "bb", 2, "big blind")
+	fs.IntVar(&o.table.BuyIn, "buyin", 0, "chips each player sits down with (0 = 100 big blinds)")
+	fs.DurationVar(&o.table.ActionTimeout, "timeout", lobby.DefaultActionTimeout, "time a player has to act")
+	fs.DurationVar(&o.table.HandPause, "pause", lobby.DefaultHandPause, "pause between hands")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if o.tables < 1 {
+		return nil, fmt.Errorf("tables must be > 0")
+	}
+	return o, nil
+}
+
+// newLobby opens the configured tables.
+func newLobby(o *options) (*lobby.Lobby, error) {
+	l := lobby.New()
+	for i := 0; i < o.tables; i++ {
+		if _, err := l.Create(o.table); err != nil {
+			l.Close()
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// newMux serves the game on /ws and a liveness check on /health.
+func newMux(l *lobby.Lobby) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/ws", &wsHandler{lobby: l})
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "OK")
+	})
+	return mux
+}
+
+func main() {
+	o, err := parseFlags(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	l, err := newLobby(o)
+	if err != nil {
+		log.Fatalf("creating tables: %v", err)
+	}
+	defer l.Close()
+
+	srv := &http.Server{Addr: o.addr, Handler: newMux(l), ReadHeaderTimeout: 10 * time.Second}
+	log.Printf("poker server listening on %s with %d tables (%d/%d blinds)", o.addr, o.tables, o.table.Game.SmallBlind, o.table.Game.BigBlind)
+	if err := srv.ListenAndServe(); err != nil {
+		log.Fatalf("HTTP server failed: %v", err)
+	}
+}
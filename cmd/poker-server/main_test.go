@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/lobby"
+)
+
+func newTestServer(t *testing.T, args ...string) *httptest.Server {
+	t.Helper()
+	o, err := parseFlags(append([]string{"-pause", "1ms", "-timeout", "2s"}, args...), io.Discard)
+	require.NoError(t, err)
+	l, err := newLobby(o)
+	require.NoError(t, err)
+	srv := httptest.NewServer(newMux(l))
+	t.Cleanup(func() {
+		srv.Close()
+		l.Close()
+	})
+	return srv
+}
+
+func dial(t *testing.T, srv *httptest.Server) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func send(t *testing.T, conn *websocket.Conn, m lobby.Message) {
+	t.Helper()
+	require.NoError(t, conn.WriteJSON(m))
+}
+
+// recv reads messages until one of the given type arrives.
+func recv(t *testing.T, conn *websocket.Conn, typ string) lobby.Message {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	for {
+		var m lobby.Message
+		require.NoError(t, conn.ReadJSON(&m))
+		if m.Type == typ {
+			return m
+		}
+	}
+}
+
+// autoplay calls every bet and stands pat until a hand ends, returning everything it saw.
+func autoplay(t *testing.T, conn *websocket.Conn) []lobby.Message {
+	t.Helper()
+	var seen []lobby.Message
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	started := false
+	for {
+		var m lobby.Message
+		require.NoError(t, conn.ReadJSON(&m))
+		seen = append(seen, m)
+		switch {
+		case m.Type == lobby.TypePrompt && m.Prompt.Kind == "discard":
+			send(t, conn, lobby.Message{Type: lobby.TypeDiscard})
+		case m.Type == lobby.TypePrompt:
+			action := "call"
+			if m.Prompt.ToCall == 0 {
+				action = "check"
+			}
+			send(t, conn, lobby.Message{Type: lobby.TypeAct, Action: action})
+		case m.Type == lobby.TypeState && m.State.InHand:
+			started = true
+		case m.Type == lobby.TypeState && started:
+			return seen
+		}
+	}
+}
+
+func TestServerPlaysAHand(t *testing.T) {
+	srv := newTestServer(t, "-tables", "1", "-sb", "5", "-bb", "10")
+	alice, bob := dial(t, srv), dial(t, srv)
+
+	send(t, alice, lobby.Message{Type: lobby.TypeList})
+	tables := recv(t, alice, lobby.TypeTables).Tables
+	require.Len(t, tables, 1)
+	assert.Equal(t, 10, tables[0].BigBlind)
+	assert.Equal(t, 1000, tables[0].BuyIn)
+
+	for name, conn := range map[string]*websocket.Conn{"alice": alice, "bob": bob} {
+		send(t, conn, lobby.Message{Type: lobby.TypeJoin, Table: tables[0].ID, Name: name})
+		recv(t, conn, lobby.TypeState)
+		send(t, conn, lobby.Message{Type: lobby.TypeSit})
+	}
+
+	done := make(chan []lobby.Message)
+	go func() { done <- autoplay(t, bob) }()
+	aliceSaw := autoplay(t, alice)
+	bobSaw := <-done
+
+	for name, seen := range map[string][]lobby.Message{"alice": aliceSaw, "bob": bobSaw} {
+		dealt := 0
+		for _, m := range seen {
+			if m.Type == lobby.TypeEvent && m.Event.Kind == "deal" {
+				dealt++
+				if m.Event.Name == name {
+					assert.Len(t, m.Event.Cards, 5, "%s sees their own hand", name)
+				} else {
+					assert.Empty(t, m.Event.Cards, "%s cannot see %s's hand", name, m.Event.Name)
+				}
+			}
+		}
+		assert.Equal(t, 2, dealt)
+		last := seen[len(seen)-1].State
+		assert.Equal(t, 1, last.Hand)
+		assert.Equal(t, 2000, last.Seats[0].Stack+last.Seats[1].Stack)
+	}
+}
+
+func TestServerRejectsBadMessages(t *testing.T) {
+	srv := newTestServer(t)
+	conn := dial(t, srv)
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("{not json")))
+	assert.Contains(t, recv(t, conn, lobby.TypeError).Error, "invalid message")
+
+	send(t, conn, lobby.Message{Type: lobby.TypeSit})
+	assert.Contains(t, recv(t, conn, lobby.TypeError).Error, "join a table first")
+
+	// the connection survives both
+	send(t, conn, lobby.Message{Type: lobby.TypeList})
+	assert.Len(t, recv(t, conn, lobby.TypeTables).Tables, 2)
+}
+
+func TestHealth(t *testing.T) {
+	srv := newTestServer(t)
+	resp, err := http.Get(srv.URL + "/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestParseFlags(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := parseFlags([]string{"-tables", "0"}, &buf)
+	assert.Error(t, err)
+	_, err = parseFlags([]string{"-nope"}, &buf)
+	assert.Error(t, err)
+
+	o, err := parseFlags([]string{"-bb", "0"}, &buf)
+	require.NoError(t, err)
+	_, err = newLobby(o)
+	assert.ErrorContains(t, err, "big blind")
+}
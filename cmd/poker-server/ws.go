@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/dangogh/GoPoker/lobby"
+)
+
+const (
+	// sendBuffer is how many messages may queue for a slow client before it is dropped;
+	// the tables never wait on a connection.
+	sendBuffer = 256
+	writeWait  = 10 * time.Second
+	pongWait   = 60 * time.Second
+	pingPeriod = pongWait * 9 / 10
+	maxMessage = 4096
+)
+
+// wsHandler upgrades each request to a WebSocket and connects it to the lobby.
+type wsHandler struct {
+	lobby    *lobby.Lobby
+	upgrader websocket.Upgrader
+}
+
+func (h *wsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // Upgrade has already replied with an error
+	}
+	c := &wsConn{conn: conn, out: make(chan lobby.Message, sendBuffer), done: make(chan struct{})}
+	s := h.lobby.Connect(c.send)
+	go c.writeLoop()
+	c.readLoop(s)
+}
+
+// wsConn pumps messages between one WebSocket and its lobby session.
+type wsConn struct {
+	conn *websocket.Conn
+	out  chan lobby.Message
+	done chan struct{} // closed when the connection is finished
+}
+
+// send queues a message without blocking; a client that cannot keep up is disconnected.
+func (c *wsConn) send(m lobby.Message) {
+	select {
+	case c.out <- m:
+	case <-c.done:
+	default:
+		log.Printf("%s: send buffer full, disconnecting", c.conn.RemoteAddr())
+		c.conn.Close()
+	}
+}
+
+func (c *wsConn) readLoop(s *lobby.Session) {
+	defer func() {
+		close(c.done)
+		s.Close()
+		c.conn.Close()
+	}()
+	c.conn.SetReadLimit(maxMessage)
+	_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error { return c.conn.SetReadDeadline(time.Now().Add(pongWait)) })
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var m lobby.Message
+		if err := json.Unmarshal(data, &m); err != nil {
+			c.send(lobby.Message{Type: lobby.TypeError, Error: "invalid message: " + err.Error()})
+			continue
+		}
+		s.Handle(m)
+	}
+}
+
+func (c *wsConn) writeLoop() {
+	ping := time.NewTicker(pingPeriod)
+	defer ping.Stop()
+	for {
+		select {
+		case m := <-c.out:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteJSON(m); err != nil {
+				c.conn.Close()
+				return
+			}
+		case <-ping.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				c.conn.Close()
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
@@ -26,6 +26,12 @@ func NewDeck() *Deck {
 	return &Deck{cards: cs}
 }
 
+// FromCards builds a deck that deals the given cards in order. It is used to replay a
+// recorded deal or to stack the deck in tests; the slice is copied.
+func FromCards(cs []cards.Card) *Deck {
+	return &Deck{cards: append([]cards.Card(nil), cs...)}
+}
+
 // Shuffle randomly shuffles the remaining cards in the deck.
 // Note: randomness source is the package-level math/rand; callers/tests may seed or use a custom source
 // if deterministic behavior is required.
@@ -35,8 +41,22 @@ func (d *Deck) Shuffle() {
 	})
 }
 
+// ShuffleWith shuffles the remaining cards using the provided source so that
+// simulations can be reproduced from a seed.
+func (d *Deck) ShuffleWith(r *rand.Rand) {
+	r.Shuffle(len(d.cards), func(i, j int) {
+		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
+	})
+}
+
 func (d *Deck) Len() int { return len(d.cards) }
 
+// Cards returns a copy of the remaining cards in dealing order. Together with FromCards
+// it records a shuffle so the same deal can be replayed, e.g. with players in other seats.
+func (d *Deck) Cards() []cards.Card {
+	return append([]cards.Card(nil), d.cards...)
+}
+
 // Deal removes and returns the next n cards from the deck (top of the deck).
 // Returns an error for negative n or if there aren't enough cards remaining.
 func (d *Deck) Deal(n int) ([]cards.Card, error) {
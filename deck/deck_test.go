@@ -1,6 +1,7 @@
 package deck
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -161,6 +162,18 @@ func TestShuffle(t *testing.T) {
 	assert.True(t, different, "shuffle should change card order (may fail with negligible probability)")
 }
 
+func TestShuffleWithSeedIsReproducible(t *testing.T) {
+	d1 := NewDeck()
+	d2 := NewDeck()
+	d1.ShuffleWith(rand.New(rand.NewSource(42)))
+	d2.ShuffleWith(rand.New(rand.NewSource(42)))
+	assert.Equal(t, d1.cards, d2.cards, "same seed should produce the same order")
+
+	d3 := NewDeck()
+	d3.ShuffleWith(rand.New(rand.NewSource(43)))
+	assert.NotEqual(t, d1.cards, d3.cards, "different seeds should produce different orders")
+}
+
 func TestRemoveCards(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -289,3 +302,34 @@ func TestIntegrationDealAndRemove(t *testing.T) {
 	expectedLen := 47 - removed
 	assert.Equal(t, expectedLen, d.Len())
 }
+
+func TestFromCards(t *testing.T) {
+	cs := []cards.Card{cards.NewCard(cards.Spades, cards.Ace), cards.NewCard(cards.Hearts, cards.Two)}
+	d := FromCards(cs)
+	cs[0] = cards.NewCard(cards.Clubs, cards.Three) // the deck must not alias the caller's slice
+
+	assert.Equal(t, 2, d.Len())
+	got, err := d.Deal(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []cards.Card{cards.NewCard(cards.Spades, cards.Ace), cards.NewCard(cards.Hearts, cards.Two)}, got)
+}
+
+func TestCardsReplaysDeal(t *testing.T) {
+	d := NewDeck()
+	d.ShuffleWith(rand.New(rand.NewSource(9)))
+	_, err := d.Deal(2)
+	assert.NoError(t, err)
+	order := d.Cards()
+	assert.Len(t, order, 50)
+
+	// changing the copy does not change the deck
+	saved := append([]cards.Card(nil), order...)
+	order[0], order[1] = order[1], order[0]
+	first, err := d.Deal(5)
+	assert.NoError(t, err)
+	assert.Equal(t, saved[:5], first)
+
+	again, err := FromCards(saved).Deal(5)
+	assert.NoError(t, err)
+	assert.Equal(t, first, again)
+}
@@ -0,0 +1,186 @@
+// Package equity computes Hold'em all-in equity between ranges of starting hands.
+//
+// Equities are estimated by Monte Carlo: each trial draws one combo per player from
+// its range, rejects deals where players share a card, and runs out the board from
+// the remaining deck. Rejecting whole deals (rather than fixing the hero combo first)
+// keeps the joint distribution of hands correct under card removal.
+package equity
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/deck"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// DefaultTrials is used when Options.Trials is zero.
+const DefaultTrials = 10000
+
+// maxRejections bounds consecutive rejected deals so contradictory ranges
+// (e.g. "AA" against "AA" against "AA") fail fast instead of spinning.
+const maxRejections = 10000
+
+// ErrNoValidDeal is returned when the ranges, board and dead cards leave no way to
+// give every player a hand.
+var ErrNoValidDeal = errors.New("no valid deal for the given ranges and cards")
+
+// Options control an equity calculation.
+type Options struct {
+	Board  []cards.Card // 0-5 known community cards
+	Dead   []cards.Card // cards known to be out of play (e.g. folded or exposed)
+	Trials int          // number of Monte Carlo deals; DefaultTrials if zero
+	Seed   int64        // random seed for reproducible results; time-based if zero
+}
+
+// Result holds per-player outcomes in the order players were given (hero first).
+// Equity counts a tie split k ways as 1/k of a win, so equities sum to 1. Trials is the
+// number of deals sampled, or of runouts dealt by Enumerate.
+type Result struct {
+	Equity []float64 `json:"equity"`
+	Win    []float64 `json:"win"`
+	Tie    []float64 `json:"tie"`
+	Trials int       `json:"trials"`
+}
+
+// Compute estimates the equity of hero against one or more villain ranges.
+func Compute(hero Range, villains []Range, opts Options) (Result, error) {
+	if len(villains) == 0 {
+		return Result{}, fmt.Errorf("at least one villain range is required")
+	}
+	ranges := append([]Range{hero}, villains...)
+	return compute(ranges, opts)
+}
+
+func compute(ranges []Range, opts Options) (Result, error) {
+	if len(opts.Board) > 5 {
+		return Result{}, fmt.Errorf("board has %d cards, at most 5 allowed", len(opts.Board))
+	}
+	known := append(append([]cards.Card(nil), opts.Board...), opts.Dead...)
+	if err := checkDistinct(known); err != nil {
+		return Result{}, err
+	}
+	if 2*len(ranges)+5+len(opts.Dead) > 52 {
+		return Result{}, fmt.Errorf("too many players for one deck: %d", len(ranges))
+	}
+
+	live := make([]Range, len(ranges))
+	for i, r := range ranges {
+		live[i] = r.Without(known)
+		if len(live[i]) == 0 {
+			return Result{}, fmt.Errorf("player %d: %w", i+1, ErrNoValidDeal)
+		}
+	}
+
+	trials := opts.Trials
+	if trials <= 0 {
+		trials = DefaultTrials
+	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	n := len(live)
+	res := Result{
+		Equity: make([]float64, n),
+		Win:    make([]float64, n),
+		Tie:    make([]float64, n),
+		Trials: trials,
+	}
+	combos := make([]Combo, n)
+	evals := make([]hand.EvaluatedHand, n)
+	used := make(map[cards.Card]bool, 2*n)
+	seven := make([]cards.Card, 0, 7)
+
+	for t := 0; t < trials; t++ {
+		if err := sampleCombos(rng, live, combos, used); err != nil {
+			return Result{}, err
+		}
+
+		d := deck.NewDeck()
+		d.RemoveCards(known)
+		for _, c := range combos {
+			d.RemoveCards(c[:])
+		}
+		d.ShuffleWith(rng)
+		runout, err := d.Deal(5 - len(opts.Board))
+		if err != nil {
+			return Result{}, err
+		}
+
+		for i, c := range combos {
+			seven = append(seven[:0], c[0], c[1])
+			seven = append(seven, opts.Board...)
+			seven = append(seven, runout...)
+			_, evals[i] = hand.BestHand(seven)
+		}
+		winners := bestIndexes(evals)
+		share := 1 / float64(len(winners))
+		for _, w := range winners {
+			res.Equity[w] += share
+			if len(winners) == 1 {
+				res.Win[w]++
+			} else {
+				res.Tie[w]++
+			}
+		}
+	}
+
+	for i := range res.Equity {
+		res.Equity[i] /= float64(trials)
+		res.Win[i] /= float64(trials)
+		res.Tie[i] /= float64(trials)
+	}
+	return res, nil
+}
+
+// sampleCombos fills combos with one non-overlapping combo per player.
+func sampleCombos(rng *rand.Rand, live []Range, combos []Combo, used map[cards.Card]bool) error {
+	for attempt := 0; attempt < maxRejections; attempt++ {
+		clear(used)
+		ok := true
+		for i, r := range live {
+			c := r[rng.Intn(len(r))]
+			if c.conflicts(used) {
+				ok = false
+				break
+			}
+			used[c[0]], used[c[1]] = true, true
+			combos[i] = c
+		}
+		if ok {
+			return nil
+		}
+	}
+	return ErrNoValidDeal
+}
+
+// bestIndexes returns the indexes of the strongest hands (several on a tie).
+func bestIndexes(evals []hand.EvaluatedHand) []int {
+	best := []int{0}
+	for i := 1; i < len(evals); i++ {
+		switch cmp := hand.Compare(evals[i], evals[best[0]]); {
+		case cmp > 0:
+			best = []int{i}
+		case cmp == 0:
+			best = append(best, i)
+		}
+	}
+	return best
+}
+
+func checkDistinct(cs []cards.Card) error {
+	seen := make(map[cards.Card]bool, len(cs))
+	for _, c := range cs {
+		if seen[c] {
+			return fmt.Errorf("card %s appears more than once", c)
+		}
+		seen[c] = true
+	}
+	return nil
+}
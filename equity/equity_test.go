@@ -0,0 +1,114 @@
+package equity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/cards"
+)
+
+func mustRange(t *testing.T, s string) Range {
+	t.Helper()
+	r, err := ParseRange(s)
+	require.NoError(t, err)
+	return r
+}
+
+func mustCards(t *testing.T, s string) []cards.Card {
+	t.Helper()
+	cs, err := cards.ParseList(s)
+	require.NoError(t, err)
+	return cs
+}
+
+func TestComputeKnownMatchups(t *testing.T) {
+	tests := []struct {
+		name    string
+		hero    string
+		villain string
+		board   string
+		want    float64
+		delta   float64
+	}{
+		// well-known preflop numbers; tolerance covers Monte Carlo noise at 4000 trials
+		{"aces vs kings", "AsAh", "KsKh", "", 0.82, 0.03},
+		{"pair vs overcards", "2s2h", "AcKd", "", 0.52, 0.03},
+		{"made flush on the river", "AhKh", "QsQc", "2h7h9h3c4d", 1.0, 0},
+		{"board plays", "2c3d", "4c5d", "AsKsQsJsTs", 0.5, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Compute(mustRange(t, tc.hero), []Range{mustRange(t, tc.villain)}, Options{
+				Board:  mustCards(t, tc.board),
+				Trials: 4000,
+				Seed:   1,
+			})
+			require.NoError(t, err)
+			assert.InDelta(t, tc.want, res.Equity[0], tc.delta)
+			assert.InDelta(t, 1.0, res.Equity[0]+res.Equity[1], 1e-9, "equities should sum to 1")
+		})
+	}
+}
+
+func TestComputeCardRemoval(t *testing.T) {
+	// With the As dead, villain's AA range has only three combos left, all of which
+	// conflict with hero's Ah half the time; the result must still be a valid deal.
+	res, err := Compute(mustRange(t, "AhKh"), []Range{mustRange(t, "AA")}, Options{
+		Dead:   mustCards(t, "As"),
+		Trials: 500,
+		Seed:   7,
+	})
+	require.NoError(t, err)
+	assert.Greater(t, res.Equity[1], res.Equity[0])
+}
+
+func TestComputeMultiway(t *testing.T) {
+	res, err := Compute(mustRange(t, "QQ+"), []Range{mustRange(t, "random"), mustRange(t, "random")}, Options{Trials: 1000, Seed: 3})
+	require.NoError(t, err)
+	require.Len(t, res.Equity, 3)
+	assert.InDelta(t, 1.0, res.Equity[0]+res.Equity[1]+res.Equity[2], 1e-9)
+	assert.Greater(t, res.Equity[0], 0.5)
+}
+
+func TestComputeReproducible(t *testing.T) {
+	opts := Options{Trials: 300, Seed: 42}
+	a, err := Compute(mustRange(t, "AK"), []Range{mustRange(t, "22+")}, opts)
+	require.NoError(t, err)
+	b, err := Compute(mustRange(t, "AK"), []Range{mustRange(t, "22+")}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+}
+
+func TestComputeErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		hero     string
+		villains []string
+		opts     Options
+		noDeal   bool
+	}{
+		{name: "no villains", hero: "AA"},
+		{name: "board too long", hero: "AA", villains: []string{"KK"}, opts: Options{Board: mustCards(t, "2c3c4c5c6c7c")}},
+		{name: "board and dead overlap", hero: "AA", villains: []string{"KK"}, opts: Options{Board: mustCards(t, "2c"), Dead: mustCards(t, "2c")}},
+		{name: "range fully blocked", hero: "AsAh", villains: []string{"KK"}, opts: Options{Dead: mustCards(t, "Ks Kh Kd")}, noDeal: true},
+		{name: "ranges collide", hero: "AsAh", villains: []string{"AsKs"}, noDeal: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var villains []Range
+			for _, v := range tc.villains {
+				villains = append(villains, mustRange(t, v))
+			}
+			tc.opts.Trials = 10
+			_, err := Compute(mustRange(t, tc.hero), villains, tc.opts)
+			assert.Error(t, err)
+			if tc.noDeal {
+				assert.ErrorIs(t, err, ErrNoValidDeal)
+			}
+		})
+	}
+}
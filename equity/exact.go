@@ -0,0 +1,91 @@
+package equity
+
+import (
+	"fmt"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/deck"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// Runouts is the number of distinct ways to complete the board when players hold known
+// cards: the cost of Enumerate. Preflop heads-up it is C(48,5) = 1,712,304.
+func Runouts(players int, board, dead []cards.Card) int {
+	n := 52 - 2*players - len(board) - len(dead)
+	k := 5 - len(board)
+	if n < k || k < 0 {
+		return 0
+	}
+	c := 1
+	for i := 0; i < k; i++ {
+		c = c * (n - i) / (i + 1)
+	}
+	return c
+}
+
+// Enumerate computes the exact equity of known hands by dealing every possible runout
+// of the board. Result.Trials is the number of runouts. Use Runouts to check the cost
+// first; Compute with single-combo ranges samples instead.
+func Enumerate(hands []Combo, board, dead []cards.Card) (Result, error) {
+	if len(hands) < 2 {
+		return Result{}, fmt.Errorf("at least two hands are required")
+	}
+	if len(board) > 5 {
+		return Result{}, fmt.Errorf("board has %d cards, at most 5 allowed", len(board))
+	}
+	known := append(append([]cards.Card(nil), board...), dead...)
+	for _, h := range hands {
+		known = append(known, h[0], h[1])
+	}
+	if err := checkDistinct(known); err != nil {
+		return Result{}, err
+	}
+	if Runouts(len(hands), board, dead) == 0 {
+		return Result{}, fmt.Errorf("not enough cards left to complete the board")
+	}
+
+	d := deck.NewDeck()
+	d.RemoveCards(known)
+	rest := d.Cards()
+	n := len(hands)
+	res := Result{Equity: make([]float64, n), Win: make([]float64, n), Tie: make([]float64, n)}
+	evals := make([]hand.EvaluatedHand, n)
+	seven := make([]cards.Card, 0, 7)
+	runout := make([]cards.Card, 5-len(board))
+
+	var deal func(start, depth int)
+	deal = func(start, depth int) {
+		if depth == len(runout) {
+			for i, h := range hands {
+				seven = append(seven[:0], h[0], h[1])
+				seven = append(seven, board...)
+				seven = append(seven, runout...)
+				_, evals[i] = hand.BestHand(seven)
+			}
+			winners := bestIndexes(evals)
+			share := 1 / float64(len(winners))
+			for _, w := range winners {
+				res.Equity[w] += share
+				if len(winners) == 1 {
+					res.Win[w]++
+				} else {
+					res.Tie[w]++
+				}
+			}
+			res.Trials++
+			return
+		}
+		for i := start; i <= len(rest)-(len(runout)-depth); i++ {
+			runout[depth] = rest[i]
+			deal(i+1, depth+1)
+		}
+	}
+	deal(0, 0)
+
+	for i := range res.Equity {
+		res.Equity[i] /= float64(res.Trials)
+		res.Win[i] /= float64(res.Trials)
+		res.Tie[i] /= float64(res.Trials)
+	}
+	return res, nil
+}
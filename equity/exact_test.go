@@ -0,0 +1,72 @@
+package equity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/cards"
+)
+
+func combo(t *testing.T, s string) Combo {
+	t.Helper()
+	cs, err := cards.ParseList(s)
+	require.NoError(t, err)
+	require.Len(t, cs, 2)
+	return Combo{cs[0], cs[1]}
+}
+
+func board(t *testing.T, s string) []cards.Card {
+	t.Helper()
+	cs, err := cards.ParseList(s)
+	require.NoError(t, err)
+	return cs
+}
+
+func TestRunouts(t *testing.T) {
+	assert.Equal(t, 1712304, Runouts(2, nil, nil))
+	assert.Equal(t, 990, Runouts(2, board(t, "2c 7d Ks"), nil))
+	assert.Equal(t, 44, Runouts(2, board(t, "2c 7d Ks 9h"), nil))
+	assert.Equal(t, 1, Runouts(2, board(t, "2c 7d Ks 9h 3s"), nil))
+	assert.Equal(t, 43, Runouts(2, board(t, "2c 7d Ks 9h"), board(t, "As")))
+}
+
+func TestEnumerateFlushDraw(t *testing.T) {
+	// a nut flush draw against top pair on the turn: 9 flush outs win, the rest lose
+	res, err := Enumerate([]Combo{combo(t, "Ah Qh"), combo(t, "Ks Jd")}, board(t, "Kh 7h 2c 3s"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 44, res.Trials)
+	assert.InDelta(t, 12.0/44, res.Win[0], 1e-9, "9 hearts plus 3 aces")
+	assert.InDelta(t, 1, res.Equity[0]+res.Equity[1], 1e-9)
+	assert.Zero(t, res.Tie[0])
+}
+
+func TestEnumerateAgreesWithSampling(t *testing.T) {
+	hands := []Combo{combo(t, "As Ad"), combo(t, "Kc Kd"), combo(t, "7h 8h")}
+	flop := board(t, "2h 9h Ks")
+	exact, err := Enumerate(hands, flop, nil)
+	require.NoError(t, err)
+	sampled, err := compute([]Range{{hands[0]}, {hands[1]}, {hands[2]}}, Options{Board: flop, Trials: 20000, Seed: 1})
+	require.NoError(t, err)
+	for i := range hands {
+		assert.InDelta(t, exact.Equity[i], sampled.Equity[i], 0.015)
+	}
+}
+
+func TestEnumerateSplitPot(t *testing.T) {
+	res, err := Enumerate([]Combo{combo(t, "2c 3d"), combo(t, "2h 3s")}, board(t, "Ah Kh Qh Jh Th"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Trials)
+	assert.Equal(t, []float64{0.5, 0.5}, res.Equity)
+	assert.Equal(t, []float64{1, 1}, res.Tie)
+}
+
+func TestEnumerateErrors(t *testing.T) {
+	_, err := Enumerate([]Combo{combo(t, "As Ad")}, nil, nil)
+	assert.Error(t, err)
+	_, err = Enumerate([]Combo{combo(t, "As Ad"), combo(t, "As Kd")}, nil, nil)
+	assert.ErrorContains(t, err, "more than once")
+	_, err = Enumerate([]Combo{combo(t, "As Ad"), combo(t, "Ks Kd")}, board(t, "2c 3c 4c 5c 6c 7c"), nil)
+	assert.ErrorContains(t, err, "at most 5")
+}
@@ -0,0 +1,130 @@
+package equity
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dangogh/GoPoker/cards"
+)
+
+// gridRanks orders rows and columns the way starting-hand charts are drawn: Ace first.
+var gridRanks = []cards.Rank{
+	cards.Ace, cards.King, cards.Queen, cards.Jack, cards.Ten, cards.Nine, cards.Eight,
+	cards.Seven, cards.Six, cards.Five, cards.Four, cards.Three, cards.Two,
+}
+
+// GridClass returns the class at a position in the 13×13 chart. Pairs sit on the
+// diagonal, suited hands above it and offsuit hands below it.
+func GridClass(row, col int) Class {
+	r1, r2 := gridRanks[row], gridRanks[col]
+	switch {
+	case row == col:
+		return Class{High: r1, Low: r1}
+	case row < col:
+		return Class{High: r1, Low: r2, Suited: true}
+	default:
+		return Class{High: r2, Low: r1}
+	}
+}
+
+// Cell is one starting-hand class in a Grid.
+type Cell struct {
+	Class  string  `json:"class"`
+	Combos int     `json:"combos"` // combos left after the board and dead cards; 0 means the class is impossible
+	Equity float64 `json:"equity"`
+	// NoDeal is set when the class has combos but none can be dealt alongside the villain
+	// ranges, so Equity is unknown rather than zero.
+	NoDeal bool `json:"no_deal,omitempty"`
+}
+
+// Grid is a 13×13 heatmap of each starting-hand class's equity against the villain ranges.
+type Grid struct {
+	Ranks  []string     `json:"ranks"`
+	Cells  [13][13]Cell `json:"cells"`
+	Trials int          `json:"trials_per_cell"`
+	Board  []string     `json:"board,omitempty"`
+}
+
+// Heatmap computes the equity of every starting-hand class against the villain ranges.
+// Each cell runs its own simulation with opts.Trials deals, seeded from opts.Seed so the
+// whole grid is reproducible.
+func Heatmap(villains []Range, opts Options) (*Grid, error) {
+	if len(villains) == 0 {
+		return nil, fmt.Errorf("at least one villain range is required")
+	}
+	if opts.Trials <= 0 {
+		opts.Trials = DefaultTrials
+	}
+	g := &Grid{Trials: opts.Trials}
+	for _, r := range gridRanks {
+		g.Ranks = append(g.Ranks, rankChars[r])
+	}
+	for _, c := range opts.Board {
+		g.Board = append(g.Board, c.String())
+	}
+
+	known := append(append([]cards.Card(nil), opts.Board...), opts.Dead...)
+	base := opts.Seed
+	for row := range gridRanks {
+		for col := range gridRanks {
+			class := GridClass(row, col)
+			hero := Range(class.Combos())
+			cell := Cell{Class: class.String(), Combos: len(hero.Without(known))}
+
+			cellOpts := opts
+			if base != 0 {
+				cellOpts.Seed = base + int64(row*13+col)
+			}
+			res, err := Compute(hero, villains, cellOpts)
+			switch {
+			case errors.Is(err, ErrNoValidDeal):
+				cell.NoDeal = true
+			case err != nil:
+				return nil, fmt.Errorf("%s: %w", cell.Class, err)
+			default:
+				cell.Equity = res.Equity[0]
+			}
+			g.Cells[row][col] = cell
+		}
+	}
+	return g, nil
+}
+
+// WriteText renders the grid as a table of equity percentages. Classes without an
+// equity (blocked by the board, dead cards or the villain ranges) are shown as "-".
+func (g *Grid) WriteText(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "%3s", ""); err != nil {
+		return err
+	}
+	for _, r := range g.Ranks {
+		if _, err := fmt.Fprintf(w, "%6s", r); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+	for row, r := range g.Ranks {
+		if _, err := fmt.Fprintf(w, "%3s", r); err != nil {
+			return err
+		}
+		for col := range g.Ranks {
+			cell := g.Cells[row][col]
+			var err error
+			if cell.Combos == 0 || cell.NoDeal {
+				_, err = fmt.Fprintf(w, "%6s", "-")
+			} else {
+				_, err = fmt.Fprintf(w, "%6.1f", 100*cell.Equity)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "(suited above the diagonal, offsuit below)")
+	return err
+}
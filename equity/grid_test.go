@@ -0,0 +1,52 @@
+package equity
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGridClassLayout(t *testing.T) {
+	assert.Equal(t, "AA", GridClass(0, 0).String())
+	assert.Equal(t, "AKs", GridClass(0, 1).String())
+	assert.Equal(t, "AKo", GridClass(1, 0).String())
+	assert.Equal(t, "32o", GridClass(12, 11).String())
+	assert.Equal(t, "22", GridClass(12, 12).String())
+}
+
+func TestHeatmap(t *testing.T) {
+	g, err := Heatmap([]Range{mustRange(t, "KK")}, Options{
+		Board:  mustCards(t, "Ks"),
+		Trials: 20,
+		Seed:   5,
+	})
+	require.NoError(t, err)
+
+	// the king on the board leaves villain three KK combos; every hero class
+	// containing a king loses combos to card removal
+	assert.Equal(t, "AA", g.Cells[0][0].Class)
+	assert.Equal(t, 6, g.Cells[0][0].Combos)
+	assert.Less(t, g.Cells[0][0].Equity, 0.5, "villain always holds trip kings")
+	assert.False(t, g.Cells[0][0].NoDeal)
+	assert.Equal(t, 3, g.Cells[1][1].Combos, "KK: three combos survive the board")
+	assert.True(t, g.Cells[1][1].NoDeal, "KK: hero and villain cannot both hold two of the last three kings")
+	assert.Zero(t, g.Cells[1][1].Equity)
+	assert.Equal(t, 3, g.Cells[0][1].Combos, "AKs: the spade combo is blocked by the board")
+
+	var buf bytes.Buffer
+	require.NoError(t, g.WriteText(&buf))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 15, "header, 13 rows and a legend")
+	assert.True(t, strings.HasPrefix(strings.TrimSpace(lines[2]), "K"))
+	assert.Contains(t, lines[2], "-", "KK is impossible once villain holds the last kings")
+
+	data, err := json.Marshal(g)
+	require.NoError(t, err)
+	var decoded Grid
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, g.Cells, decoded.Cells)
+}
@@ -0,0 +1,268 @@
+package equity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dangogh/GoPoker/cards"
+)
+
+// Combo is a concrete two-card Hold'em starting hand.
+type Combo [2]cards.Card
+
+func (c Combo) String() string { return c[0].String() + c[1].String() }
+
+// conflicts reports whether the combo shares a card with any card in used.
+func (c Combo) conflicts(used map[cards.Card]bool) bool {
+	return used[c[0]] || used[c[1]]
+}
+
+// Class is one of the 169 starting-hand classes: a pocket pair, or two distinct
+// ranks that are either suited or offsuit. High is always the larger rank.
+type Class struct {
+	High   cards.Rank
+	Low    cards.Rank
+	Suited bool
+}
+
+// rankChars uses "T" for ten because range notation needs one character per rank.
+var rankChars = map[cards.Rank]string{
+	cards.Two: "2", cards.Three: "3", cards.Four: "4", cards.Five: "5", cards.Six: "6",
+	cards.Seven: "7", cards.Eight: "8", cards.Nine: "9", cards.Ten: "T",
+	cards.Jack: "J", cards.Queen: "Q", cards.King: "K", cards.Ace: "A",
+}
+
+// String returns the conventional label, e.g. "QQ", "AKs" or "T9o".
+func (c Class) String() string {
+	s := rankChars[c.High] + rankChars[c.Low]
+	switch {
+	case c.High == c.Low:
+		return s
+	case c.Suited:
+		return s + "s"
+	default:
+		return s + "o"
+	}
+}
+
+// Combos expands the class into its concrete combos: 6 for a pair, 4 suited, 12 offsuit.
+func (c Class) Combos() []Combo {
+	var out []Combo
+	for s1 := cards.Clubs; s1 <= cards.Spades; s1++ {
+		for s2 := cards.Clubs; s2 <= cards.Spades; s2++ {
+			switch {
+			case c.High == c.Low:
+				if s2 <= s1 {
+					continue
+				}
+			case c.Suited:
+				if s1 != s2 {
+					continue
+				}
+			default:
+				if s1 == s2 {
+					continue
+				}
+			}
+			out = append(out, Combo{cards.NewCard(s1, c.High), cards.NewCard(s2, c.Low)})
+		}
+	}
+	return out
+}
+
+// ClassOf returns the starting-hand class a combo belongs to.
+func ClassOf(c Combo) Class {
+	hi, lo := c[0], c[1]
+	if lo.Rank > hi.Rank {
+		hi, lo = lo, hi
+	}
+	return Class{High: hi.Rank, Low: lo.Rank, Suited: hi.Rank != lo.Rank && hi.Suit == lo.Suit}
+}
+
+// Range is the set of combos a player may hold, each considered equally likely.
+type Range []Combo
+
+// Without returns the combos that do not use any of the given cards. This is the
+// card-removal effect: a known board or dead card makes combos containing it impossible.
+func (r Range) Without(cs []cards.Card) Range {
+	used := make(map[cards.Card]bool, len(cs))
+	for _, c := range cs {
+		used[c] = true
+	}
+	out := make(Range, 0, len(r))
+	for _, combo := range r {
+		if !combo.conflicts(used) {
+			out = append(out, combo)
+		}
+	}
+	return out
+}
+
+// ParseRange parses a comma-separated range in standard notation:
+//
+//	QQ       a pocket pair
+//	AKs AKo  suited or offsuit classes; "AK" means both
+//	TT+ ATs+ the class and every stronger one with the same high card
+//	A5s-A2s  a span of kickers (or of pairs, e.g. "22-55")
+//	AsKs     one specific combo
+//	random   every two-card combination
+//
+// Duplicate combos produced by overlapping tokens are collapsed.
+func ParseRange(s string) (Range, error) {
+	var out Range
+	seen := map[Combo]bool{}
+	add := func(cs []Combo) {
+		for _, c := range cs {
+			// normalise so "KsAs" and "AsKs" are the same combo
+			if c[1].Rank > c[0].Rank || (c[1].Rank == c[0].Rank && c[1].Suit < c[0].Suit) {
+				c[0], c[1] = c[1], c[0]
+			}
+			if !seen[c] {
+				seen[c] = true
+				out = append(out, c)
+			}
+		}
+	}
+
+	for _, tok := range strings.Split(s, ",") {
+		tok = strings.TrimSpace(tok)
+		if tok == "" {
+			continue
+		}
+		combos, err := parseToken(tok)
+		if err != nil {
+			return nil, err
+		}
+		add(combos)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("empty range %q", s)
+	}
+	return out, nil
+}
+
+func parseToken(tok string) ([]Combo, error) {
+	if strings.EqualFold(tok, "random") || strings.EqualFold(tok, "any") {
+		return randomCombos(), nil
+	}
+	if cs, err := cards.ParseList(tok); err == nil && len(cs) == 2 {
+		return []Combo{{cs[0], cs[1]}}, nil
+	}
+
+	if lo, hi, ok := strings.Cut(tok, "-"); ok {
+		a, err := parseClasses(lo)
+		if err != nil {
+			return nil, err
+		}
+		b, err := parseClasses(hi)
+		if err != nil {
+			return nil, err
+		}
+		return spanCombos(tok, a, b)
+	}
+
+	plus := strings.HasSuffix(tok, "+")
+	classes, err := parseClasses(strings.TrimSuffix(tok, "+"))
+	if err != nil {
+		return nil, err
+	}
+	var out []Combo
+	for _, c := range classes {
+		if !plus {
+			out = append(out, c.Combos()...)
+			continue
+		}
+		if c.High == c.Low {
+			for r := c.Low; r <= cards.Ace; r++ {
+				out = append(out, Class{High: r, Low: r}.Combos()...)
+			}
+			continue
+		}
+		for r := c.Low; r < c.High; r++ {
+			out = append(out, Class{High: c.High, Low: r, Suited: c.Suited}.Combos()...)
+		}
+	}
+	return out, nil
+}
+
+// parseClasses parses "AKs", "AKo", "QQ" or "AK" (both suited and offsuit).
+func parseClasses(s string) ([]Class, error) {
+	if len(s) < 2 || len(s) > 3 {
+		return nil, fmt.Errorf("invalid range token %q", s)
+	}
+	r1, err := cards.ParseRank(s[:1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid range token %q: %w", s, err)
+	}
+	r2, err := cards.ParseRank(s[1:2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid range token %q: %w", s, err)
+	}
+	if r2 > r1 {
+		r1, r2 = r2, r1
+	}
+	if len(s) == 2 {
+		if r1 == r2 {
+			return []Class{{High: r1, Low: r2}}, nil
+		}
+		return []Class{{High: r1, Low: r2, Suited: true}, {High: r1, Low: r2}}, nil
+	}
+	if r1 == r2 {
+		return nil, fmt.Errorf("invalid range token %q: pairs cannot be suited or offsuit", s)
+	}
+	switch strings.ToLower(s[2:]) {
+	case "s":
+		return []Class{{High: r1, Low: r2, Suited: true}}, nil
+	case "o":
+		return []Class{{High: r1, Low: r2}}, nil
+	default:
+		return nil, fmt.Errorf("invalid range token %q: suffix must be s or o", s)
+	}
+}
+
+// spanCombos expands "A5s-A2s" or "22-55": both ends must share the high card (or both be
+// pairs) and suitedness, so the span is unambiguous.
+func spanCombos(tok string, a, b []Class) ([]Combo, error) {
+	if len(a) != len(b) {
+		return nil, fmt.Errorf("invalid range span %q", tok)
+	}
+	var out []Combo
+	for i := range a {
+		from, to := a[i], b[i]
+		if from.Suited != to.Suited {
+			return nil, fmt.Errorf("invalid range span %q: mixed suitedness", tok)
+		}
+		pairs := from.High == from.Low
+		if pairs != (to.High == to.Low) || (!pairs && from.High != to.High) {
+			return nil, fmt.Errorf("invalid range span %q: ends must be pairs or share a high card", tok)
+		}
+		lo, hi := from.Low, to.Low
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		for r := lo; r <= hi; r++ {
+			c := Class{High: from.High, Low: r, Suited: from.Suited}
+			if pairs {
+				c.High = r
+			}
+			out = append(out, c.Combos()...)
+		}
+	}
+	return out, nil
+}
+
+func randomCombos() []Combo {
+	all := make([]cards.Card, 0, 52)
+	for s := cards.Clubs; s <= cards.Spades; s++ {
+		for r := cards.Two; r <= cards.Ace; r++ {
+			all = append(all, cards.NewCard(s, r))
+		}
+	}
+	out := make([]Combo, 0, 1326)
+	for i := range all {
+		for j := i + 1; j < len(all); j++ {
+			out = append(out, Combo{all[i], all[j]})
+		}
+	}
+	return out
+}
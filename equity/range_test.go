@@ -0,0 +1,74 @@
+package equity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/cards"
+)
+
+func TestParseRangeCounts(t *testing.T) {
+	tests := []struct {
+		input  string
+		combos int
+	}{
+		{"AA", 6},
+		{"AKs", 4},
+		{"AKo", 12},
+		{"AK", 16},
+		{"KA", 16},
+		{"QQ+", 18},
+		{"ATs+", 16},
+		{"A5s-A2s", 16},
+		{"22-44", 18},
+		{"AsKs", 1},
+		{"KsAs, AKs", 4},
+		{"AA, KK, AKs", 16},
+		{"random", 1326},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			r, err := ParseRange(tc.input)
+			require.NoError(t, err)
+			assert.Len(t, r, tc.combos)
+		})
+	}
+}
+
+func TestParseRangeErrors(t *testing.T) {
+	for _, input := range []string{"", "AAs", "AXs", "AKx", "A5s-K2s", "A5s-A2o", "22-A5s", "AKQJ"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseRange(input)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestClassString(t *testing.T) {
+	assert.Equal(t, "QQ", Class{High: cards.Queen, Low: cards.Queen}.String())
+	assert.Equal(t, "AKs", Class{High: cards.Ace, Low: cards.King, Suited: true}.String())
+	assert.Equal(t, "T9o", Class{High: cards.Ten, Low: cards.Nine}.String())
+}
+
+func TestClassOfRoundTrip(t *testing.T) {
+	for _, c := range []Class{
+		{High: cards.Seven, Low: cards.Seven},
+		{High: cards.Ace, Low: cards.Two, Suited: true},
+		{High: cards.King, Low: cards.Jack},
+	} {
+		for _, combo := range c.Combos() {
+			assert.Equal(t, c, ClassOf(combo), "combo %s", combo)
+		}
+	}
+}
+
+func TestRangeWithout(t *testing.T) {
+	r, err := ParseRange("AA")
+	require.NoError(t, err)
+	as, err := cards.Parse("As")
+	require.NoError(t, err)
+	assert.Len(t, r.Without([]cards.Card{as}), 3, "removing one ace leaves 3 of 6 combos")
+}
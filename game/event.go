@@ -0,0 +1,95 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// EventKind identifies what happened during a hand.
+type EventKind int
+
+const (
+	EventAnte EventKind = iota
+	EventBlind
+	EventDeal // private: Cards holds the seat's hand
+	EventAction
+	EventDraw // private: Cards holds the seat's new hand; Amount is the number drawn
+	EventShow
+	EventWin
+)
+
+// Event is a step of a hand, reported to Config.Observer. Deal and draw events carry the
+// seat's private cards; String only ever renders the public part, so it is safe to show
+// to every player.
+type Event struct {
+	Kind   EventKind
+	Seat   int
+	Name   string
+	Action Action // EventAction
+	Amount int    // chips posted, bet total, cards drawn or chips won
+	AllIn  bool
+	Cards  []cards.Card
+	Eval   hand.EvaluatedHand // EventShow, EventWin at showdown
+	Pot    int                // EventWin: index of the pot (0 = main pot)
+}
+
+// Public returns the event as every player sees it: deal and draw events lose their cards.
+func (e Event) Public() Event {
+	if e.Kind == EventDeal || e.Kind == EventDraw {
+		e.Cards = nil
+	}
+	return e
+}
+
+// String describes the public part of the event, e.g. "Bob raises to 40".
+func (e Event) String() string {
+	allIn := ""
+	if e.AllIn {
+		allIn = " (all-in)"
+	}
+	switch e.Kind {
+	case EventAnte:
+		return fmt.Sprintf("%s antes %d%s", e.Name, e.Amount, allIn)
+	case EventBlind:
+		return fmt.Sprintf("%s posts blind %d", e.Name, e.Amount)
+	case EventDeal:
+		return e.Name + " is dealt in"
+	case EventAction:
+		switch e.Action.Kind {
+		case Bet:
+			return fmt.Sprintf("%s bets %d%s", e.Name, e.Amount, allIn)
+		case Raise:
+			return fmt.Sprintf("%s raises to %d%s", e.Name, e.Amount, allIn)
+		case Call:
+			return fmt.Sprintf("%s calls%s", e.Name, allIn)
+		default:
+			return fmt.Sprintf("%s %ss", e.Name, e.Action.Kind)
+		}
+	case EventDraw:
+		if e.Amount == 0 {
+			return e.Name + " stands pat"
+		}
+		return fmt.Sprintf("%s draws %d", e.Name, e.Amount)
+	case EventShow:
+		return fmt.Sprintf("%s shows %s: %s", e.Name, cardList(e.Cards), hand.Describe(e.Eval))
+	case EventWin:
+		pot := "the pot"
+		if e.Pot > 0 {
+			pot = fmt.Sprintf("side pot %d", e.Pot)
+		}
+		return fmt.Sprintf("%s wins %d from %s", e.Name, e.Amount, pot)
+	default:
+		return fmt.Sprintf("event %d", int(e.Kind))
+	}
+}
+
+func cardList(cs []cards.Card) string {
+	names := make([]string, len(cs))
+	for i, c := range cs {
+		names[i] = c.String()
+	}
+	return strings.Join(names, " ")
+}
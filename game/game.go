@@ -0,0 +1,588 @@
+// Package game plays single hands of no-limit five-card draw: antes and blinds, a
+// betting round, one draw, a second betting round and the showdown with side pots.
+//
+// The engine owns the rules; decisions come from Player implementations, so the same
+// code drives interactive play, bots and simulations.
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/deck"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// MaxSeats bounds a table so the deck (almost) always covers five cards each plus draws.
+// If it does run short, later players can only draw what is left.
+const MaxSeats = 8
+
+// ErrNotEnoughPlayers is returned when fewer than two seats have chips.
+var ErrNotEnoughPlayers = errors.New("at least two players with chips are required")
+
+// ActionKind is a betting decision.
+type ActionKind int
+
+const (
+	Fold ActionKind = iota
+	Check
+	Call
+	Bet
+	Raise
+)
+
+func (k ActionKind) String() string {
+	switch k {
+	case Fold:
+		return "fold"
+	case Check:
+		return "check"
+	case Call:
+		return "call"
+	case Bet:
+		return "bet"
+	case Raise:
+		return "raise"
+	default:
+		return fmt.Sprintf("ActionKind(%d)", int(k))
+	}
+}
+
+// Action is a betting decision. For Bet and Raise, Amount is the total the player's
+// bet for the round becomes ("raise to"); it is ignored otherwise.
+type Action struct {
+	Kind   ActionKind
+	Amount int
+}
+
+// Round identifies the betting round.
+type Round int
+
+const (
+	PreDraw Round = iota
+	PostDraw
+)
+
+func (r Round) String() string {
+	if r == PreDraw {
+		return "pre-draw"
+	}
+	return "post-draw"
+}
+
+// View is what a player may see when deciding: their own cards and public table state.
+type View struct {
+	Seat     int
+	Hand     []cards.Card
+	Round    Round
+	Pot      int   // chips committed by everyone so far, including this round's bets
+	ToCall   int   // chips needed to call
+	MinRaise int   // smallest legal "raise to" total; 0 if raising is not possible
+	Stack    int   // chips behind for this player
+	BigBlind int   // size of the big blind, the natural betting unit
+	Bets     []int // this round's bet per seat
+	Stacks   []int
+	Dealt    []bool // seats dealt into this hand
+	Folded   []bool
+	Drawn    []int // cards drawn per seat in the draw, -1 before the draw
+	Button   int
+	Names    []string
+}
+
+// Player makes the decisions for one seat. Errors abort the hand, which lets an
+// interactive player quit mid-hand.
+type Player interface {
+	// Act chooses a betting action. Illegal actions are coerced to the nearest legal
+	// one: checking into a bet folds, undersized raises become minimum raises and
+	// oversized ones go all-in.
+	Act(v View) (Action, error)
+	// Discard returns the indexes of v.Hand to throw away before drawing.
+	Discard(v View) ([]int, error)
+}
+
+// Watcher is implemented by players that want to follow the whole hand, e.g. to model
+// their opponents. They receive every event of hands they are dealt into, with other
+// players' private cards removed.
+type Watcher interface {
+	Observe(e Event)
+}
+
+// Seat is a player at the table. Seats with no chips sit the hand out.
+type Seat struct {
+	Name   string
+	Stack  int
+	Player Player
+}
+
+// Config sets the forced bets.
+type Config struct {
+	Ante       int
+	SmallBlind int
+	BigBlind   int
+	// Observer, if set, receives every public and private event as it happens.
+	Observer func(Event)
+}
+
+// Pot is a main or side pot and who won it.
+type Pot struct {
+	Amount   int   `json:"amount"`
+	Eligible []int `json:"eligible"`
+	Winners  []int `json:"winners"`
+}
+
+// Result describes a finished hand. Stacks on the seats are updated in place.
+type Result struct {
+	Hands    [][]cards.Card       // final cards per seat; nil for seats not dealt in
+	Evals    []hand.EvaluatedHand // final evaluations of hands that reached showdown
+	Folded   []bool
+	Showdown bool  // more than one player was still in at the end
+	Pots     []Pot // main pot first
+	Net      []int // chip change per seat
+}
+
+// Play deals and plays one hand. Button is the dealer seat; blinds are posted by the
+// next seats with chips (the button itself posts the small blind heads-up). If a
+// player returns an error the hand is abandoned and every stack is restored.
+func Play(d *deck.Deck, seats []*Seat, button int, cfg Config) (*Result, error) {
+	if len(seats) > MaxSeats {
+		return nil, fmt.Errorf("at most %d seats, got %d", MaxSeats, len(seats))
+	}
+	if cfg.BigBlind <= 0 && cfg.Ante <= 0 {
+		return nil, fmt.Errorf("a big blind or an ante is required")
+	}
+	g := newState(d, seats, button, cfg)
+	if g.countIn() < 2 {
+		return nil, ErrNotEnoughPlayers
+	}
+	if err := g.play(); err != nil {
+		for i, s := range seats {
+			s.Stack = g.start[i]
+		}
+		return nil, err
+	}
+	return g.result(), nil
+}
+
+// state is one hand in progress.
+type state struct {
+	d      *deck.Deck
+	seats  []*Seat
+	cfg    Config
+	button int
+
+	in      []bool // dealt into this hand
+	folded  []bool
+	hands   [][]cards.Card
+	bets    []int // current round
+	contrib []int // whole hand
+	start   []int // stacks before the hand
+	drawn   []int
+	round   Round
+
+	evals    []hand.EvaluatedHand
+	pots     []Pot
+	showdown bool
+}
+
+func newState(d *deck.Deck, seats []*Seat, button int, cfg Config) *state {
+	n := len(seats)
+	g := &state{
+		d: d, seats: seats, cfg: cfg, button: button,
+		in:      make([]bool, n),
+		folded:  make([]bool, n),
+		hands:   make([][]cards.Card, n),
+		bets:    make([]int, n),
+		contrib: make([]int, n),
+		start:   make([]int, n),
+		drawn:   make([]int, n),
+	}
+	for i, s := range seats {
+		g.in[i] = s.Stack > 0
+		g.start[i] = s.Stack
+		g.drawn[i] = -1
+	}
+	return g
+}
+
+func (g *state) emit(e Event) {
+	if e.Seat >= 0 && e.Seat < len(g.seats) {
+		e.Name = g.seats[e.Seat].Name
+	}
+	if g.cfg.Observer != nil {
+		g.cfg.Observer(e)
+	}
+	for i, s := range g.seats {
+		if w, ok := s.Player.(Watcher); ok && g.in[i] {
+			if i == e.Seat {
+				w.Observe(e)
+			} else {
+				w.Observe(e.Public())
+			}
+		}
+	}
+}
+
+func (g *state) countIn() int {
+	n := 0
+	for _, in := range g.in {
+		if in {
+			n++
+		}
+	}
+	return n
+}
+
+// next returns the next seat after i that is still in the hand and has not folded.
+func (g *state) next(i int) int {
+	n := len(g.seats)
+	for k := 1; k <= n; k++ {
+		j := (i + k) % n
+		if g.in[j] && !g.folded[j] {
+			return j
+		}
+	}
+	return i
+}
+
+func (g *state) live() int {
+	n := 0
+	for i := range g.seats {
+		if g.in[i] && !g.folded[i] {
+			n++
+		}
+	}
+	return n
+}
+
+// post moves up to amount from a seat's stack into the pot. Round bets only count
+// blinds, not antes, since antes are dead money that don't count toward calling.
+func (g *state) post(seat, amount int, live bool) int {
+	if amount > g.seats[seat].Stack {
+		amount = g.seats[seat].Stack
+	}
+	g.seats[seat].Stack -= amount
+	g.contrib[seat] += amount
+	if live {
+		g.bets[seat] += amount
+	}
+	return amount
+}
+
+func (g *state) play() error {
+	if g.cfg.Ante > 0 {
+		for i := range g.seats {
+			if g.in[i] {
+				g.emit(Event{Kind: EventAnte, Seat: i, Amount: g.post(i, g.cfg.Ante, false)})
+			}
+		}
+	}
+
+	// heads-up the button posts the small blind and acts first before the draw
+	sb := g.next(g.button)
+	if g.countIn() == 2 && g.in[g.button] {
+		sb = g.button
+	}
+	bb := g.next(sb)
+	if g.cfg.SmallBlind > 0 {
+		g.emit(Event{Kind: EventBlind, Seat: sb, Amount: g.post(sb, g.cfg.SmallBlind, true)})
+	}
+	if g.cfg.BigBlind > 0 {
+		g.emit(Event{Kind: EventBlind, Seat: bb, Amount: g.post(bb, g.cfg.BigBlind, true)})
+	}
+
+	// deal one card at a time, starting left of the button
+	var order []int
+	for i, k := g.next(g.button), 0; k < g.countIn(); i, k = g.next(i), k+1 {
+		order = append(order, i)
+	}
+	for k := 0; k < 5; k++ {
+		for _, i := range order {
+			c, err := g.d.Deal(1)
+			if err != nil {
+				return fmt.Errorf("deal: %w", err)
+			}
+			g.hands[i] = append(g.hands[i], c[0])
+		}
+	}
+	for i := range g.seats {
+		if g.in[i] {
+			g.emit(Event{Kind: EventDeal, Seat: i, Cards: append([]cards.Card(nil), g.hands[i]...)})
+		}
+	}
+
+	g.round = PreDraw
+	if err := g.betting(g.next(bb)); err != nil {
+		return err
+	}
+	if g.live() > 1 {
+		if err := g.drawPhase(); err != nil {
+			return err
+		}
+		g.round = PostDraw
+		for i := range g.bets {
+			g.bets[i] = 0
+		}
+		if err := g.betting(g.next(g.button)); err != nil {
+			return err
+		}
+	}
+	g.settle()
+	return nil
+}
+
+func (g *state) view(seat int) View {
+	v := View{
+		Seat:     seat,
+		Hand:     append([]cards.Card(nil), g.hands[seat]...),
+		Round:    g.round,
+		Stack:    g.seats[seat].Stack,
+		BigBlind: g.cfg.BigBlind,
+		Bets:     append([]int(nil), g.bets...),
+		Dealt:    append([]bool(nil), g.in...),
+		Folded:   append([]bool(nil), g.folded...),
+		Drawn:    append([]int(nil), g.drawn...),
+		Button:   g.button,
+	}
+	for i, s := range g.seats {
+		v.Pot += g.contrib[i]
+		v.Stacks = append(v.Stacks, s.Stack)
+		v.Names = append(v.Names, s.Name)
+	}
+	return v
+}
+
+func (g *state) betting(first int) error {
+	n := len(g.seats)
+	acted := make([]bool, n)
+	current := 0
+	for _, b := range g.bets {
+		current = max(current, b)
+	}
+	// the minimum raise increment is the last full raise, starting at one big blind
+	raiseBy := max(g.cfg.BigBlind, 1)
+
+	canAct := func(i int) bool { return g.in[i] && !g.folded[i] && g.seats[i].Stack > 0 }
+	needs := func(i int) bool {
+		if !canAct(i) {
+			return false
+		}
+		if g.bets[i] < current {
+			return true
+		}
+		if acted[i] {
+			return false
+		}
+		// nobody left to bet against: everyone else is all-in or folded
+		for j := range g.seats {
+			if j != i && canAct(j) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i, idle := first, 0; idle < n && g.live() > 1; i = (i + 1) % n {
+		if !needs(i) {
+			idle++
+			continue
+		}
+		idle = 0
+
+		v := g.view(i)
+		v.ToCall = min(current-g.bets[i], g.seats[i].Stack)
+		// a seat that already acted is back only because of an incomplete raise,
+		// which does not reopen the betting: it may call or fold
+		if !acted[i] && g.seats[i].Stack > current-g.bets[i] {
+			v.MinRaise = min(current+raiseBy, g.bets[i]+g.seats[i].Stack)
+		}
+		a, err := g.seats[i].Player.Act(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", g.seats[i].Name, err)
+		}
+		a = g.coerce(i, a, current, v.MinRaise)
+		acted[i] = true
+
+		switch a.Kind {
+		case Fold:
+			g.folded[i] = true
+		case Call:
+			g.post(i, current-g.bets[i], true)
+		case Bet, Raise:
+			g.post(i, a.Amount-g.bets[i], true)
+			if g.bets[i]-current >= raiseBy {
+				raiseBy = g.bets[i] - current
+				// a full raise lets everyone else raise again
+				for j := range acted {
+					if j != i {
+						acted[j] = false
+					}
+				}
+			}
+			current = g.bets[i]
+		}
+		g.emit(Event{Kind: EventAction, Seat: i, Action: a, Amount: g.bets[i], AllIn: g.seats[i].Stack == 0})
+	}
+	return nil
+}
+
+// coerce turns any requested action into a legal one.
+func (g *state) coerce(seat int, a Action, current, minRaise int) Action {
+	toCall := current - g.bets[seat]
+	stack := g.seats[seat].Stack
+	switch a.Kind {
+	case Fold:
+		if toCall == 0 {
+			return Action{Kind: Check}
+		}
+		return a
+	case Check, Call:
+		if toCall == 0 {
+			return Action{Kind: Check}
+		}
+		if a.Kind == Check {
+			return Action{Kind: Fold}
+		}
+		return Action{Kind: Call}
+	case Bet, Raise:
+		if minRaise == 0 {
+			// can only call off the rest of the stack
+			return Action{Kind: Call}
+		}
+		amount := max(a.Amount, minRaise)
+		amount = min(amount, g.bets[seat]+stack)
+		kind := Raise
+		if current == 0 {
+			kind = Bet
+		}
+		return Action{Kind: kind, Amount: amount}
+	default:
+		if toCall == 0 {
+			return Action{Kind: Check}
+		}
+		return Action{Kind: Fold}
+	}
+}
+
+func (g *state) drawPhase() error {
+	first := g.next(g.button)
+	for i, k := first, 0; k < len(g.seats); i, k = (i+1)%len(g.seats), k+1 {
+		if !g.in[i] || g.folded[i] {
+			continue
+		}
+		v := g.view(i)
+		idxs, err := g.seats[i].Player.Discard(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", g.seats[i].Name, err)
+		}
+		idxs = validDiscards(g.hands[i], idxs, g.d.Len())
+
+		repl, err := g.d.Deal(len(idxs))
+		if err != nil {
+			return fmt.Errorf("draw: %w", err)
+		}
+		for k, idx := range idxs {
+			g.hands[i][idx] = repl[k]
+		}
+		g.drawn[i] = len(idxs)
+		g.emit(Event{Kind: EventDraw, Seat: i, Amount: len(idxs), Cards: append([]cards.Card(nil), g.hands[i]...)})
+	}
+	return nil
+}
+
+// validDiscards drops out-of-range and repeated indexes, enforces the five-card draw
+// limit from hand.ComputeMaxDiscard and never draws more cards than the deck holds.
+// The fourth discard the limit allows for an ace is dropped if the ace is thrown too.
+func validDiscards(cs []cards.Card, idxs []int, left int) []int {
+	h := hand.Hand{Cards: cs}
+	limit := min(hand.ComputeMaxDiscard(h), left)
+	seen := make(map[int]bool, len(idxs))
+	out := make([]int, 0, len(idxs))
+	for _, idx := range idxs {
+		if idx < 0 || idx >= len(cs) || seen[idx] || len(out) == limit {
+			continue
+		}
+		seen[idx] = true
+		out = append(out, idx)
+	}
+	if len(out) > 3 && !hand.KeepsAce(h, out) {
+		out = out[:3]
+	}
+	sort.Ints(out)
+	return out
+}
+
+func (g *state) settle() {
+	live := g.live()
+	g.showdown = live > 1
+	g.evals = make([]hand.EvaluatedHand, len(g.seats))
+	if g.showdown {
+		for i := range g.seats {
+			if g.in[i] && !g.folded[i] {
+				g.evals[i] = hand.Evaluate(hand.Hand{Cards: g.hands[i]})
+				g.emit(Event{Kind: EventShow, Seat: i, Cards: append([]cards.Card(nil), g.hands[i]...), Eval: g.evals[i]})
+			}
+		}
+	}
+
+	g.pots = buildPots(g.contrib, g.folded, g.in)
+	for p := range g.pots {
+		pot := &g.pots[p]
+		pot.Winners = g.bestOf(pot.Eligible)
+		share := pot.Amount / len(pot.Winners)
+		odd := pot.Amount % len(pot.Winners)
+		for _, w := range g.orderFromButton(pot.Winners) {
+			won := share
+			// odd chips go to the first winners left of the button
+			if odd > 0 {
+				won++
+				odd--
+			}
+			g.seats[w].Stack += won
+			g.emit(Event{Kind: EventWin, Seat: w, Amount: won, Eval: g.evals[w], Pot: p})
+		}
+	}
+}
+
+func (g *state) bestOf(eligible []int) []int {
+	if !g.showdown {
+		return eligible
+	}
+	best := []int{eligible[0]}
+	for _, i := range eligible[1:] {
+		switch c := hand.Compare(g.evals[i], g.evals[best[0]]); {
+		case c > 0:
+			best = []int{i}
+		case c == 0:
+			best = append(best, i)
+		}
+	}
+	return best
+}
+
+func (g *state) orderFromButton(seats []int) []int {
+	n := len(g.seats)
+	out := append([]int(nil), seats...)
+	sort.Slice(out, func(a, b int) bool {
+		return (out[a]-g.button+n-1)%n < (out[b]-g.button+n-1)%n
+	})
+	return out
+}
+
+func (g *state) result() *Result {
+	r := &Result{
+		Hands:    g.hands,
+		Folded:   g.folded,
+		Showdown: g.showdown,
+		Pots:     g.pots,
+		Net:      make([]int, len(g.seats)),
+	}
+	if g.showdown {
+		r.Evals = g.evals
+	}
+	for i, s := range g.seats {
+		r.Net[i] = s.Stack - g.start[i]
+	}
+	return r
+}
@@ -0,0 +1,298 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/deck"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// scripted plays a fixed list of actions (then checks/calls) and discards.
+type scripted struct {
+	acts    []Action
+	discard []int
+	views   []View
+}
+
+func (s *scripted) Act(v View) (Action, error) {
+	s.views = append(s.views, v)
+	if len(s.acts) == 0 {
+		return Action{Kind: Call}, nil
+	}
+	a := s.acts[0]
+	s.acts = s.acts[1:]
+	return a, nil
+}
+
+func (s *scripted) Discard(v View) ([]int, error) { return s.discard, nil }
+
+// stacked builds a deck that deals the given hands round-robin (in dealing order, i.e.
+// starting left of the button) followed by the draw cards.
+func stacked(t *testing.T, hands []string, draws string) *deck.Deck {
+	t.Helper()
+	parsed := make([][]cards.Card, len(hands))
+	for i, h := range hands {
+		cs, err := cards.ParseList(h)
+		require.NoError(t, err)
+		require.Len(t, cs, 5)
+		parsed[i] = cs
+	}
+	var order []cards.Card
+	for k := 0; k < 5; k++ {
+		for _, h := range parsed {
+			order = append(order, h[k])
+		}
+	}
+	rest, err := cards.ParseList(draws)
+	require.NoError(t, err)
+	return deck.FromCards(append(order, rest...))
+}
+
+func seatsOf(stacks []int, players ...Player) []*Seat {
+	seats := make([]*Seat, len(players))
+	for i, p := range players {
+		seats[i] = &Seat{Name: string(rune('A' + i)), Stack: stacks[i], Player: p}
+	}
+	return seats
+}
+
+func TestFoldToBigBlind(t *testing.T) {
+	// three-handed, button 0: seat 1 small blind, seat 2 big blind, seat 0 first to act
+	p0 := &scripted{acts: []Action{{Kind: Fold}}}
+	p1 := &scripted{acts: []Action{{Kind: Fold}}}
+	p2 := &scripted{}
+	seats := seatsOf([]int{100, 100, 100}, p0, p1, p2)
+
+	res, err := Play(deck.NewDeck(), seats, 0, Config{SmallBlind: 1, BigBlind: 2})
+	require.NoError(t, err)
+
+	assert.False(t, res.Showdown)
+	assert.Empty(t, p2.views, "big blind never has to act when everyone folds")
+	assert.Equal(t, []int{0, -1, 1}, res.Net)
+	assert.Equal(t, []int{100, 99, 101}, []int{seats[0].Stack, seats[1].Stack, seats[2].Stack})
+}
+
+func TestShowdownAfterDraw(t *testing.T) {
+	// heads-up, button 0 posts the small blind; dealing starts with seat 1
+	d := stacked(t, []string{
+		"Ac Ad 7h 4s 2c", // seat 1: pair of aces
+		"Kc Kd Qh 9s 3c", // seat 0: pair of kings
+	}, "5h 6h 8d Kh 9d Jd")
+	p0 := &scripted{discard: []int{2, 3, 4}}
+	p1 := &scripted{discard: []int{2, 3, 4}}
+	seats := seatsOf([]int{100, 100}, p0, p1)
+
+	var events []Event
+	res, err := Play(d, seats, 0, Config{SmallBlind: 1, BigBlind: 2, Observer: func(e Event) { events = append(events, e) }})
+	require.NoError(t, err)
+
+	// seat 1 draws first (5h 6h 8d): still aces; seat 0 draws Kh 9d Jd: trip kings
+	require.True(t, res.Showdown)
+	assert.Equal(t, hand.ThreeOfKind, res.Evals[0].Category)
+	assert.Equal(t, hand.OnePair, res.Evals[1].Category)
+	assert.Equal(t, []int{2, -2}, res.Net)
+	require.Len(t, res.Pots, 1)
+	assert.Equal(t, 4, res.Pots[0].Amount)
+	assert.Equal(t, []int{0}, res.Pots[0].Winners)
+
+	// the first decision is the button's, facing one more chip
+	require.NotEmpty(t, p0.views)
+	assert.Equal(t, PreDraw, p0.views[0].Round)
+	assert.Equal(t, 1, p0.views[0].ToCall)
+	assert.Equal(t, 4, p0.views[0].MinRaise)
+
+	var texts []string
+	for _, e := range events {
+		texts = append(texts, e.String())
+	}
+	assert.Contains(t, texts, "A posts blind 1")
+	assert.Contains(t, texts, "B draws 3")
+	assert.Contains(t, texts, "A wins 4 from the pot")
+}
+
+func TestSidePots(t *testing.T) {
+	// seat 1 is short and all-in; seats 2 and 0 build a side pot that seat 1 cannot win
+	d := stacked(t, []string{
+		"Ac Ad Ah 4s 2c", // seat 1: trips, best hand
+		"Kc Kd Qh 9s 3c", // seat 2: kings
+		"Qc Qd Jh 8s 5c", // seat 0: queens
+	}, "")
+	p0 := &scripted{}
+	p1 := &scripted{acts: []Action{{Kind: Raise, Amount: 1000}}}
+	p2 := &scripted{acts: []Action{{Kind: Raise, Amount: 1000}}}
+	seats := seatsOf([]int{100, 20, 100}, p0, p1, p2)
+
+	res, err := Play(d, seats, 0, Config{Ante: 1, BigBlind: 2})
+	require.NoError(t, err)
+
+	require.Len(t, res.Pots, 2)
+	assert.Equal(t, 60, res.Pots[0].Amount, "main pot: 20 from each player")
+	assert.Equal(t, []int{1}, res.Pots[0].Winners)
+	assert.Equal(t, 160, res.Pots[1].Amount, "side pot: the other 80 from seats 0 and 2")
+	assert.Equal(t, []int{0, 2}, res.Pots[1].Eligible)
+	assert.Equal(t, []int{2}, res.Pots[1].Winners)
+	assert.Equal(t, []int{0, 60, 160}, []int{seats[0].Stack, seats[1].Stack, seats[2].Stack})
+}
+
+func TestSplitPotOddChip(t *testing.T) {
+	d := stacked(t, []string{
+		"Ac Kd Qh Js 9c",
+		"As Kh Qd Jc 9d",
+		"2c 3d 4h 6s 7c",
+	}, "")
+	folder := &scripted{acts: []Action{{Kind: Fold}}}
+	seats := seatsOf([]int{100, 100, 100}, folder, &scripted{}, &scripted{})
+
+	// seat 0 folds after its ante; hands are dealt to seats 1, 2, 0
+	res, err := Play(d, seats, 0, Config{Ante: 1, SmallBlind: 1, BigBlind: 2})
+	require.NoError(t, err)
+	require.True(t, res.Showdown)
+	// seats 1 and 2 hold the same ace-high; 1+1+1 antes + 2+2 = 7 chips split 4/3
+	assert.Equal(t, []int{1, 2}, res.Pots[0].Winners)
+	assert.Equal(t, []int{-1, 1, 0}, res.Net)
+}
+
+func TestCoerceIllegalActions(t *testing.T) {
+	tests := []struct {
+		name  string
+		act   Action
+		want  Action
+		stack int
+	}{
+		{"check facing a bet folds", Action{Kind: Check}, Action{Kind: Fold}, 100},
+		{"small raise becomes min raise", Action{Kind: Raise, Amount: 3}, Action{Kind: Raise, Amount: 4}, 100},
+		{"huge raise goes all-in", Action{Kind: Raise, Amount: 500}, Action{Kind: Raise, Amount: 100}, 100},
+		{"short stack can only call", Action{Kind: Raise, Amount: 50}, Action{Kind: Call}, 2},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got Action
+			seats := seatsOf([]int{tc.stack, 100}, &scripted{acts: []Action{tc.act}}, &scripted{acts: []Action{{Kind: Fold}}})
+			_, err := Play(deck.NewDeck(), seats, 0, Config{SmallBlind: 1, BigBlind: 2, Observer: func(e Event) {
+				if e.Kind == EventAction && e.Seat == 0 && got == (Action{}) {
+					got = e.Action
+				}
+			}})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestIncompleteRaiseDoesNotReopenBetting(t *testing.T) {
+	// button 0: seat 1 small blind, seat 2 big blind; seat 0 raises to 20, then
+	// seat 1 raises to 25 or 30 and seat 2 calls before seat 0 acts again
+	tests := []struct {
+		name        string
+		stack1      int
+		raise1      int
+		minRaise    int // offered to seat 0 the second time
+		bbMinRaise  int // offered to seat 2, which had not acted yet
+		seat0Second Action
+	}{
+		{"short all-in raise", 25, 1000, 0, 35, Action{Kind: Call}},
+		{"full raise", 100, 30, 40, 40, Action{Kind: Raise, Amount: 100}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p0 := &scripted{acts: []Action{{Kind: Raise, Amount: 20}, {Kind: Raise, Amount: 100}}}
+			p1 := &scripted{acts: []Action{{Kind: Raise, Amount: tc.raise1}}}
+			p2 := &scripted{}
+			seats := seatsOf([]int{100, tc.stack1, 100}, p0, p1, p2)
+			var acts []Action
+			_, err := Play(deck.NewDeck(), seats, 0, Config{SmallBlind: 5, BigBlind: 10, Observer: func(e Event) {
+				if e.Kind == EventAction && e.Seat == 0 {
+					acts = append(acts, e.Action)
+				}
+			}})
+			require.NoError(t, err)
+
+			require.GreaterOrEqual(t, len(p2.views), 1)
+			assert.Equal(t, tc.bbMinRaise, p2.views[0].MinRaise)
+			require.GreaterOrEqual(t, len(p0.views), 2)
+			assert.Equal(t, tc.minRaise, p0.views[1].MinRaise)
+			require.GreaterOrEqual(t, len(acts), 2)
+			assert.Equal(t, tc.seat0Second, acts[1])
+		})
+	}
+}
+
+func TestValidDiscards(t *testing.T) {
+	tests := []struct {
+		name  string
+		cards string
+		idxs  []int
+		left  int
+		want  []int
+	}{
+		{"no ace: at most three", "Kc Qd 9h 5s 2c", []int{4, 3, 1, 0}, 52, []int{1, 3, 4}},
+		{"bad and repeated indexes", "Kc Qd 9h 5s 2c", []int{2, 2, 7, -1}, 52, []int{2}},
+		{"cannot draw more than the deck holds", "Kc Qd 9h 5s 2c", []int{0, 1}, 1, []int{0}},
+		{"four keeping the ace", "Kc Ad 9h 5s 2c", []int{0, 2, 3, 4}, 52, []int{0, 2, 3, 4}},
+		{"four throwing the ace", "Kc Ad 9h 5s 2c", []int{1, 2, 3, 4}, 52, []int{1, 2, 3}},
+		{"ace thrown, another kept", "Ac Ad 9h 5s 2c", []int{1, 2, 3, 4}, 52, []int{1, 2, 3, 4}},
+		{"three throwing the ace", "Kc Ad 9h 5s 2c", []int{1, 2, 3}, 52, []int{1, 2, 3}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cs, err := cards.ParseList(tc.cards)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, validDiscards(cs, tc.idxs, tc.left))
+		})
+	}
+}
+
+func TestPlayErrors(t *testing.T) {
+	seats := seatsOf([]int{100, 0}, &scripted{}, &scripted{})
+	_, err := Play(deck.NewDeck(), seats, 0, Config{BigBlind: 2})
+	assert.ErrorIs(t, err, ErrNotEnoughPlayers)
+
+	_, err = Play(deck.NewDeck(), seatsOf([]int{100, 100}, &scripted{}, &scripted{}), 0, Config{})
+	assert.Error(t, err, "no forced bets")
+
+	quit := errors.New("quit")
+	seats = seatsOf([]int{100, 100}, quitter{quit}, &scripted{})
+	_, err = Play(deck.NewDeck(), seats, 0, Config{SmallBlind: 1, BigBlind: 2})
+	assert.ErrorIs(t, err, quit)
+	assert.Equal(t, []int{100, 100}, []int{seats[0].Stack, seats[1].Stack}, "blinds are returned")
+}
+
+type quitter struct{ err error }
+
+func (q quitter) Act(View) (Action, error)    { return Action{}, q.err }
+func (q quitter) Discard(View) ([]int, error) { return nil, q.err }
+
+// watcher is a scripted player that also records what it is shown.
+type watcher struct {
+	scripted
+	seen []Event
+}
+
+func (w *watcher) Observe(e Event) { w.seen = append(w.seen, e) }
+
+func TestWatchersSeeOnlyTheirOwnCards(t *testing.T) {
+	w := &watcher{}
+	seats := seatsOf([]int{100, 100}, w, &scripted{acts: []Action{{Kind: Check}, {Kind: Check}}})
+	_, err := Play(deck.NewDeck(), seats, 0, Config{SmallBlind: 1, BigBlind: 2})
+	require.NoError(t, err)
+
+	deals := map[int][]cards.Card{}
+	for _, e := range w.seen {
+		assert.NotEmpty(t, e.Name)
+		if e.Kind == EventDeal || e.Kind == EventDraw {
+			deals[e.Seat] = e.Cards
+		}
+	}
+	assert.Len(t, deals[0], 5, "own cards")
+	assert.Contains(t, deals, 1)
+	assert.Nil(t, deals[1], "the opponent's cards are hidden")
+	assert.Equal(t, "B is dealt in", Event{Kind: EventDeal, Name: "B"}.String())
+	require.NotEmpty(t, w.views)
+	assert.Equal(t, []bool{true, true}, w.views[0].Dealt)
+}
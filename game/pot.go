@@ -0,0 +1,48 @@
+package game
+
+import "sort"
+
+// buildPots splits total contributions into a main pot and side pots. Each pot is
+// capped at the smallest all-in among the players still contesting it, so a player
+// can only win from each opponent what they themselves put in. Chips from folded
+// players fall into the pots their contributions reach.
+func buildPots(contrib []int, folded, in []bool) []Pot {
+	left := append([]int(nil), contrib...)
+	var levels []int
+	for i, c := range contrib {
+		if in[i] && !folded[i] && c > 0 {
+			levels = append(levels, c)
+		}
+	}
+	sort.Ints(levels)
+
+	var pots []Pot
+	prev := 0
+	for _, level := range levels {
+		if level == prev {
+			continue
+		}
+		pot := Pot{}
+		for i := range left {
+			take := min(left[i], level-prev)
+			pot.Amount += take
+			left[i] -= take
+			if in[i] && !folded[i] && contrib[i] >= level {
+				pot.Eligible = append(pot.Eligible, i)
+			}
+		}
+		prev = level
+		pots = append(pots, pot)
+	}
+
+	// folded players who put in more than any live player: their excess is dead money
+	// that goes to the last pot rather than disappearing
+	extra := 0
+	for i := range left {
+		extra += left[i]
+	}
+	if extra > 0 && len(pots) > 0 {
+		pots[len(pots)-1].Amount += extra
+	}
+	return pots
+}
@@ -0,0 +1,50 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildPots(t *testing.T) {
+	tests := []struct {
+		name    string
+		contrib []int
+		folded  []bool
+		want    []Pot
+	}{
+		{
+			name:    "single pot",
+			contrib: []int{10, 10, 10},
+			folded:  []bool{false, false, false},
+			want:    []Pot{{Amount: 30, Eligible: []int{0, 1, 2}}},
+		},
+		{
+			name:    "short all-in makes a side pot",
+			contrib: []int{50, 20, 50},
+			folded:  []bool{false, false, false},
+			want:    []Pot{{Amount: 60, Eligible: []int{0, 1, 2}}, {Amount: 60, Eligible: []int{0, 2}}},
+		},
+		{
+			name:    "folded chips stay in the pot",
+			contrib: []int{30, 10, 10},
+			folded:  []bool{true, false, false},
+			want:    []Pot{{Amount: 50, Eligible: []int{1, 2}}},
+		},
+		{
+			name:    "uncalled excess is returned through its own pot",
+			contrib: []int{100, 40},
+			folded:  []bool{false, false},
+			want:    []Pot{{Amount: 80, Eligible: []int{0, 1}}, {Amount: 60, Eligible: []int{0}}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			in := make([]bool, len(tc.contrib))
+			for i := range in {
+				in[i] = true
+			}
+			assert.Equal(t, tc.want, buildPots(tc.contrib, tc.folded, in))
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package hand
+
+import (
+	"strings"
+
+	"github.com/dangogh/GoPoker/cards"
+)
+
+// Describe names an evaluated hand the way players say it, including the kickers that
+// decide ties: "Pair of Aces, K-9-4 kickers", "Aces full of Kings", "Wheel straight".
+// Rank names come from cards so descriptions match how cards are printed.
+func Describe(e EvaluatedHand) string {
+	base := name(e)
+	var kickers []cards.Rank
+	switch e.Category {
+	case HighCard, Flush, OnePair, ThreeOfKind, FourOfKind:
+		kickers = tail(e.Ranks, 1)
+	case TwoPair:
+		kickers = tail(e.Ranks, 2)
+	}
+	if len(kickers) == 0 {
+		return base
+	}
+	syms := make([]string, len(kickers))
+	for i, r := range kickers {
+		syms[i] = r.String()
+	}
+	noun := " kickers"
+	if len(kickers) == 1 {
+		noun = " kicker"
+	}
+	return base + ", " + strings.Join(syms, "-") + noun
+}
+
+func tail(rs []cards.Rank, from int) []cards.Rank {
+	if len(rs) <= from {
+		return nil
+	}
+	return rs[from:]
+}
+
+// name gives the conventional short name of a hand without kickers, e.g. "Aces full of Kings".
+func name(e EvaluatedHand) string {
+	if len(e.Ranks) == 0 {
+		return e.Category.String()
+	}
+	top := e.Ranks[0]
+	switch e.Category {
+	case StraightFlush:
+		switch top {
+		case cards.Ace:
+			return "Royal flush"
+		case cards.Five:
+			return "Wheel straight flush"
+		}
+		return top.Name() + "-high straight flush"
+	case FourOfKind:
+		return "Four " + top.Plural()
+	case FullHouse:
+		if len(e.Ranks) < 2 {
+			break
+		}
+		return top.Plural() + " full of " + e.Ranks[1].Plural()
+	case Flush:
+		return top.Name() + "-high flush"
+	case Straight:
+		if top == cards.Five {
+			return "Wheel straight"
+		}
+		return top.Name() + "-high straight"
+	case ThreeOfKind:
+		return "Three " + top.Plural()
+	case TwoPair:
+		if len(e.Ranks) < 2 {
+			break
+		}
+		return top.Plural() + " and " + e.Ranks[1].Plural()
+	case OnePair:
+		return "Pair of " + top.Plural()
+	case HighCard:
+		return top.Name() + " high"
+	}
+	return e.Category.String()
+}
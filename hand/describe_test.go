@@ -0,0 +1,38 @@
+package hand
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		cards string
+		want  string
+	}{
+		{"As Ks Qs Js Ts", "Royal flush"},
+		{"5d 4d 3d 2d Ad", "Wheel straight flush"},
+		{"9c 8c 7c 6c 5c", "Nine-high straight flush"},
+		{"Ac Ad Ah As Kc", "Four Aces, K kicker"},
+		{"Ac Ad Ah Ks Kc", "Aces full of Kings"},
+		{"Kh Jh 9h 5h 2h", "King-high flush, J-9-5-2 kickers"},
+		{"Kh Qd Jh Th 9h", "King-high straight"},
+		{"5c 4d 3h 2s Ac", "Wheel straight"},
+		{"Qc Qd Qh As 7c", "Three Queens, A-7 kickers"},
+		{"Ac Ad Kh Ks 9c", "Aces and Kings, 9 kicker"},
+		{"Ac Ad Kh 9s 4c", "Pair of Aces, K-9-4 kickers"},
+		{"6c 6d Th 9s 4c", "Pair of Sixes, 10-9-4 kickers"},
+		{"Ac Kd 9h 4s 2c", "Ace high, K-9-4-2 kickers"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			assert.Equal(t, tc.want, Describe(Evaluate(Hand{Cards: parse(t, tc.cards)})))
+		})
+	}
+}
+
+func TestDescribePartial(t *testing.T) {
+	assert.Equal(t, "Pair of Aces", Describe(Evaluate(Hand{Cards: parse(t, "Ac Ad")})))
+	assert.Equal(t, "Flush", Describe(EvaluatedHand{Category: Flush}))
+}
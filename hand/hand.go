@@ -32,8 +32,23 @@ type EvaluatedHand struct {
 	Ranks    []cards.Rank // tiebreaker ranks in descending priority
 }
 
-// Evaluate computes the category and tiebreaker ranks for the hand.
+// Validate reports the first card in h that is not one of the 52 cards.
+func (h Hand) Validate() error {
+	for i, c := range h.Cards {
+		if !c.Valid() {
+			return fmt.Errorf("card %d is invalid: %v", i, c)
+		}
+	}
+	return nil
+}
+
+// Evaluate computes the category and tiebreaker ranks for the hand. It panics on an
+// invalid card rather than score a malformed hand as some weaker one; hands built
+// from untrusted input should be checked with Validate first.
 func Evaluate(h Hand) EvaluatedHand {
+	if err := h.Validate(); err != nil {
+		panic("hand.Evaluate: " + err.Error())
+	}
 	// Count ranks and gather suits
 	rankCount := map[cards.Rank]int{}
 	suitCount := map[cards.Suit]int{}
@@ -56,9 +71,9 @@ func Evaluate(h Hand) EvaluatedHand {
 	}
 	sort.Slice(allRanks, func(i, j int) bool { return allRanks[i] > allRanks[j] })
 
-	// Detect flush
+	// Detect flush; a flush needs five cards, so partial hands never qualify
 	isFlush := false
-	if len(suitCount) == 1 {
+	if len(suitCount) == 1 && len(h.Cards) >= 5 {
 		isFlush = true
 	}
 
@@ -171,6 +186,41 @@ func Evaluate(h Hand) EvaluatedHand {
 	}
 }
 
+// BestHand returns the strongest five-card hand that can be made from cs, as used by
+// board games such as Hold'em where a player combines hole cards with community cards.
+// Fewer than five cards are evaluated as-is so partial hands still compare sensibly.
+func BestHand(cs []cards.Card) (Hand, EvaluatedHand) {
+	if len(cs) <= 5 {
+		h := Hand{Cards: append([]cards.Card(nil), cs...)}
+		return h, Evaluate(h)
+	}
+
+	var best Hand
+	var bestEval EvaluatedHand
+	first := true
+	combo := make([]cards.Card, 5)
+	// walk every 5-card subset; 21 for seven cards, which is cheap enough to brute force
+	var walk func(start, depth int)
+	walk = func(start, depth int) {
+		if depth == 5 {
+			h := Hand{Cards: combo}
+			e := Evaluate(h)
+			if first || Compare(e, bestEval) > 0 {
+				first = false
+				bestEval = e
+				best = Hand{Cards: append([]cards.Card(nil), combo...)}
+			}
+			return
+		}
+		for i := start; i <= len(cs)-(5-depth); i++ {
+			combo[depth] = cs[i]
+			walk(i+1, depth+1)
+		}
+	}
+	walk(0, 0)
+	return best, bestEval
+}
+
 // ComputeMaxDiscard determines the maximum cards that can be discarded based on 5-card draw rules:
 // - If the highest card to keep is an Ace, allow up to 4 discards (draw 4 cards).
 // - Otherwise, allow up to 3 discards (standard 5-card draw max).
@@ -191,6 +241,21 @@ func ComputeMaxDiscard(h Hand) int {
 	return 3
 }
 
+// KeepsAce reports whether h still holds an ace once the cards at discards are thrown,
+// which is what lets ComputeMaxDiscard allow a fourth discard.
+func KeepsAce(h Hand, discards []int) bool {
+	thrown := make(map[int]bool, len(discards))
+	for _, idx := range discards {
+		thrown[idx] = true
+	}
+	for i, c := range h.Cards {
+		if c.Rank == cards.Ace && !thrown[i] {
+			return true
+		}
+	}
+	return false
+}
+
 // RecommendDiscards returns the indices of cards to discard (0-based) up to maxDiscard.
 // Aggressive strategy:
 // - Do not break strong made hands (straight, flush, straight flush, full house, four of a kind).
@@ -453,6 +518,25 @@ func Compare(a, b EvaluatedHand) int {
 	return 0
 }
 
+// Places gives each hand its finishing place, 1 for the best. Tied hands share a
+// place and the next place is skipped, so three hands with the top two tied finish
+// 1, 1, 3.
+func Places(evals []EvaluatedHand) []int {
+	order := make([]int, len(evals))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return Compare(evals[order[a]], evals[order[b]]) > 0 })
+	places := make([]int, len(evals))
+	for k, i := range order {
+		places[i] = k + 1
+		if k > 0 && Compare(evals[i], evals[order[k-1]]) == 0 {
+			places[i] = places[order[k-1]]
+		}
+	}
+	return places
+}
+
 // String returns a human-readable name for the Category.
 func (c Category) String() string {
 	switch c {
@@ -399,6 +399,50 @@ func TestEvaluateWheelStraightFlush(t *testing.T) {
 	assert.Equal(t, []cards.Rank{cards.Five}, ev.Ranks)
 }
 
+func TestEvaluateShortSuitedHands(t *testing.T) {
+	tests := []struct {
+		name     string
+		cards    string
+		category Category
+		ranks    []cards.Rank
+	}{
+		{"one card", "Ah", HighCard, []cards.Rank{cards.Ace}},
+		{"two suited", "Ah Kh", HighCard, []cards.Rank{cards.Ace, cards.King}},
+		{"three suited", "Qh Jh 3h", HighCard, []cards.Rank{cards.Queen, cards.Jack, cards.Three}},
+		{"four suited", "Ah Kh 9h 4h", HighCard, []cards.Rank{cards.Ace, cards.King, cards.Nine, cards.Four}},
+		{"five suited", "Ah Kh 9h 4h 2h", Flush, []cards.Rank{cards.Ace, cards.King, cards.Nine, cards.Four, cards.Two}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ev := Evaluate(Hand{Cards: parse(t, tc.cards)})
+			assert.Equal(t, tc.category, ev.Category)
+			assert.Equal(t, tc.ranks, ev.Ranks)
+		})
+	}
+}
+
+func TestEvaluateRejectsInvalidCards(t *testing.T) {
+	tests := []struct {
+		name string
+		bad  cards.Card
+		want string
+	}{
+		{"rank above ace", cards.NewCard(cards.Spades, 15), "card 4 is invalid"},
+		{"zero card", cards.Card{}, "card 4 is invalid"},
+		{"suit out of range", cards.NewCard(7, cards.King), "card 4 is invalid"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := Hand{Cards: append(parse(t, "Ah Kd 9c 4s"), tc.bad)}
+			assert.ErrorContains(t, h.Validate(), tc.want)
+			assert.PanicsWithValue(t, "hand.Evaluate: "+h.Validate().Error(), func() { Evaluate(h) })
+		})
+	}
+	assert.NoError(t, Hand{Cards: parse(t, "Ah Kd 9c 4s 2h")}.Validate())
+}
+
 func TestCompareLongerRanksList(t *testing.T) {
 	// Test rare case where rank lists have different lengths
 	a := EvaluatedHand{Category: HighCard, Ranks: []cards.Rank{cards.Ace, cards.King, cards.Queen}}
@@ -407,6 +451,27 @@ func TestCompareLongerRanksList(t *testing.T) {
 	assert.Equal(t, -1, Compare(b, a))
 }
 
+func TestPlaces(t *testing.T) {
+	pair := func(r cards.Rank) EvaluatedHand { return EvaluatedHand{Category: OnePair, Ranks: []cards.Rank{r}} }
+	tests := []struct {
+		name  string
+		evals []EvaluatedHand
+		want  []int
+	}{
+		{"none", nil, []int{}},
+		{"one", []EvaluatedHand{pair(cards.Two)}, []int{1}},
+		{"distinct", []EvaluatedHand{pair(cards.Two), pair(cards.Ace), pair(cards.King)}, []int{3, 1, 2}},
+		{"tied winners", []EvaluatedHand{pair(cards.Ace), pair(cards.Two), pair(cards.Ace)}, []int{1, 3, 1}},
+		{"tie below the winner", []EvaluatedHand{pair(cards.Two), pair(cards.Ace), pair(cards.Two), pair(cards.Three)}, []int{3, 1, 3, 2}},
+		{"all tied", []EvaluatedHand{pair(cards.Nine), pair(cards.Nine)}, []int{1, 1}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Places(tc.evals))
+		})
+	}
+}
+
 func TestRecommendDiscardsEdgeCases(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -465,6 +530,27 @@ func TestComputeMaxDiscardEmptyHand(t *testing.T) {
 	assert.Equal(t, 3, ComputeMaxDiscard(h))
 }
 
+func TestKeepsAce(t *testing.T) {
+	tests := []struct {
+		name     string
+		cards    string
+		discards []int
+		want     bool
+	}{
+		{"ace kept", "Ah Kd 9c 5s 2c", []int{1, 2, 3, 4}, true},
+		{"ace thrown", "Ah Kd 9c 5s 2c", []int{0, 1, 2, 3}, false},
+		{"second ace kept", "Ah Ad 9c 5s 2c", []int{0, 2, 3, 4}, true},
+		{"no ace", "Kd Qd 9c 5s 2c", nil, false},
+		{"stand pat", "Ah Kd 9c 5s 2c", nil, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, KeepsAce(Hand{Cards: parse(t, tc.cards)}, tc.discards))
+		})
+	}
+}
+
 // FuzzEvaluate fuzzes Evaluate with random 5-card hands.
 // Invariants: Evaluate should not panic, should return valid category, and ranks length should be reasonable.
 func FuzzEvaluate(f *testing.F) {
@@ -563,3 +649,45 @@ func FuzzRecommendDiscards(f *testing.F) {
 		}
 	})
 }
+
+// parse builds cards from compact notation, failing the test on bad input.
+func parse(t *testing.T, s string) []cards.Card {
+	t.Helper()
+	cs, err := cards.ParseList(s)
+	if err != nil {
+		t.Fatalf("parse %q: %v", s, err)
+	}
+	return cs
+}
+
+func TestBestHand(t *testing.T) {
+	tests := []struct {
+		name     string
+		cards    string
+		category Category
+		ranks    []cards.Rank
+	}{
+		{"seven cards flush over straight", "Ah Kh 9h 4h 2h Qd Jc", Flush, []cards.Rank{cards.Ace, cards.King, cards.Nine, cards.Four, cards.Two}},
+		{"board plays", "2c 3d As Ks Qs Js Ts", StraightFlush, []cards.Rank{cards.Ace}},
+		{"full house from two trips", "9c 9d 9h 4s 4c 4d Ah", FullHouse, []cards.Rank{cards.Nine, cards.Four}},
+		{"six cards wheel", "Ac 2d 3h 4s 5c Kd", Straight, []cards.Rank{cards.Five}},
+		{"exactly five", "Ac Ad 3h 4s 5c", OnePair, []cards.Rank{cards.Ace, cards.Five, cards.Four, cards.Three}},
+		{"fewer than five", "Ac Ad", OnePair, []cards.Rank{cards.Ace}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			best, ev := BestHand(parse(t, tc.cards))
+			assert.Equal(t, tc.category, ev.Category)
+			assert.Equal(t, tc.ranks, ev.Ranks)
+			assert.Equal(t, ev, Evaluate(best), "returned hand should evaluate to the returned result")
+		})
+	}
+}
+
+func BenchmarkBestHandSeven(b *testing.B) {
+	cs, _ := cards.ParseList("Ah Kh 9h 4h 2h Qd Jc")
+	for i := 0; i < b.N; i++ {
+		BestHand(cs)
+	}
+}
@@ -0,0 +1,191 @@
+package hand
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dangogh/GoPoker/cards"
+)
+
+// DistinctHands is the number of five-card equivalence classes: hands that differ only
+// in suits (and are not flushes) always tie, which collapses C(52,5) = 2,598,960 hands
+// into 7,462 distinct strengths.
+const DistinctHands = 7462
+
+// totalHands is C(52,5), the number of five-card hands in a deck.
+const totalHands = 2598960
+
+// Strength places an evaluated hand among all possible five-card hands.
+type Strength struct {
+	// Rank is 1 for a royal flush down to DistinctHands for 7-5-4-3-2.
+	Rank int `json:"rank"`
+	// Percentile is the share of the other distinct classes that rank below this
+	// one, 0-100: 100 for a royal flush and 0 for 7-5-4-3-2.
+	Percentile float64 `json:"percentile"`
+	// Beats is the fraction of all dealt five-card hands that are strictly weaker.
+	// Unlike Percentile it weights each class by how often it is dealt, which is what
+	// "how good is this" usually means: a pair of Twos beats half of all hands.
+	Beats float64 `json:"beats"`
+	// Description names the hand with its kickers, as produced by Describe.
+	Description string `json:"description"`
+}
+
+type classInfo struct {
+	rank   int
+	weaker int // number of dealt hands strictly weaker than this class
+}
+
+// CategoryStats summarizes one category over every five-card hand.
+type CategoryStats struct {
+	Category Category
+	// Distinct is the number of equivalence classes, e.g. 10 straights.
+	Distinct int
+	// Hands is the number of dealt hands, out of C(52,5).
+	Hands int
+	// Probability is the chance of being dealt the category in five cards.
+	Probability float64
+	// Best and Worst are the category's strongest and weakest classes.
+	Best, Worst EvaluatedHand
+}
+
+var (
+	classesOnce sync.Once
+	classes     map[uint64]classInfo
+	categories  []CategoryStats // strongest first
+)
+
+// classKey packs a category and its tiebreaker ranks into a comparable map key.
+func classKey(e EvaluatedHand) uint64 {
+	k := uint64(e.Category)
+	for _, r := range e.Ranks {
+		k = k<<4 | uint64(r)
+	}
+	return k<<4 | uint64(len(e.Ranks))
+}
+
+// buildClasses enumerates one representative per equivalence class rather than all
+// 2.6M hands, then orders them with Compare so the table always agrees with Evaluate.
+func buildClasses() {
+	type class struct {
+		eval  EvaluatedHand
+		count int // dealt hands in this class
+	}
+	var all []class
+	add := func(cs []cards.Card, count int) {
+		all = append(all, class{eval: Evaluate(Hand{Cards: cs}), count: count})
+	}
+	suit := func(i int) cards.Suit { return cards.Suit(i % 4) }
+
+	ranks := make([]cards.Rank, 0, 13)
+	for r := cards.Two; r <= cards.Ace; r++ {
+		ranks = append(ranks, r)
+	}
+
+	// five distinct ranks: flush or not (straights and straight flushes included)
+	for a := 0; a < 13; a++ {
+		for b := a + 1; b < 13; b++ {
+			for c := b + 1; c < 13; c++ {
+				for d := c + 1; d < 13; d++ {
+					for e := d + 1; e < 13; e++ {
+						rs := []cards.Rank{ranks[a], ranks[b], ranks[c], ranks[d], ranks[e]}
+						flush := make([]cards.Card, 5)
+						offsuit := make([]cards.Card, 5)
+						for i, r := range rs {
+							flush[i] = cards.NewCard(cards.Spades, r)
+							offsuit[i] = cards.NewCard(suit(i), r)
+						}
+						add(flush, 4)
+						add(offsuit, 1020) // 4^5 suit patterns minus the 4 flushes
+					}
+				}
+			}
+		}
+	}
+
+	// paired hands never flush, so suits only need to keep cards distinct
+	for _, p := range ranks {
+		for _, q := range ranks {
+			if q == p {
+				continue
+			}
+			add([]cards.Card{cards.NewCard(0, p), cards.NewCard(1, p), cards.NewCard(2, p), cards.NewCard(3, p), cards.NewCard(0, q)}, 4)
+			add([]cards.Card{cards.NewCard(0, p), cards.NewCard(1, p), cards.NewCard(2, p), cards.NewCard(0, q), cards.NewCard(1, q)}, 24)
+			for _, k := range ranks {
+				if k == p || k == q {
+					continue
+				}
+				if q < k { // trips with two kickers, each kicker pair counted once
+					add([]cards.Card{cards.NewCard(0, p), cards.NewCard(1, p), cards.NewCard(2, p), cards.NewCard(0, q), cards.NewCard(1, k)}, 64)
+				}
+				if p > q { // two pair, high pair first
+					add([]cards.Card{cards.NewCard(0, p), cards.NewCard(1, p), cards.NewCard(0, q), cards.NewCard(1, q), cards.NewCard(2, k)}, 144)
+				}
+			}
+		}
+		// one pair with three distinct kickers
+		for a := 0; a < 13; a++ {
+			for b := a + 1; b < 13; b++ {
+				for c := b + 1; c < 13; c++ {
+					if ranks[a] == p || ranks[b] == p || ranks[c] == p {
+						continue
+					}
+					add([]cards.Card{cards.NewCard(0, p), cards.NewCard(1, p), cards.NewCard(0, ranks[a]), cards.NewCard(1, ranks[b]), cards.NewCard(2, ranks[c])}, 384)
+				}
+			}
+		}
+	}
+
+	sort.Slice(all, func(i, j int) bool { return Compare(all[i].eval, all[j].eval) > 0 })
+
+	classes = make(map[uint64]classInfo, len(all))
+	weaker := totalHands
+	for i, c := range all {
+		weaker -= c.count
+		classes[classKey(c.eval)] = classInfo{rank: i + 1, weaker: weaker}
+
+		// sorted strongest first, so each category's classes are contiguous
+		if len(categories) == 0 || categories[len(categories)-1].Category != c.eval.Category {
+			categories = append(categories, CategoryStats{Category: c.eval.Category, Best: c.eval})
+		}
+		st := &categories[len(categories)-1]
+		st.Distinct++
+		st.Hands += c.count
+		st.Probability = float64(st.Hands) / totalHands
+		st.Worst = c.eval
+	}
+}
+
+// Categories returns the statistics of every category, strongest first. They come from
+// the same enumeration as StrengthOf, so they always agree with Evaluate.
+func Categories() []CategoryStats {
+	classesOnce.Do(buildClasses)
+	return append([]CategoryStats(nil), categories...)
+}
+
+// AbsoluteRank returns the hand's position among the DistinctHands five-card classes,
+// 1 being a royal flush. It fails for evaluations that no five-card hand produces,
+// such as those of partial hands.
+func AbsoluteRank(e EvaluatedHand) (int, error) {
+	classesOnce.Do(buildClasses)
+	info, ok := classes[classKey(e)]
+	if !ok {
+		return 0, fmt.Errorf("not a five-card hand: %s %v", e.Category, e.Ranks)
+	}
+	return info.rank, nil
+}
+
+// StrengthOf reports the absolute rank, percentile and description of a five-card hand.
+func StrengthOf(e EvaluatedHand) (Strength, error) {
+	classesOnce.Do(buildClasses)
+	info, ok := classes[classKey(e)]
+	if !ok {
+		return Strength{}, fmt.Errorf("not a five-card hand: %s %v", e.Category, e.Ranks)
+	}
+	return Strength{
+		Rank:        info.rank,
+		Percentile:  100 * float64(DistinctHands-info.rank) / float64(DistinctHands-1),
+		Beats:       float64(info.weaker) / totalHands,
+		Description: Describe(e),
+	}, nil
+}
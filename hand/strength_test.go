@@ -0,0 +1,106 @@
+package hand
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassTableIsComplete(t *testing.T) {
+	classesOnce.Do(buildClasses)
+	assert.Len(t, classes, DistinctHands)
+
+	worst, err := StrengthOf(Evaluate(Hand{Cards: parse(t, "7c 5d 4h 3s 2c")}))
+	require.NoError(t, err)
+	assert.Equal(t, DistinctHands, worst.Rank)
+	assert.Zero(t, worst.Beats, "class counts should add up to every dealt hand")
+	assert.Zero(t, worst.Percentile)
+}
+
+func TestCategories(t *testing.T) {
+	// the standard table of five-card hand frequencies
+	want := []struct {
+		category Category
+		distinct int
+		hands    int
+	}{
+		{StraightFlush, 10, 40},
+		{FourOfKind, 156, 624},
+		{FullHouse, 156, 3744},
+		{Flush, 1277, 5108},
+		{Straight, 10, 10200},
+		{ThreeOfKind, 858, 54912},
+		{TwoPair, 858, 123552},
+		{OnePair, 2860, 1098240},
+		{HighCard, 1277, 1302540},
+	}
+	got := Categories()
+	require.Len(t, got, len(want))
+	for i, w := range want {
+		assert.Equal(t, w.category, got[i].Category)
+		assert.Equal(t, w.distinct, got[i].Distinct, w.category.String())
+		assert.Equal(t, w.hands, got[i].Hands, w.category.String())
+		assert.InDelta(t, float64(w.hands)/2598960, got[i].Probability, 1e-12)
+	}
+	assert.Equal(t, "Royal flush", Describe(got[0].Best))
+	assert.Equal(t, "Wheel straight flush", Describe(got[0].Worst))
+	assert.Equal(t, Evaluate(Hand{Cards: parse(t, "7c 5d 4h 3s 2c")}), got[8].Worst)
+
+	got[0].Hands = 0
+	assert.Equal(t, 40, Categories()[0].Hands, "callers get a copy")
+}
+
+func TestStrengthOf(t *testing.T) {
+	tests := []struct {
+		cards       string
+		rank        int
+		description string
+	}{
+		// reference ranks from the standard 7462-class ordering
+		{"As Ks Qs Js Ts", 1, "Royal flush"},
+		{"5d 4d 3d 2d Ad", 10, "Wheel straight flush"},
+		{"Ac Ad Ah As Kc", 11, "Four Aces, K kicker"},
+		{"Ac Ad Ah Ks Kc", 167, "Aces full of Kings"},
+		{"Ah Kh Qh Jh 9h", 323, "Ace-high flush, K-Q-J-9 kickers"},
+		{"Kh Qd Jh Th 9h", 1601, "King-high straight"},
+		{"5c 4d 3h 2s Ac", 1609, "Wheel straight"},
+		{"Ac Ad Ah Ks Qc", 1610, "Three Aces, K-Q kickers"},
+		{"Ac Ad Kh Ks Qc", 2468, "Aces and Kings, Q kicker"},
+		{"Ac Ad Kh Qs Jc", 3326, "Pair of Aces, K-Q-J kickers"},
+		{"2c 2d 5h 4s 3c", 6185, "Pair of Twos, 5-4-3 kickers"},
+		{"Ac Kd Qh Js 9c", 6186, "Ace high, K-Q-J-9 kickers"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			e := Evaluate(Hand{Cards: parse(t, tc.cards)})
+			s, err := StrengthOf(e)
+			require.NoError(t, err)
+			assert.Equal(t, tc.rank, s.Rank)
+			assert.Equal(t, tc.description, s.Description)
+
+			r, err := AbsoluteRank(e)
+			require.NoError(t, err)
+			assert.Equal(t, tc.rank, r)
+		})
+	}
+}
+
+func TestStrengthOrderingMatchesCompare(t *testing.T) {
+	weak, err := StrengthOf(Evaluate(Hand{Cards: parse(t, "2c 2d 5h 4s 3c")}))
+	require.NoError(t, err)
+	strong, err := StrengthOf(Evaluate(Hand{Cards: parse(t, "Ac Ad Kh Qs Jc")}))
+	require.NoError(t, err)
+	assert.Less(t, strong.Rank, weak.Rank)
+	assert.Greater(t, strong.Percentile, weak.Percentile)
+	assert.Greater(t, strong.Beats, weak.Beats)
+	// a pair of twos beats every high-card hand: 1,302,540 of 2,598,960
+	assert.InDelta(t, 1302540.0/2598960, weak.Beats, 1e-12)
+}
+
+func TestStrengthOfPartialHand(t *testing.T) {
+	_, err := StrengthOf(Evaluate(Hand{Cards: parse(t, "Ac Ad")}))
+	assert.Error(t, err)
+	_, err = AbsoluteRank(EvaluatedHand{Category: Flush})
+	assert.Error(t, err)
+}
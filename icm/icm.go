@@ -0,0 +1,172 @@
+// Package icm converts tournament chip stacks into prize equity with the Independent
+// Chip Model, and uses it to build push/fold charts.
+//
+// ICM assumes the Malmuth-Harville model: a player's chance of finishing first is their
+// share of the chips, and given who finished ahead of them, the same holds for each
+// later place among the players left. Equities computes this exactly by walking every
+// finishing order of the paid places, which is fast for final tables and small payout
+// structures; for large fields it falls back to sampling finishing orders.
+package icm
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand"
+	"slices"
+)
+
+// DefaultTrials is the number of sampled finishing orders Equities uses for fields too
+// large to enumerate.
+const DefaultTrials = 200000
+
+// maxExactStates bounds the exact walk: it visits one state per set of players that can
+// fill the top places, which grows as C(players, places).
+const maxExactStates = 1 << 20
+
+// ErrTooLarge is returned by Exact when the field is too large to enumerate.
+var ErrTooLarge = errors.New("too many players and paid places for an exact ICM calculation")
+
+// Equities returns each player's expected prize. payouts[k] is the prize for place k+1;
+// missing places pay nothing. It is exact when that is tractable and otherwise samples
+// DefaultTrials finishing orders with a fixed seed, so results are repeatable.
+func Equities(stacks []int, payouts []float64) ([]float64, error) {
+	eq, err := Exact(stacks, payouts)
+	if errors.Is(err, ErrTooLarge) {
+		return Approximate(stacks, payouts, DefaultTrials, 1)
+	}
+	return eq, err
+}
+
+// Exact computes Malmuth-Harville equities by enumerating every order in which players
+// can fill the paid places. Players with no chips share the places below everyone else.
+func Exact(stacks []int, payouts []float64) ([]float64, error) {
+	alive, pay, eq, err := prepare(stacks, payouts)
+	if err != nil {
+		return nil, err
+	}
+	places := min(len(alive), len(pay))
+	if len(alive) > 64 || states(len(alive), places) > maxExactStates {
+		return nil, ErrTooLarge
+	}
+
+	// cur maps a set of players (bit i is alive[i]) to the probability that exactly they
+	// took the places already handed out
+	cur := map[uint64]float64{0: 1}
+	total := 0
+	for _, i := range alive {
+		total += stacks[i]
+	}
+	for place := 0; place < places; place++ {
+		next := make(map[uint64]float64, len(cur)*2)
+		// a fixed order keeps the floating-point sums, and so the results, reproducible
+		for _, mask := range slices.Sorted(maps.Keys(cur)) {
+			p := cur[mask]
+			left := total
+			for b, i := range alive {
+				if mask&(1<<b) != 0 {
+					left -= stacks[i]
+				}
+			}
+			for b, i := range alive {
+				if mask&(1<<b) != 0 {
+					continue
+				}
+				q := p * float64(stacks[i]) / float64(left)
+				eq[i] += q * pay[place]
+				next[mask|1<<b] += q
+			}
+		}
+		cur = next
+	}
+	return eq, nil
+}
+
+// Approximate estimates the same equities by sampling finishing orders: repeatedly pick
+// the next finisher with probability proportional to chips. The seed makes it repeatable.
+func Approximate(stacks []int, payouts []float64, trials int, seed int64) ([]float64, error) {
+	alive, pay, eq, err := prepare(stacks, payouts)
+	if err != nil {
+		return nil, err
+	}
+	if trials <= 0 {
+		return nil, fmt.Errorf("trials must be > 0")
+	}
+	places := min(len(alive), len(pay))
+	rng := rand.New(rand.NewSource(seed))
+	left := make([]int, len(alive))
+	sums := make([]float64, len(stacks))
+	for t := 0; t < trials; t++ {
+		copy(left, alive)
+		total := 0
+		for _, i := range left {
+			total += stacks[i]
+		}
+		for place := 0; place < places; place++ {
+			x := rng.Intn(total)
+			k := 0
+			for ; x >= stacks[left[k]]; k++ {
+				x -= stacks[left[k]]
+			}
+			i := left[k]
+			sums[i] += pay[place]
+			total -= stacks[i]
+			left[k] = left[len(left)-1]
+			left = left[:len(left)-1]
+		}
+		left = left[:len(alive)]
+	}
+	for i := range sums {
+		eq[i] += sums[i] / float64(trials)
+	}
+	return eq, nil
+}
+
+// prepare validates the input, returns the players with chips, the payouts padded to
+// one per player, and equities with the busted players' shares already filled in.
+func prepare(stacks []int, payouts []float64) ([]int, []float64, []float64, error) {
+	if len(stacks) == 0 {
+		return nil, nil, nil, fmt.Errorf("no stacks")
+	}
+	var alive, busted []int
+	for i, s := range stacks {
+		switch {
+		case s < 0:
+			return nil, nil, nil, fmt.Errorf("stack %d is negative", i)
+		case s == 0:
+			busted = append(busted, i)
+		default:
+			alive = append(alive, i)
+		}
+	}
+	if len(alive) == 0 {
+		return nil, nil, nil, fmt.Errorf("nobody has chips")
+	}
+	pay := make([]float64, len(stacks))
+	copy(pay, payouts)
+
+	eq := make([]float64, len(stacks))
+	if len(busted) > 0 {
+		share := 0.0
+		for _, p := range pay[len(alive):] {
+			share += p
+		}
+		for _, i := range busted {
+			eq[i] = share / float64(len(busted))
+		}
+	}
+	return alive, pay[:len(alive)], eq, nil
+}
+
+// states counts the sets of at most places-1 players out of n, the size of the walk.
+func states(n, places int) int {
+	total, c := 0, 1
+	for k := 0; k < places; k++ {
+		total += c
+		if total > maxExactStates {
+			return total
+		}
+		c = c * (n - k) / (k + 1)
+	}
+	return total
+}
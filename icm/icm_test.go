@@ -0,0 +1,102 @@
+package icm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sum(xs []float64) float64 {
+	t := 0.0
+	for _, x := range xs {
+		t += x
+	}
+	return t
+}
+
+func TestExactKnownValues(t *testing.T) {
+	eq, err := Exact([]int{5000, 3000, 2000}, []float64{50, 30, 20})
+	require.NoError(t, err)
+	// first with 5/10, second 3/10·5/7 + 2/10·5/8, third the rest
+	second := 0.3*5.0/7 + 0.2*5.0/8
+	assert.InDelta(t, 50*0.5+30*second+20*(1-0.5-second), eq[0], 1e-9)
+	assert.InDelta(t, 100, sum(eq), 1e-9)
+	assert.Greater(t, eq[0], eq[1])
+	assert.Greater(t, eq[1], eq[2])
+	assert.Less(t, eq[0], 50.0, "the chip leader's equity is less than their chip share of the pool")
+}
+
+func TestExactIsReproducible(t *testing.T) {
+	stacks, payouts := []int{310, 290, 170, 130, 100, 60}, []float64{0.4, 0.25, 0.15, 0.12, 0.08}
+	first, err := Exact(stacks, payouts)
+	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		again, err := Exact(stacks, payouts)
+		require.NoError(t, err)
+		require.Equal(t, first, again, "bit-for-bit equal on every run")
+	}
+}
+
+func TestExactHeadsUpIsLinear(t *testing.T) {
+	eq, err := Exact([]int{300, 100}, []float64{70, 30})
+	require.NoError(t, err)
+	assert.InDelta(t, 30+40*0.75, eq[0], 1e-9)
+	assert.InDelta(t, 30+40*0.25, eq[1], 1e-9)
+}
+
+func TestExactEqualStacks(t *testing.T) {
+	eq, err := Exact([]int{10, 10, 10, 10, 10, 10}, []float64{0.5, 0.3, 0.2})
+	require.NoError(t, err)
+	for _, e := range eq {
+		assert.InDelta(t, 1.0/6, e, 1e-9)
+	}
+}
+
+func TestBustedPlayersShareTheLastPlaces(t *testing.T) {
+	eq, err := Exact([]int{100, 0, 100, 0}, []float64{40, 30, 20, 10})
+	require.NoError(t, err)
+	assert.InDelta(t, 15, eq[1], 1e-9)
+	assert.InDelta(t, 15, eq[3], 1e-9)
+	assert.InDelta(t, 35, eq[0], 1e-9)
+	assert.InDelta(t, 35, eq[2], 1e-9)
+}
+
+func TestApproximateMatchesExact(t *testing.T) {
+	stacks := []int{4200, 3100, 2500, 1800, 900, 400}
+	pay := []float64{0.5, 0.3, 0.2}
+	exact, err := Exact(stacks, pay)
+	require.NoError(t, err)
+	approx, err := Approximate(stacks, pay, 200000, 9)
+	require.NoError(t, err)
+	for i := range stacks {
+		assert.InDelta(t, exact[i], approx[i], 0.005, "player %d", i)
+	}
+	assert.InDelta(t, 1, sum(approx), 1e-9)
+}
+
+func TestEquitiesLargeFieldFallsBack(t *testing.T) {
+	stacks := make([]int, 60)
+	for i := range stacks {
+		stacks[i] = 1000 + 50*i
+	}
+	pay := []float64{0.29, 0.17, 0.12, 0.09, 0.075, 0.06, 0.05, 0.045, 0.04, 0.035, 0.025}
+	_, err := Exact(stacks, pay)
+	assert.ErrorIs(t, err, ErrTooLarge)
+
+	eq, err := Equities(stacks, pay)
+	require.NoError(t, err)
+	assert.InDelta(t, 1, sum(eq), 1e-9)
+	assert.Greater(t, eq[59], eq[0])
+}
+
+func TestEquitiesErrors(t *testing.T) {
+	_, err := Equities(nil, []float64{1})
+	assert.Error(t, err)
+	_, err = Equities([]int{10, -1}, []float64{1})
+	assert.Error(t, err)
+	_, err = Equities([]int{0, 0}, []float64{1})
+	assert.Error(t, err)
+	_, err = Approximate([]int{1, 2}, []float64{1}, 0, 1)
+	assert.Error(t, err)
+}
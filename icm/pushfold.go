@@ -0,0 +1,208 @@
+package icm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dangogh/GoPoker/equity"
+)
+
+// Spot is an all-in-or-fold decision: everyone else has folded to Hero, and Caller is
+// the only player left to act, as when the small blind faces the big blind.
+type Spot struct {
+	// Stacks are the chips each player started the hand with.
+	Stacks []int
+	// Posted is what each player has already put in the pot (blinds and antes), taken
+	// from their stack. Nil means nothing has been posted.
+	Posted  []int
+	Payouts []float64
+	Hero    int
+	Caller  int
+	// CallRange is the hands Caller calls the shove with.
+	CallRange equity.Range
+}
+
+// Decision is the push/fold verdict for one starting-hand class.
+type Decision struct {
+	Class string `json:"class"`
+	Push  bool   `json:"push"`
+	// Gain is the prize equity gained by shoving instead of folding, in payout units.
+	Gain float64 `json:"gain"`
+	// Equity is Hero's showdown equity when called; CallFreq is how often Caller has a
+	// calling hand given the cards Hero holds.
+	Equity   float64 `json:"equity"`
+	CallFreq float64 `json:"call_freq"`
+}
+
+// Chart is a 13×13 push/fold chart laid out like equity.Grid.
+type Chart struct {
+	Ranks  []string         `json:"ranks"`
+	Cells  [13][13]Decision `json:"cells"`
+	FoldEV float64          `json:"fold_ev"`
+	Trials int              `json:"trials_per_cell"`
+}
+
+// outcomes are Hero's prize equities for each way the hand can end, computed once per
+// spot since only the probabilities change between starting hands.
+type outcomes struct {
+	fold, steal, win, lose float64
+}
+
+func (s Spot) outcomes() (outcomes, error) {
+	n := len(s.Stacks)
+	if n < 2 {
+		return outcomes{}, fmt.Errorf("at least two stacks are required")
+	}
+	if s.Hero < 0 || s.Hero >= n || s.Caller < 0 || s.Caller >= n || s.Hero == s.Caller {
+		return outcomes{}, fmt.Errorf("hero and caller must be two different players")
+	}
+	posted := s.Posted
+	if posted == nil {
+		posted = make([]int, n)
+	}
+	if len(posted) != n {
+		return outcomes{}, fmt.Errorf("posted has %d entries for %d stacks", len(posted), n)
+	}
+	pot := 0
+	behind := make([]int, n)
+	for i := range s.Stacks {
+		if posted[i] < 0 || posted[i] > s.Stacks[i] {
+			return outcomes{}, fmt.Errorf("player %d posted %d from a stack of %d", i, posted[i], s.Stacks[i])
+		}
+		pot += posted[i]
+		behind[i] = s.Stacks[i] - posted[i]
+	}
+	if behind[s.Hero] == 0 || behind[s.Caller] == 0 {
+		return outcomes{}, fmt.Errorf("hero and caller must both have chips behind")
+	}
+
+	heroEV := func(adjust func(st []int)) (float64, error) {
+		st := append([]int(nil), behind...)
+		adjust(st)
+		eq, err := Equities(st, s.Payouts)
+		if err != nil {
+			return 0, err
+		}
+		return eq[s.Hero], nil
+	}
+	var o outcomes
+	var err error
+	if o.fold, err = heroEV(func(st []int) { st[s.Caller] += pot }); err != nil {
+		return o, err
+	}
+	if o.steal, err = heroEV(func(st []int) { st[s.Hero] += pot }); err != nil {
+		return o, err
+	}
+	// when called each side risks the smaller of the two full stacks
+	risk := min(s.Stacks[s.Hero], s.Stacks[s.Caller])
+	called := pot + 2*risk - posted[s.Hero] - posted[s.Caller]
+	heroIn, callerIn := risk-posted[s.Hero], risk-posted[s.Caller]
+	if o.win, err = heroEV(func(st []int) { st[s.Hero] += called - heroIn; st[s.Caller] -= callerIn }); err != nil {
+		return o, err
+	}
+	o.lose, err = heroEV(func(st []int) { st[s.Hero] -= heroIn; st[s.Caller] += called - callerIn })
+	return o, err
+}
+
+// Decide evaluates shoving one starting-hand class. Equity when called comes from
+// equity.Compute against CallRange with opts; ties count as half a win, as in equity.
+func (s Spot) Decide(class equity.Class, opts equity.Options) (Decision, error) {
+	o, err := s.outcomes()
+	if err != nil {
+		return Decision{}, err
+	}
+	return s.decide(o, class, opts)
+}
+
+func (s Spot) decide(o outcomes, class equity.Class, opts equity.Options) (Decision, error) {
+	d := Decision{Class: class.String()}
+	hero := equity.Range(class.Combos())
+	for _, c := range hero {
+		// 1225 = C(50,2), every two-card hand Caller can hold
+		d.CallFreq += float64(len(s.CallRange.Without(c[:]))) / 1225
+	}
+	d.CallFreq /= float64(len(hero))
+
+	if d.CallFreq > 0 {
+		res, err := equity.Compute(hero, []equity.Range{s.CallRange}, opts)
+		if err != nil && !errors.Is(err, equity.ErrNoValidDeal) {
+			return d, fmt.Errorf("%s: %w", d.Class, err)
+		}
+		if err == nil {
+			d.Equity = res.Equity[0]
+		}
+	}
+	push := (1-d.CallFreq)*o.steal + d.CallFreq*(d.Equity*o.win+(1-d.Equity)*o.lose)
+	d.Gain = push - o.fold
+	d.Push = d.Gain > 0
+	return d, nil
+}
+
+// PushFold decides every starting-hand class. Each cell's equity simulation is seeded
+// from opts.Seed as in equity.Heatmap, so a seeded chart is reproducible.
+func PushFold(s Spot, opts equity.Options) (*Chart, error) {
+	if len(s.CallRange) == 0 {
+		return nil, fmt.Errorf("call range is empty")
+	}
+	o, err := s.outcomes()
+	if err != nil {
+		return nil, err
+	}
+	if opts.Trials <= 0 {
+		opts.Trials = equity.DefaultTrials
+	}
+	c := &Chart{FoldEV: o.fold, Trials: opts.Trials}
+	for i := 0; i < 13; i++ {
+		c.Ranks = append(c.Ranks, equity.GridClass(i, i).String()[:1])
+	}
+	base := opts.Seed
+	for row := 0; row < 13; row++ {
+		for col := 0; col < 13; col++ {
+			cellOpts := opts
+			if base != 0 {
+				cellOpts.Seed = base + int64(row*13+col)
+			}
+			if c.Cells[row][col], err = s.decide(o, equity.GridClass(row, col), cellOpts); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return c, nil
+}
+
+// WriteText draws the chart with the shoving classes named and the folds as dots.
+func (c *Chart) WriteText(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "%3s", ""); err != nil {
+		return err
+	}
+	for _, r := range c.Ranks {
+		if _, err := fmt.Fprintf(w, "%5s", r); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+	pushes := 0
+	for row, r := range c.Ranks {
+		if _, err := fmt.Fprintf(w, "%3s", r); err != nil {
+			return err
+		}
+		for col := range c.Ranks {
+			cell, mark := c.Cells[row][col], "."
+			if cell.Push {
+				mark = cell.Class
+				pushes += len(equity.GridClass(row, col).Combos())
+			}
+			if _, err := fmt.Fprintf(w, "%5s", mark); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "push %.1f%% of hands (suited above the diagonal, offsuit below)\n", 100*float64(pushes)/1326)
+	return err
+}
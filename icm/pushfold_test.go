@@ -0,0 +1,115 @@
+package icm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/equity"
+)
+
+func mustRange(t *testing.T, s string) equity.Range {
+	t.Helper()
+	r, err := equity.ParseRange(s)
+	require.NoError(t, err)
+	return r
+}
+
+var (
+	aces       = equity.Class{High: cards.Ace, Low: cards.Ace}
+	sevenDeuce = equity.Class{High: cards.Seven, Low: cards.Two}
+)
+
+func TestDecideHeadsUpChipEV(t *testing.T) {
+	// winner take all makes prize equity proportional to chips
+	s := Spot{
+		Stacks: []int{200, 200}, Posted: []int{10, 20}, Payouts: []float64{1},
+		Hero: 0, Caller: 1, CallRange: mustRange(t, "QQ+"),
+	}
+	d, err := s.Decide(aces, equity.Options{Trials: 2000, Seed: 1})
+	require.NoError(t, err)
+	assert.True(t, d.Push)
+	assert.InDelta(t, 13.0/1225, d.CallFreq, 1e-9, "AA leaves 6 QQ, 6 KK and 1 AA")
+	assert.Greater(t, d.Equity, 0.7)
+
+	// tight callers make even the worst hand a profitable shove at 10 big blinds
+	d, err = s.Decide(sevenDeuce, equity.Options{Trials: 2000, Seed: 1})
+	require.NoError(t, err)
+	assert.InDelta(t, 18.0/1225, d.CallFreq, 1e-9)
+	assert.True(t, d.Push)
+	assert.InDelta(t, 30.0/400, d.Gain, 0.02, "it steals the 30-chip pot nearly every time")
+}
+
+func TestBubbleTightensShoving(t *testing.T) {
+	// four left, three paid, one micro stack: losing a flip costs the big stacks a lot
+	bubble := Spot{
+		Stacks: []int{5000, 5000, 5000, 50}, Posted: []int{50, 100, 0, 0},
+		Payouts: []float64{0.5, 0.3, 0.2}, Hero: 0, Caller: 1, CallRange: mustRange(t, "22+,A2+,K9+,QT+,JT"),
+	}
+	chipEV := bubble
+	chipEV.Payouts = []float64{1}
+
+	opts := equity.Options{Trials: 1500, Seed: 3}
+	tens := equity.Class{High: cards.Ten, Low: cards.Ten}
+	d, err := chipEV.Decide(tens, opts)
+	require.NoError(t, err)
+	assert.True(t, d.Push, "TT is a shove for chips")
+
+	d, err = bubble.Decide(tens, opts)
+	require.NoError(t, err)
+	assert.False(t, d.Push, "but not on the bubble")
+	d, err = bubble.Decide(aces, opts)
+	require.NoError(t, err)
+	assert.True(t, d.Push)
+}
+
+func TestPushFoldChart(t *testing.T) {
+	s := Spot{
+		Stacks: []int{300, 300, 300}, Posted: []int{10, 20, 0}, Payouts: []float64{0.65, 0.35},
+		Hero: 0, Caller: 1, CallRange: mustRange(t, "77+,ATs+,AJo+,KQs"),
+	}
+	c, err := PushFold(s, equity.Options{Trials: 200, Seed: 5})
+	require.NoError(t, err)
+	assert.Equal(t, "A", c.Ranks[0])
+	assert.Equal(t, "T", c.Ranks[4])
+	assert.True(t, c.Cells[0][0].Push, "AA")
+	assert.Equal(t, "AKs", c.Cells[0][1].Class)
+
+	again, err := PushFold(s, equity.Options{Trials: 200, Seed: 5})
+	require.NoError(t, err)
+	assert.Equal(t, c, again)
+
+	var buf bytes.Buffer
+	require.NoError(t, c.WriteText(&buf))
+	assert.Contains(t, buf.String(), "AA")
+	assert.Regexp(t, `push \d+\.\d% of hands`, buf.String())
+}
+
+func TestSpotErrors(t *testing.T) {
+	good := Spot{Stacks: []int{100, 100}, Payouts: []float64{1}, Hero: 0, Caller: 1, CallRange: mustRange(t, "AA")}
+	tests := []struct {
+		name   string
+		modify func(*Spot)
+	}{
+		{"same player", func(s *Spot) { s.Caller = 0 }},
+		{"out of range", func(s *Spot) { s.Caller = 2 }},
+		{"posted too much", func(s *Spot) { s.Posted = []int{200, 0} }},
+		{"posted mismatch", func(s *Spot) { s.Posted = []int{1} }},
+		{"all in already", func(s *Spot) { s.Posted = []int{100, 0} }},
+		{"one stack", func(s *Spot) { s.Stacks = []int{100} }},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := good
+			tc.modify(&s)
+			_, err := s.Decide(aces, equity.Options{Trials: 10})
+			assert.Error(t, err)
+		})
+	}
+	good.CallRange = nil
+	_, err := PushFold(good, equity.Options{Trials: 10})
+	assert.Error(t, err)
+}
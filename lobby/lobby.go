@@ -0,0 +1,170 @@
+// Package lobby hosts poker tables for remote players. Clients talk to it with the JSON
+// Messages in protocol.go over any transport that delivers them in order; the
+// poker-server command carries them over WebSocket.
+//
+// A client connects, lists the tables, joins one as a spectator and sits down. Each
+// table deals five-card draw hands with the game engine whenever two or more players
+// are seated, prompting the player to act and folding for them if they take too long.
+// Hole cards stay private: a client is only ever sent its own cards, plus whatever is
+// shown at showdown.
+package lobby
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Lobby is the set of tables on a server. It is safe for concurrent use.
+type Lobby struct {
+	mu     sync.Mutex
+	tables map[string]*Table
+	next   int
+	closed bool
+}
+
+// New returns an empty lobby.
+func New() *Lobby {
+	return &Lobby{tables: map[string]*Table{}}
+}
+
+// Create opens a table and starts its dealer goroutine.
+func (l *Lobby) Create(cfg TableConfig) (*Table, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil, fmt.Errorf("lobby is closed")
+	}
+	t, err := newTable(fmt.Sprintf("t%d", l.next+1), cfg)
+	if err != nil {
+		return nil, err
+	}
+	l.next++
+	l.tables[t.id] = t
+	return t, nil
+}
+
+// Table returns the table with the given ID, or nil.
+func (l *Lobby) Table(id string) *Table {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.tables[id]
+}
+
+// Tables describes every table, ordered by ID.
+func (l *Lobby) Tables() []TableInfo {
+	l.mu.Lock()
+	ts := make([]*Table, 0, len(l.tables))
+	for _, t := range l.tables {
+		ts = append(ts, t)
+	}
+	l.mu.Unlock()
+	return sortedInfos(ts)
+}
+
+// Close stops every table. Hands in progress finish with the remote players folding.
+func (l *Lobby) Close() {
+	l.mu.Lock()
+	l.closed = true
+	ts := l.tables
+	l.tables = map[string]*Table{}
+	l.mu.Unlock()
+	for _, t := range ts {
+		t.close()
+	}
+}
+
+// Session is one connected client.
+type Session struct {
+	lobby *Lobby
+	send  func(Message)
+
+	mu    sync.Mutex
+	name  string
+	table *Table
+}
+
+// Connect registers a client. send delivers a message to it; it is called from the
+// tables' goroutines while they hold locks, so it must be safe for concurrent use and
+// must not block — a transport that falls behind should drop the client instead.
+func (l *Lobby) Connect(send func(Message)) *Session {
+	return &Session{lobby: l, send: send}
+}
+
+// Handle processes one message from the client. Problems are reported back to the
+// client as error messages.
+func (s *Session) Handle(m Message) {
+	if err := s.handle(m); err != nil {
+		s.send(Message{Type: TypeError, Error: err.Error()})
+	}
+}
+
+func (s *Session) handle(m Message) error {
+	switch m.Type {
+	case TypeList:
+		s.send(Message{Type: TypeTables, Tables: s.lobby.Tables()})
+		return nil
+	case TypeJoin:
+		return s.join(m.Table, m.Name)
+	}
+
+	s.mu.Lock()
+	t := s.table
+	s.mu.Unlock()
+	if t == nil {
+		return fmt.Errorf("join a table first")
+	}
+	switch m.Type {
+	case TypeSit:
+		seat := -1
+		if m.Seat != nil {
+			seat = *m.Seat
+		}
+		return t.sit(s, seat)
+	case TypeAct, TypeDiscard:
+		return t.answer(s, m)
+	case TypeStand:
+		return t.stand(s, false)
+	case TypeLeave:
+		s.mu.Lock()
+		s.table = nil
+		s.mu.Unlock()
+		return t.stand(s, true)
+	default:
+		return fmt.Errorf("unknown message type %q", m.Type)
+	}
+}
+
+// join makes the client a spectator at a table. A client is at one table at a time.
+func (s *Session) join(id, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("a name is required to join")
+	}
+	t := s.lobby.Table(id)
+	if t == nil {
+		return fmt.Errorf("no table %q", id)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.table != nil {
+		return fmt.Errorf("already at table %s; leave it first", s.table.id)
+	}
+	s.name = name
+	if err := t.join(s); err != nil {
+		return err
+	}
+	s.table = t
+	return nil
+}
+
+// Close disconnects the client, giving up its seat.
+func (s *Session) Close() {
+	s.mu.Lock()
+	t := s.table
+	s.table = nil
+	s.mu.Unlock()
+	if t != nil {
+		_ = t.stand(s, true)
+	}
+}
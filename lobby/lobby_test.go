@@ -0,0 +1,251 @@
+package lobby
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/game"
+)
+
+// client is an in-process connection that records what the server sends. With auto set
+// it calls every bet and stands pat, so hands run to showdown.
+type client struct {
+	s    *Session
+	auto bool
+
+	mu  sync.Mutex
+	log []Message
+}
+
+func connect(l *Lobby, auto bool) *client {
+	c := &client{auto: auto}
+	c.s = l.Connect(func(m Message) {
+		c.mu.Lock()
+		c.log = append(c.log, m)
+		c.mu.Unlock()
+		if c.auto && m.Type == TypePrompt {
+			// answer from another goroutine: send is called with the table locked
+			go c.answer(m.Prompt)
+		}
+	})
+	return c
+}
+
+func (c *client) answer(p *Prompt) {
+	if p.Kind == "discard" {
+		c.s.Handle(Message{Type: TypeDiscard})
+		return
+	}
+	action := "call"
+	if p.ToCall == 0 {
+		action = "check"
+	}
+	c.s.Handle(Message{Type: TypeAct, Action: action})
+}
+
+func (c *client) messages() []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Message{}, c.log...)
+}
+
+func (c *client) last(typ string) (Message, bool) {
+	msgs := c.messages()
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msgs[i].Type == typ {
+			return msgs[i], true
+		}
+	}
+	return Message{}, false
+}
+
+// waitHands waits until the client has seen n hands finish.
+func (c *client) waitHands(t *testing.T, n int) *State {
+	t.Helper()
+	var st *State
+	require.Eventually(t, func() bool {
+		m, ok := c.last(TypeState)
+		if ok && m.State.Hand >= n && !m.State.InHand {
+			st = m.State
+			return true
+		}
+		return false
+	}, 5*time.Second, 5*time.Millisecond)
+	return st
+}
+
+func newTestTable(t *testing.T, timeout time.Duration) (*Lobby, *Table) {
+	t.Helper()
+	l := New()
+	t.Cleanup(l.Close)
+	tbl, err := l.Create(TableConfig{
+		Name:          "test",
+		Game:          game.Config{SmallBlind: 1, BigBlind: 2},
+		BuyIn:         1000,
+		ActionTimeout: timeout,
+		HandPause:     time.Millisecond,
+		Seed:          7,
+	})
+	require.NoError(t, err)
+	return l, tbl
+}
+
+func join(t *testing.T, c *client, table, name string, sit bool) {
+	t.Helper()
+	c.s.Handle(Message{Type: TypeJoin, Table: table, Name: name})
+	if sit {
+		c.s.Handle(Message{Type: TypeSit})
+	}
+	_, failed := c.last(TypeError)
+	require.False(t, failed, "%s joins %s", name, table)
+}
+
+func TestHoleCardsStayPrivate(t *testing.T) {
+	l, tbl := newTestTable(t, time.Second)
+	alice, bob, eve := connect(l, true), connect(l, true), connect(l, false)
+	join(t, alice, tbl.ID(), "alice", true)
+	join(t, bob, tbl.ID(), "bob", true)
+	join(t, eve, tbl.ID(), "eve", false)
+	alice.waitHands(t, 3)
+
+	for name, c := range map[string]*client{"alice": alice, "bob": bob, "eve": eve} {
+		own := 0
+		for _, m := range c.messages() {
+			if m.Type != TypeEvent || len(m.Event.Cards) == 0 {
+				continue
+			}
+			switch m.Event.Kind {
+			case "deal", "draw":
+				assert.Equal(t, name, m.Event.Name, "%s sees only their own cards", name)
+				own++
+			case "show", "win":
+			default:
+				t.Errorf("%s: unexpected cards in %s event", name, m.Event.Kind)
+			}
+		}
+		if name == "eve" {
+			assert.Zero(t, own, "spectators see no hole cards")
+		} else {
+			assert.GreaterOrEqual(t, own, 3, "%s sees their own hands", name)
+		}
+	}
+
+	m, ok := alice.last(TypeState)
+	require.True(t, ok)
+	assert.Len(t, m.State.Seats, 2)
+	assert.Equal(t, 2000, m.State.Seats[0].Stack+m.State.Seats[1].Stack, "chips are conserved")
+	assert.GreaterOrEqual(t, m.State.You, 0)
+	m, _ = eve.last(TypeState)
+	assert.Equal(t, -1, m.State.You)
+}
+
+func TestIdlePlayerTimesOut(t *testing.T) {
+	l, tbl := newTestTable(t, 20*time.Millisecond)
+	alice, bob := connect(l, true), connect(l, false)
+	join(t, alice, tbl.ID(), "alice", true)
+	join(t, bob, tbl.ID(), "bob", true)
+	bob.waitHands(t, 2)
+
+	m, ok := bob.last(TypePrompt)
+	require.True(t, ok, "the idle player was prompted")
+	assert.Equal(t, int64(20), m.Prompt.TimeoutMs)
+	assert.NotEmpty(t, m.Prompt.Hand)
+}
+
+func TestStandingUpMidHandFolds(t *testing.T) {
+	l, tbl := newTestTable(t, 5*time.Second)
+	alice, bob := connect(l, true), connect(l, false)
+	join(t, alice, tbl.ID(), "alice", true)
+	join(t, bob, tbl.ID(), "bob", true)
+
+	require.Eventually(t, func() bool {
+		_, ok := bob.last(TypePrompt)
+		return ok
+	}, 5*time.Second, 5*time.Millisecond)
+	bob.s.Handle(Message{Type: TypeStand})
+
+	// the hand ends long before the timeout, and bob keeps watching from the rail
+	st := bob.waitHands(t, 1)
+	assert.Equal(t, -1, st.You)
+	assert.Len(t, st.Seats, 1)
+	assert.Equal(t, 1, tbl.Info().Watching)
+	assert.Equal(t, 1, tbl.Info().Seated)
+}
+
+func TestSessionErrors(t *testing.T) {
+	l, tbl := newTestTable(t, time.Second)
+	tests := []struct {
+		name string
+		msgs []Message
+		want string
+	}{
+		{"sit before joining", []Message{{Type: TypeSit}}, "join a table first"},
+		{"unknown table", []Message{{Type: TypeJoin, Table: "t9", Name: "x"}}, `no table "t9"`},
+		{"no name", []Message{{Type: TypeJoin, Table: tbl.ID(), Name: " "}}, "name is required"},
+		{"act while watching", []Message{{Type: TypeJoin, Table: tbl.ID(), Name: "a"}, {Type: TypeAct, Action: "call"}}, "not seated"},
+		{"act out of turn", []Message{{Type: TypeJoin, Table: tbl.ID(), Name: "b"}, {Type: TypeSit}, {Type: TypeAct, Action: "call"}}, "not your turn"},
+		{"join twice", []Message{{Type: TypeJoin, Table: tbl.ID(), Name: "c"}, {Type: TypeJoin, Table: tbl.ID(), Name: "c"}}, "already at table"},
+		{"bad seat", []Message{{Type: TypeJoin, Table: tbl.ID(), Name: "d"}, {Type: TypeSit, Seat: new(int)}, {Type: TypeStand}, {Type: TypeSit, Seat: ptr(99)}}, "out of range"},
+		{"unknown type", []Message{{Type: TypeJoin, Table: tbl.ID(), Name: "e"}, {Type: "shout"}}, `unknown message type "shout"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := connect(l, false)
+			defer c.s.Close()
+			for _, m := range tt.msgs {
+				c.s.Handle(m)
+			}
+			m, ok := c.last(TypeError)
+			require.True(t, ok)
+			assert.Contains(t, m.Error, tt.want)
+		})
+	}
+
+	a, b := connect(l, false), connect(l, false)
+	join(t, a, tbl.ID(), "same", false)
+	b.s.Handle(Message{Type: TypeJoin, Table: tbl.ID(), Name: "same"})
+	m, ok := b.last(TypeError)
+	require.True(t, ok)
+	assert.Contains(t, m.Error, "already at this table")
+}
+
+func ptr(i int) *int { return &i }
+
+func TestLobbyTables(t *testing.T) {
+	l := New()
+	defer l.Close()
+	_, err := l.Create(TableConfig{})
+	assert.ErrorContains(t, err, "big blind")
+
+	a, err := l.Create(TableConfig{Name: "low", Game: game.Config{SmallBlind: 1, BigBlind: 2}})
+	require.NoError(t, err)
+	_, err = l.Create(TableConfig{Game: game.Config{SmallBlind: 5, BigBlind: 10, Ante: 1}})
+	require.NoError(t, err)
+	assert.Same(t, a, l.Table("t1"))
+	assert.Nil(t, l.Table("t3"))
+
+	c := connect(l, false)
+	c.s.Handle(Message{Type: TypeList})
+	m, ok := c.last(TypeTables)
+	require.True(t, ok)
+	assert.Equal(t, []TableInfo{
+		{ID: "t1", Name: "low", SmallBlind: 1, BigBlind: 2, BuyIn: 200},
+		{ID: "t2", Name: "t2", SmallBlind: 5, BigBlind: 10, Ante: 1, BuyIn: 1000},
+	}, m.Tables)
+
+	l.Close()
+	_, err = l.Create(TableConfig{Game: game.Config{BigBlind: 2}})
+	assert.Error(t, err)
+}
+
+func TestParseAction(t *testing.T) {
+	a, err := ParseAction(Message{Action: "raise", Amount: 40})
+	require.NoError(t, err)
+	assert.Equal(t, game.Action{Kind: game.Raise, Amount: 40}, a)
+	_, err = ParseAction(Message{Action: "shove"})
+	assert.ErrorContains(t, err, "valid options")
+}
@@ -0,0 +1,181 @@
+package lobby
+
+import (
+	"fmt"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/game"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// Message types sent by clients.
+const (
+	TypeList    = "list"    // list the tables
+	TypeJoin    = "join"    // join a table as a spectator: Table, Name
+	TypeSit     = "sit"     // take a seat at the joined table: Seat (optional)
+	TypeAct     = "act"     // answer an "act" prompt: Action, Amount
+	TypeDiscard = "discard" // answer a "discard" prompt: Discards
+	TypeStand   = "stand"   // give up the seat but keep watching
+	TypeLeave   = "leave"   // leave the table altogether
+)
+
+// Message types sent by the server.
+const (
+	TypeTables = "tables" // Tables
+	TypeState  = "state"  // State: seats and stacks, sent whenever they change
+	TypeEvent  = "event"  // Event: something happened in the current hand
+	TypePrompt = "prompt" // Prompt: the server is waiting for this client's decision
+	TypeError  = "error"  // Error: the last request was rejected
+)
+
+// Message is the JSON envelope used in both directions. Type says which of the other
+// fields are set.
+type Message struct {
+	Type string `json:"type"`
+
+	Table    string `json:"table,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Seat     *int   `json:"seat,omitempty"`     // omitted for the first free seat
+	Action   string `json:"action,omitempty"`   // fold, check, call, bet or raise
+	Amount   int    `json:"amount,omitempty"`   // for bet and raise: the total to raise to
+	Discards []int  `json:"discards,omitempty"` // indexes into the hand; empty stands pat
+
+	Tables []TableInfo `json:"tables,omitempty"`
+	State  *State      `json:"state,omitempty"`
+	Event  *Event      `json:"event,omitempty"`
+	Prompt *Prompt     `json:"prompt,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// TableInfo describes a table in the lobby.
+type TableInfo struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	SmallBlind int    `json:"small_blind"`
+	BigBlind   int    `json:"big_blind"`
+	Ante       int    `json:"ante,omitempty"`
+	BuyIn      int    `json:"buy_in"`
+	Seated     int    `json:"seated"`
+	Watching   int    `json:"watching"`
+	Hands      int    `json:"hands"`
+}
+
+// State is a table as one client sees it.
+type State struct {
+	Table  string     `json:"table"`
+	Hand   int        `json:"hand"` // hands played, counting the one in progress
+	InHand bool       `json:"in_hand"`
+	Button int        `json:"button"` // -1 before the first hand
+	Seats  []SeatInfo `json:"seats"`  // occupied seats only
+	You    int        `json:"you"`    // this client's seat, -1 when only watching
+}
+
+// SeatInfo is one occupied seat. While a hand is running Stack is the stack it started
+// with; events carry the changes.
+type SeatInfo struct {
+	Seat  int    `json:"seat"`
+	Name  string `json:"name"`
+	Stack int    `json:"stack"`
+}
+
+// Event is one step of a hand. Cards are only ever set for the receiving player's own
+// hand, or for hands shown at showdown.
+type Event struct {
+	Kind   string   `json:"kind"` // ante, blind, deal, action, draw, show or win
+	Seat   int      `json:"seat"`
+	Name   string   `json:"name"`
+	Action string   `json:"action,omitempty"`
+	Amount int      `json:"amount,omitempty"`
+	AllIn  bool     `json:"all_in,omitempty"`
+	Cards  []string `json:"cards,omitempty"`
+	Hand   string   `json:"hand,omitempty"` // description of a shown or winning hand
+	Pot    int      `json:"pot,omitempty"`  // for win: 0 is the main pot
+	Text   string   `json:"text"`           // human-readable summary
+}
+
+// Prompt asks a player for a decision.
+type Prompt struct {
+	Kind      string   `json:"kind"` // act or discard
+	Hand      []string `json:"hand"`
+	Round     string   `json:"round"`
+	Pot       int      `json:"pot"`
+	ToCall    int      `json:"to_call"`
+	MinRaise  int      `json:"min_raise"` // 0 when raising is not possible
+	Stack     int      `json:"stack"`
+	BigBlind  int      `json:"big_blind"`
+	TimeoutMs int64    `json:"timeout_ms"` // the server folds (or stands pat) after this
+}
+
+var eventKinds = map[game.EventKind]string{
+	game.EventAnte:   "ante",
+	game.EventBlind:  "blind",
+	game.EventDeal:   "deal",
+	game.EventAction: "action",
+	game.EventDraw:   "draw",
+	game.EventShow:   "show",
+	game.EventWin:    "win",
+}
+
+// eventOf converts an engine event. Callers pass e.Public() for players who must not
+// see the cards.
+func eventOf(e game.Event) *Event {
+	out := &Event{
+		Kind:   eventKinds[e.Kind],
+		Seat:   e.Seat,
+		Name:   e.Name,
+		Amount: e.Amount,
+		AllIn:  e.AllIn,
+		Cards:  cardStrings(e.Cards),
+		Pot:    e.Pot,
+		Text:   e.String(),
+	}
+	if e.Kind == game.EventAction {
+		out.Action = e.Action.Kind.String()
+	}
+	if (e.Kind == game.EventShow || e.Kind == game.EventWin) && len(e.Eval.Ranks) > 0 {
+		out.Hand = hand.Describe(e.Eval)
+	}
+	return out
+}
+
+func promptOf(kind string, v game.View, timeoutMs int64) *Prompt {
+	return &Prompt{
+		Kind:      kind,
+		Hand:      cardStrings(v.Hand),
+		Round:     v.Round.String(),
+		Pot:       v.Pot,
+		ToCall:    v.ToCall,
+		MinRaise:  v.MinRaise,
+		Stack:     v.Stack,
+		BigBlind:  v.BigBlind,
+		TimeoutMs: timeoutMs,
+	}
+}
+
+var actionKinds = map[string]game.ActionKind{
+	"fold":  game.Fold,
+	"check": game.Check,
+	"call":  game.Call,
+	"bet":   game.Bet,
+	"raise": game.Raise,
+}
+
+// ParseAction converts an act message into an engine action.
+func ParseAction(m Message) (game.Action, error) {
+	k, ok := actionKinds[m.Action]
+	if !ok {
+		return game.Action{}, fmt.Errorf("unknown action %q (valid options: fold, check, call, bet, raise)", m.Action)
+	}
+	return game.Action{Kind: k, Amount: m.Amount}, nil
+}
+
+func cardStrings(cs []cards.Card) []string {
+	if len(cs) == 0 {
+		return nil
+	}
+	out := make([]string, len(cs))
+	for i, c := range cs {
+		out[i] = c.String()
+	}
+	return out
+}
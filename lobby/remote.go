@@ -0,0 +1,115 @@
+package lobby
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dangogh/GoPoker/game"
+)
+
+// remote is a seated client. The table's goroutine calls Act and Discard, which prompt
+// the client and wait for its answer, the timeout or the player leaving.
+type remote struct {
+	name    string
+	session *Session
+	timeout time.Duration
+
+	mu      sync.Mutex
+	waiting string // prompt kind being waited for, "" when none
+	answers chan Message
+
+	goneOnce sync.Once
+	gone     chan struct{}
+}
+
+func newRemote(name string, s *Session, timeout time.Duration) *remote {
+	return &remote{name: name, session: s, timeout: timeout, answers: make(chan Message, 1), gone: make(chan struct{})}
+}
+
+var errNotYourTurn = errors.New("it is not your turn")
+
+// answer hands a client's message to the waiting Act or Discard.
+func (r *remote) answer(m Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if (m.Type == TypeAct && r.waiting != "act") || (m.Type == TypeDiscard && r.waiting != "discard") {
+		return errNotYourTurn
+	}
+	if m.Type == TypeAct {
+		if _, err := ParseAction(m); err != nil {
+			return err
+		}
+	}
+	r.waiting = ""
+	r.answers <- m
+	return nil
+}
+
+// quit makes every current and future decision the default one.
+func (r *remote) quit() { r.goneOnce.Do(func() { close(r.gone) }) }
+
+// ask prompts the client and returns its answer, or false on timeout or when the
+// player has left.
+func (r *remote) ask(kind string, v game.View) (Message, bool) {
+	select {
+	case <-r.gone:
+		return Message{}, false
+	default:
+	}
+	r.mu.Lock()
+	r.waiting = kind
+	r.mu.Unlock()
+	r.session.send(Message{Type: TypePrompt, Prompt: promptOf(kind, v, r.timeout.Milliseconds())})
+
+	timer := time.NewTimer(r.timeout)
+	defer timer.Stop()
+	select {
+	case m := <-r.answers:
+		return m, true
+	case <-timer.C:
+	case <-r.gone:
+	}
+	r.mu.Lock()
+	r.waiting = ""
+	r.mu.Unlock()
+	// an answer may have slipped in just before waiting was cleared
+	select {
+	case m := <-r.answers:
+		return m, true
+	default:
+		return Message{}, false
+	}
+}
+
+// Act defaults to checking, or folding when there is a bet to call.
+func (r *remote) Act(v game.View) (game.Action, error) {
+	m, ok := r.ask("act", v)
+	if !ok {
+		if v.ToCall == 0 {
+			return game.Action{Kind: game.Check}, nil
+		}
+		return game.Action{Kind: game.Fold}, nil
+	}
+	return ParseAction(m)
+}
+
+// Discard defaults to standing pat.
+func (r *remote) Discard(v game.View) ([]int, error) {
+	m, ok := r.ask("discard", v)
+	if !ok {
+		return nil, nil
+	}
+	return m.Discards, nil
+}
+
+// Observe forwards the hand's events; the engine has already hidden other players' cards.
+// Once the player has stood up the table sends them the spectators' events instead.
+func (r *remote) Observe(e game.Event) {
+	select {
+	case <-r.gone:
+		return
+	default:
+	}
+	r.session.send(Message{Type: TypeEvent, Event: eventOf(e)})
+}
@@ -0,0 +1,373 @@
+package lobby
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dangogh/GoPoker/game"
+	"github.com/dangogh/GoPoker/table"
+)
+
+// Defaults for TableConfig fields left at zero.
+const (
+	DefaultActionTimeout = 30 * time.Second
+	DefaultHandPause     = 3 * time.Second
+)
+
+// TableConfig describes a table to create.
+type TableConfig struct {
+	Name string
+	Game game.Config
+	// BuyIn is the stack a player sits down with.
+	BuyIn int
+	// ActionTimeout is how long a player may think before the server folds for them.
+	ActionTimeout time.Duration
+	// HandPause is the wait before each hand, so players can read the last one.
+	HandPause time.Duration
+	// Seed shuffles the decks; 0 picks one from the clock.
+	Seed int64
+}
+
+// Table runs hands in its own goroutine whenever at least two players are seated.
+// Clients change seats between hands: sitting down or standing up during a hand takes
+// effect when it ends, and a player who stands up mid-hand folds.
+type Table struct {
+	id  string
+	cfg TableConfig
+
+	mu       sync.Mutex
+	t        *table.Table
+	sessions map[*Session]*remote // everyone at the table; nil for spectators
+	pending  []func()             // seat changes waiting for the hand to end
+	inHand   bool
+	dealt    map[*Session]bool // seated in the hand in progress
+
+	// copied from t between hands, since the engine changes them unlocked
+	seats  []SeatInfo
+	hands  int
+	button int
+
+	wake    chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+func newTable(id string, cfg TableConfig) (*Table, error) {
+	if cfg.Game.BigBlind <= 0 {
+		return nil, fmt.Errorf("a big blind is required")
+	}
+	if cfg.Game.SmallBlind < 0 || cfg.Game.Ante < 0 {
+		return nil, fmt.Errorf("blinds and antes must be >= 0")
+	}
+	if cfg.BuyIn <= 0 {
+		cfg.BuyIn = 100 * cfg.Game.BigBlind
+	}
+	if cfg.ActionTimeout <= 0 {
+		cfg.ActionTimeout = DefaultActionTimeout
+	}
+	if cfg.HandPause < 0 {
+		return nil, fmt.Errorf("hand pause must be >= 0")
+	}
+	if cfg.HandPause == 0 {
+		cfg.HandPause = DefaultHandPause
+	}
+	if cfg.Name == "" {
+		cfg.Name = id
+	}
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+	t := &Table{
+		id:       id,
+		cfg:      cfg,
+		sessions: map[*Session]*remote{},
+		button:   -1,
+		wake:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	gcfg := cfg.Game
+	gcfg.Observer = t.observe
+	t.t = table.New(gcfg, rand.New(rand.NewSource(cfg.Seed)))
+	go t.run()
+	return t, nil
+}
+
+// ID identifies the table in the lobby.
+func (t *Table) ID() string { return t.id }
+
+// Info summarizes the table.
+func (t *Table) Info() TableInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.infoLocked()
+}
+
+func (t *Table) infoLocked() TableInfo {
+	info := TableInfo{
+		ID: t.id, Name: t.cfg.Name, BuyIn: t.cfg.BuyIn, Hands: t.hands,
+		SmallBlind: t.cfg.Game.SmallBlind, BigBlind: t.cfg.Game.BigBlind, Ante: t.cfg.Game.Ante,
+	}
+	for _, r := range t.sessions {
+		if r != nil {
+			info.Seated++
+		} else {
+			info.Watching++
+		}
+	}
+	return info
+}
+
+// run deals hands until the table is closed.
+func (t *Table) run() {
+	defer close(t.stopped)
+	for {
+		t.mu.Lock()
+		ready := t.t.Active() >= 2
+		t.mu.Unlock()
+		if !ready {
+			select {
+			case <-t.wake:
+				continue
+			case <-t.done:
+				return
+			}
+		}
+		select {
+		case <-time.After(t.cfg.HandPause):
+		case <-t.done:
+			return
+		}
+
+		t.mu.Lock()
+		t.applyPendingLocked()
+		if t.t.Active() < 2 {
+			t.mu.Unlock()
+			continue
+		}
+		t.inHand = true
+		t.dealt = map[*Session]bool{}
+		for s, r := range t.sessions {
+			if i := t.t.Seat(r.nameOrEmpty()); i >= 0 && t.t.Seats()[i].Stack > 0 {
+				t.dealt[s] = true
+			}
+		}
+		t.broadcastStateLocked()
+		t.mu.Unlock()
+
+		// the engine runs unlocked: seat changes wait in pending until it returns
+		_, err := t.t.PlayHand()
+
+		t.mu.Lock()
+		t.inHand = false
+		if err != nil {
+			t.broadcastLocked(Message{Type: TypeError, Error: err.Error()})
+		}
+		t.applyPendingLocked()
+		// players who busted lost their seat
+		for s, r := range t.sessions {
+			if r != nil && t.t.Seat(r.name) < 0 {
+				t.sessions[s] = nil
+			}
+		}
+		t.snapshotLocked()
+		t.broadcastStateLocked()
+		t.mu.Unlock()
+	}
+}
+
+func (r *remote) nameOrEmpty() string {
+	if r == nil {
+		return ""
+	}
+	return r.name
+}
+
+// observe sends the public side of every event to the spectators. Players dealt in
+// get their events through remote.Observe, with their own cards.
+func (t *Table) observe(e game.Event) {
+	msg := Message{Type: TypeEvent, Event: eventOf(e.Public())}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for s := range t.sessions {
+		if !t.dealt[s] {
+			s.send(msg)
+		}
+	}
+}
+
+// later runs f now, or after the current hand if one is running.
+func (t *Table) laterLocked(f func()) {
+	if t.inHand {
+		t.pending = append(t.pending, f)
+		return
+	}
+	f()
+	t.snapshotLocked()
+	t.broadcastStateLocked()
+	t.poke()
+}
+
+func (t *Table) applyPendingLocked() {
+	for _, f := range t.pending {
+		f()
+	}
+	t.pending = nil
+	t.snapshotLocked()
+}
+
+func (t *Table) poke() {
+	select {
+	case t.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (t *Table) snapshotLocked() {
+	t.hands, t.button = t.t.Hands(), t.t.Button()
+	t.seats = t.seats[:0]
+	for i, s := range t.t.Seats() {
+		if s.Player != nil {
+			t.seats = append(t.seats, SeatInfo{Seat: i, Name: s.Name, Stack: s.Stack})
+		}
+	}
+}
+
+func (t *Table) stateLocked(s *Session) *State {
+	st := &State{Table: t.id, Hand: t.hands, InHand: t.inHand, Button: t.button, You: -1,
+		Seats: append([]SeatInfo{}, t.seats...)}
+	if t.inHand {
+		st.Hand++
+	}
+	if r := t.sessions[s]; r != nil {
+		for _, seat := range st.Seats {
+			if seat.Name == r.name {
+				st.You = seat.Seat
+			}
+		}
+	}
+	return st
+}
+
+func (t *Table) broadcastStateLocked() {
+	for s := range t.sessions {
+		s.send(Message{Type: TypeState, State: t.stateLocked(s)})
+	}
+}
+
+func (t *Table) broadcastLocked(m Message) {
+	for s := range t.sessions {
+		s.send(m)
+	}
+}
+
+// join adds a spectator.
+func (t *Table) join(s *Session) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for other := range t.sessions {
+		if other.name == s.name {
+			return fmt.Errorf("%s is already at this table", s.name)
+		}
+	}
+	t.sessions[s] = nil
+	s.send(Message{Type: TypeState, State: t.stateLocked(s)})
+	return nil
+}
+
+// sit seats a spectator with the table's buy-in; seat -1 takes the first free seat.
+func (t *Table) sit(s *Session, seat int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r, ok := t.sessions[s]
+	if !ok {
+		return fmt.Errorf("join a table first")
+	}
+	if r != nil {
+		return fmt.Errorf("you are already seated")
+	}
+	r = newRemote(s.name, s, t.cfg.ActionTimeout)
+	t.sessions[s] = r
+	t.laterLocked(func() {
+		if t.sessions[s] != r {
+			return // stood up or left before the hand ended
+		}
+		if _, err := t.t.Sit(seat, r.name, t.cfg.BuyIn, r); err != nil {
+			t.sessions[s] = nil
+			s.send(Message{Type: TypeError, Error: err.Error()})
+		}
+	})
+	return nil
+}
+
+// stand gives up the seat, folding the current hand; with leave the client also stops
+// watching.
+func (t *Table) stand(s *Session, leave bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r, ok := t.sessions[s]
+	if !ok {
+		return fmt.Errorf("you are not at this table")
+	}
+	if r == nil && !leave {
+		return fmt.Errorf("you are not seated")
+	}
+	if r != nil {
+		// from now on the client follows the rest of the hand as a spectator
+		r.quit()
+		t.sessions[s] = nil
+		delete(t.dealt, s)
+		t.laterLocked(func() {
+			if t.t.Seat(r.name) >= 0 && t.t.Seats()[t.t.Seat(r.name)].Player == r {
+				t.t.Leave(r.name)
+			}
+		})
+	}
+	if leave {
+		delete(t.sessions, s)
+	}
+	return nil
+}
+
+// answer routes a decision to the client's seat.
+func (t *Table) answer(s *Session, m Message) error {
+	t.mu.Lock()
+	r := t.sessions[s]
+	t.mu.Unlock()
+	if r == nil {
+		return fmt.Errorf("you are not seated")
+	}
+	return r.answer(m)
+}
+
+// close stops dealing; a hand in progress finishes with every remote player folding.
+func (t *Table) close() {
+	t.mu.Lock()
+	select {
+	case <-t.done:
+		t.mu.Unlock()
+		return
+	default:
+	}
+	close(t.done)
+	for _, r := range t.sessions {
+		if r != nil {
+			r.quit()
+		}
+	}
+	t.mu.Unlock()
+	<-t.stopped
+}
+
+// sortedInfos orders tables by ID.
+func sortedInfos(ts []*Table) []TableInfo {
+	out := make([]TableInfo, 0, len(ts))
+	for _, t := range ts {
+		out = append(out, t.Info())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
@@ -0,0 +1,183 @@
+// Package outs answers "which cards improve me and how often" for draw and board games.
+//
+// Probabilities are exact: every combination of the drawn cards is enumerated from the
+// unseen deck, so results are reproducible and suitable for reference tables.
+package outs
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/deck"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// MaxDraw caps the number of cards drawn; C(47,5) is already 1.5M hands to evaluate.
+const MaxDraw = 5
+
+// Options describe what else is known about the deal.
+type Options struct {
+	Board []cards.Card // community cards that play with the hand
+	Dead  []cards.Card // cards that cannot be drawn (discards, exposed cards)
+	Draw  int          // number of cards still to come
+}
+
+// Target reports how often the final hand reaches a category.
+type Target struct {
+	Category    hand.Category `json:"category_rank"`
+	Name        string        `json:"category"`
+	Outs        []string      `json:"outs"`        // unseen cards that alone reach at least this category
+	Probability float64       `json:"probability"` // P(final hand is at least this category)
+	Exact       float64       `json:"exact"`       // P(final hand is exactly this category)
+}
+
+// Analysis is the result of Analyze. Targets lists every category stronger than the
+// current hand, weakest first.
+type Analysis struct {
+	Current      hand.Category `json:"current_rank"`
+	CurrentName  string        `json:"current"`
+	Draw         int           `json:"draw"`
+	Unseen       int           `json:"unseen"`
+	Combinations int           `json:"combinations"`
+	Targets      []Target      `json:"targets"`
+}
+
+// Analyze enumerates every way to draw opts.Draw cards from the unseen deck and reports,
+// for each category above the current one, the outs and the probability of reaching it.
+// The final hand is the best five cards of held + board + drawn cards.
+func Analyze(held []cards.Card, opts Options) (Analysis, error) {
+	if opts.Draw < 0 || opts.Draw > MaxDraw {
+		return Analysis{}, fmt.Errorf("draw must be between 0 and %d, got %d", MaxDraw, opts.Draw)
+	}
+	known := make([]cards.Card, 0, len(held)+len(opts.Board)+len(opts.Dead))
+	known = append(known, held...)
+	known = append(known, opts.Board...)
+	known = append(known, opts.Dead...)
+	seen := make(map[cards.Card]bool, len(known))
+	for _, c := range known {
+		if seen[c] {
+			return Analysis{}, fmt.Errorf("card %s appears more than once", c)
+		}
+		seen[c] = true
+	}
+
+	visible := append(append([]cards.Card(nil), held...), opts.Board...)
+	if len(visible) == 0 {
+		return Analysis{}, fmt.Errorf("no cards to analyze")
+	}
+	if len(visible)+opts.Draw > 7 {
+		return Analysis{}, fmt.Errorf("%d cards plus %d drawn is more than seven", len(visible), opts.Draw)
+	}
+
+	d := deck.NewDeck()
+	d.RemoveCards(known)
+	unseen, err := d.Deal(d.Len())
+	if err != nil {
+		return Analysis{}, err
+	}
+	if opts.Draw > len(unseen) {
+		return Analysis{}, fmt.Errorf("cannot draw %d cards from %d unseen", opts.Draw, len(unseen))
+	}
+
+	_, cur := hand.BestHand(visible)
+	a := Analysis{
+		Current:     cur.Category,
+		CurrentName: cur.Category.String(),
+		Draw:        opts.Draw,
+		Unseen:      len(unseen),
+	}
+
+	counts := make(map[hand.Category]int)
+	final := make([]cards.Card, len(visible), len(visible)+opts.Draw)
+	copy(final, visible)
+	forEachCombination(unseen, opts.Draw, func(drawn []cards.Card) {
+		_, e := hand.BestHand(append(final, drawn...))
+		counts[e.Category]++
+		a.Combinations++
+	})
+
+	outs := map[hand.Category][]cards.Card{}
+	if opts.Draw > 0 {
+		for _, c := range unseen {
+			_, e := hand.BestHand(append(final, c))
+			for cat := cur.Category + 1; cat <= e.Category; cat++ {
+				outs[cat] = append(outs[cat], c)
+			}
+		}
+	}
+
+	atLeast := 0
+	for cat := hand.StraightFlush; cat > cur.Category; cat-- {
+		atLeast += counts[cat]
+		sortCards(outs[cat])
+		names := make([]string, len(outs[cat]))
+		for i, c := range outs[cat] {
+			names[i] = c.String()
+		}
+		a.Targets = append(a.Targets, Target{
+			Category:    cat,
+			Name:        cat.String(),
+			Outs:        names,
+			Probability: float64(atLeast) / float64(a.Combinations),
+			Exact:       float64(counts[cat]) / float64(a.Combinations),
+		})
+	}
+	// built strongest-first to accumulate "at least"; report weakest-first
+	for i, j := 0, len(a.Targets)-1; i < j; i, j = i+1, j-1 {
+		a.Targets[i], a.Targets[j] = a.Targets[j], a.Targets[i]
+	}
+	return a, nil
+}
+
+// AnalyzeDraw analyzes a five-card draw decision: the cards at discard indexes are
+// thrown away (and so cannot be drawn back) and replaced from the deck.
+func AnalyzeDraw(h hand.Hand, discards []int) (Analysis, error) {
+	drop := make(map[int]bool, len(discards))
+	for _, idx := range discards {
+		if idx < 0 || idx >= len(h.Cards) {
+			return Analysis{}, fmt.Errorf("discard index %d out of range", idx)
+		}
+		if drop[idx] {
+			return Analysis{}, fmt.Errorf("discard index %d repeated", idx)
+		}
+		drop[idx] = true
+	}
+	var held, dead []cards.Card
+	for i, c := range h.Cards {
+		if drop[i] {
+			dead = append(dead, c)
+		} else {
+			held = append(held, c)
+		}
+	}
+	return Analyze(held, Options{Dead: dead, Draw: len(discards)})
+}
+
+// forEachCombination calls fn with every k-card subset of cs. The slice passed to fn
+// is reused between calls.
+func forEachCombination(cs []cards.Card, k int, fn func([]cards.Card)) {
+	combo := make([]cards.Card, k)
+	var walk func(start, depth int)
+	walk = func(start, depth int) {
+		if depth == k {
+			fn(combo)
+			return
+		}
+		for i := start; i <= len(cs)-(k-depth); i++ {
+			combo[depth] = cs[i]
+			walk(i+1, depth+1)
+		}
+	}
+	walk(0, 0)
+}
+
+// sortCards orders outs by rank then suit so output is stable and easy to scan.
+func sortCards(cs []cards.Card) {
+	sort.Slice(cs, func(i, j int) bool {
+		if cs[i].Rank != cs[j].Rank {
+			return cs[i].Rank > cs[j].Rank
+		}
+		return cs[i].Suit > cs[j].Suit
+	})
+}
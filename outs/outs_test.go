@@ -0,0 +1,116 @@
+package outs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+func mustCards(t *testing.T, s string) []cards.Card {
+	t.Helper()
+	cs, err := cards.ParseList(s)
+	require.NoError(t, err)
+	return cs
+}
+
+func target(t *testing.T, a Analysis, cat hand.Category) Target {
+	t.Helper()
+	for _, tg := range a.Targets {
+		if tg.Category == cat {
+			return tg
+		}
+	}
+	t.Fatalf("no target for %v", cat)
+	return Target{}
+}
+
+func TestAnalyzeFourFlush(t *testing.T) {
+	a, err := Analyze(mustCards(t, "Ah Kh 9h 4h"), Options{Dead: mustCards(t, "2c"), Draw: 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, hand.HighCard, a.Current)
+	assert.Equal(t, 47, a.Unseen)
+	assert.Equal(t, 47, a.Combinations)
+	flush := target(t, a, hand.Flush)
+	assert.Len(t, flush.Outs, 9)
+	assert.InDelta(t, 9.0/47, flush.Probability, 1e-9)
+	assert.InDelta(t, 9.0/47, flush.Exact, 1e-9)
+	assert.Equal(t, "A♠", target(t, a, hand.OnePair).Outs[0], "strongest outs first")
+}
+
+func TestAnalyzeOpenEnder(t *testing.T) {
+	a, err := Analyze(mustCards(t, "5c 6d 7h 8s"), Options{Dead: mustCards(t, "Kc"), Draw: 1})
+	require.NoError(t, err)
+	straight := target(t, a, hand.Straight)
+	assert.Len(t, straight.Outs, 8)
+	assert.InDelta(t, 8.0/47, straight.Probability, 1e-9)
+}
+
+func TestAnalyzeDrawToPair(t *testing.T) {
+	h := hand.Hand{Cards: mustCards(t, "As Ad Kc 7h 2d")}
+	a, err := AnalyzeDraw(h, []int{2, 3, 4})
+	require.NoError(t, err)
+
+	assert.Equal(t, hand.OnePair, a.Current)
+	assert.Equal(t, 16215, a.Combinations, "C(47,3)")
+	// the textbook figure for improving a pair when drawing three
+	assert.InDelta(t, 0.2871, target(t, a, hand.TwoPair).Probability, 0.0005)
+	assert.Equal(t, []string{"A♥", "A♣"}, target(t, a, hand.TwoPair).Outs, "only trips beat a lone pair with one card")
+	assert.Len(t, target(t, a, hand.ThreeOfKind).Outs, 2)
+}
+
+func TestAnalyzeHoldemFlushDraw(t *testing.T) {
+	a, err := Analyze(mustCards(t, "Ah Kh"), Options{Board: mustCards(t, "2h 7h 9c"), Draw: 2})
+	require.NoError(t, err)
+	assert.InDelta(t, 1-703.0/1081, target(t, a, hand.Flush).Probability, 1e-9)
+}
+
+func TestAnalyzeNoDraw(t *testing.T) {
+	a, err := Analyze(mustCards(t, "As Ks Qs Js 9d"), Options{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, a.Combinations)
+	for _, tg := range a.Targets {
+		assert.Zero(t, tg.Probability)
+		assert.Empty(t, tg.Outs)
+	}
+}
+
+func TestAnalyzeProbabilitiesAreMonotonic(t *testing.T) {
+	a, err := Analyze(mustCards(t, "Ts 9s"), Options{Board: mustCards(t, "8s 2d Kc"), Draw: 2})
+	require.NoError(t, err)
+	for i := 1; i < len(a.Targets); i++ {
+		assert.GreaterOrEqual(t, a.Targets[i-1].Probability, a.Targets[i].Probability)
+	}
+}
+
+func TestAnalyzeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		held string
+		opts Options
+	}{
+		{"negative draw", "As", Options{Draw: -1}},
+		{"draw too large", "As", Options{Draw: 6}},
+		{"no cards", "", Options{Draw: 1}},
+		{"duplicate", "As Ks", Options{Dead: []cards.Card{cards.NewCard(cards.Spades, cards.Ace)}}},
+		{"more than seven", "As Ks Qs Js Ts 9s", Options{Draw: 2}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Analyze(mustCards(t, tc.held), tc.opts)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAnalyzeDrawBadIndexes(t *testing.T) {
+	h := hand.Hand{Cards: mustCards(t, "As Ad Kc 7h 2d")}
+	_, err := AnalyzeDraw(h, []int{5})
+	assert.Error(t, err)
+	_, err = AnalyzeDraw(h, []int{1, 1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,68 @@
+package pokergrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+)
+
+// Client calls the evaluator service. It is the generated EvaluatorClient with
+// EvaluateAll added for batches.
+type Client struct {
+	EvaluatorClient
+}
+
+// NewClient wraps an existing connection.
+func NewClient(cc grpc.ClientConnInterface) *Client {
+	return &Client{NewEvaluatorClient(cc)}
+}
+
+// Dial connects to a server at target, e.g. "localhost:9090". Pass credentials in opts;
+// the caller closes the returned connection.
+func Dial(target string, opts ...grpc.DialOption) (*Client, *grpc.ClientConn, error) {
+	cc, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewClient(cc), cc, nil
+}
+
+// EvaluateAll evaluates a batch over one stream, sending while it receives so large
+// batches don't wait on flow control, and returns the results in order.
+func (c *Client) EvaluateAll(ctx context.Context, hands []*Hand, opts ...grpc.CallOption) ([]*EvaluatedHand, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.EvaluateStream(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	sendErr := make(chan error, 1)
+	go func() {
+		for _, h := range hands {
+			if err := stream.Send(h); err != nil {
+				// the server ended the call; Recv reports why
+				sendErr <- nil
+				return
+			}
+		}
+		sendErr <- stream.CloseSend()
+	}()
+
+	out := make([]*EvaluatedHand, 0, len(hands))
+	for {
+		e, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	if err := <-sendErr; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
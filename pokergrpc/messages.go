@@ -0,0 +1,37 @@
+package pokergrpc
+
+import (
+	"fmt"
+
+	"github.com/dangogh/GoPoker/cards"
+)
+
+// The message types are generated from poker.proto. Rank values are the pips, as in
+// cards.Rank, and Category values are those of hand.Category; Suit is one more than
+// cards.Suit so that 0 means unset.
+
+// CardOf converts a card for the wire.
+func CardOf(c cards.Card) *Card {
+	return &Card{Rank: Rank(c.Rank), Suit: Suit(c.Suit + 1)}
+}
+
+// Card converts back, rejecting unset or out-of-range values.
+func (c *Card) Card() (cards.Card, error) {
+	rank, suit := c.GetRank(), c.GetSuit()
+	if rank < Rank(cards.Two) || rank > Rank(cards.Ace) {
+		return cards.Card{}, fmt.Errorf("invalid rank %d", rank)
+	}
+	if suit < 1 || suit > Suit(cards.Spades)+1 {
+		return cards.Card{}, fmt.Errorf("invalid suit %d", suit)
+	}
+	return cards.NewCard(cards.Suit(suit-1), cards.Rank(rank)), nil
+}
+
+// HandOf converts cards for the wire.
+func HandOf(id string, cs []cards.Card) *Hand {
+	h := &Hand{Id: id, Cards: make([]*Card, len(cs))}
+	for i, c := range cs {
+		h.Cards[i] = CardOf(c)
+	}
+	return h
+}
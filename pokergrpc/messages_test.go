@@ -0,0 +1,172 @@
+package pokergrpc
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/dangogh/GoPoker/cards"
+)
+
+// TestWireFormat pins the encoding to the field numbers and types in poker.proto.
+func TestWireFormat(t *testing.T) {
+	marshal := func(m proto.Message) []byte {
+		b, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+		require.NoError(t, err)
+		return b
+	}
+	h := &Hand{Cards: []*Card{{Rank: Rank_RANK_ACE, Suit: Suit_SUIT_SPADES}}, Id: "a"}
+	assert.Equal(t, []byte{0x0a, 0x04, 0x08, 0x0e, 0x10, 0x04, 0x12, 0x01, 'a'}, marshal(h))
+
+	e := &EvaluatedHand{Id: "x", Category: Category_CATEGORY_TWO_PAIR, Ranks: []Rank{13, 7, 10}, Description: "d", AbsoluteRank: 2600, Beats: 0.5}
+	assert.Equal(t, []byte{
+		0x0a, 0x01, 'x', // id
+		0x10, 0x02, // category
+		0x1a, 0x03, 0x0d, 0x07, 0x0a, // packed ranks
+		0x22, 0x01, 'd', // description
+		0x28, 0xa8, 0x14, // absolute_rank
+		0x31, 0, 0, 0, 0, 0, 0, 0xe0, 0x3f, // beats
+	}, marshal(e))
+
+	assert.Empty(t, marshal(&Card{}), "default values are left out")
+}
+
+func TestUnmarshalToleratesOtherEncoders(t *testing.T) {
+	// unpacked ranks and an unknown field 9, as an older or newer peer might send
+	var b []byte
+	b = protowire.AppendTag(b, 3, protowire.VarintType)
+	b = protowire.AppendVarint(b, 14)
+	b = protowire.AppendTag(b, 9, protowire.BytesType)
+	b = protowire.AppendString(b, "future")
+	b = protowire.AppendTag(b, 3, protowire.VarintType)
+	b = protowire.AppendVarint(b, 13)
+	var e EvaluatedHand
+	require.NoError(t, proto.Unmarshal(b, &e))
+	assert.Equal(t, []Rank{14, 13}, e.Ranks)
+
+	assert.Error(t, proto.Unmarshal([]byte{0x0a, 0x05, 'x'}, &e), "truncated input")
+	assert.Error(t, proto.Unmarshal([]byte{0x0a, 0x02, 0x08}, &Hand{}), "truncated nested card")
+}
+
+func TestCardConversion(t *testing.T) {
+	for s := cards.Clubs; s <= cards.Spades; s++ {
+		c := cards.NewCard(s, cards.Queen)
+		back, err := CardOf(c).Card()
+		require.NoError(t, err)
+		assert.Equal(t, c, back)
+	}
+	assert.Equal(t, &Card{Rank: Rank_RANK_ACE, Suit: Suit_SUIT_CLUBS}, CardOf(cards.NewCard(cards.Clubs, cards.Ace)))
+	_, err := (&Card{Rank: 14}).Card()
+	assert.ErrorContains(t, err, "invalid suit 0")
+	_, err = (&Card{Rank: 15, Suit: 1}).Card()
+	assert.ErrorContains(t, err, "invalid rank 15")
+	_, err = (*Card)(nil).Card()
+	assert.ErrorContains(t, err, "invalid rank 0")
+	assert.Equal(t, Category_CATEGORY_FULL_HOUSE, Category(6))
+}
+
+var (
+	protoBlock = regexp.MustCompile(`^(message|enum|service) (\w+) \{`)
+	protoField = regexp.MustCompile(`^\s*(repeated )?(\w+) (\w+) = (\d+);`)
+	protoValue = regexp.MustCompile(`^\s*(\w+) = (\d+);`)
+	protoRPC   = regexp.MustCompile(`^\s*rpc (\w+)\((stream )?(\w+)\) returns \((stream )?(\w+)\);`)
+)
+
+// TestGeneratedCodeMatchesProto catches poker.proto being edited without go generate
+// being run again: every declaration in the file must be in the compiled descriptor.
+func TestGeneratedCodeMatchesProto(t *testing.T) {
+	f, err := os.Open("poker.proto")
+	require.NoError(t, err)
+	defer f.Close()
+	fd := File_pokergrpc_poker_proto
+
+	typeName := func(fld protoreflect.FieldDescriptor) string {
+		switch {
+		case fld.Enum() != nil:
+			return string(fld.Enum().Name())
+		case fld.Message() != nil:
+			return string(fld.Message().Name())
+		}
+		return fld.Kind().String()
+	}
+
+	var kind, name string
+	counts := map[string]int{}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		if m := protoBlock.FindStringSubmatch(line); m != nil {
+			kind, name = m[1], m[2]
+			counts[kind]++
+			continue
+		}
+		switch kind {
+		case "message":
+			m := protoField.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			md := fd.Messages().ByName(protoreflect.Name(name))
+			require.NotNil(t, md, "message %s", name)
+			fld := md.Fields().ByName(protoreflect.Name(m[3]))
+			require.NotNil(t, fld, "%s.%s", name, m[3])
+			num, _ := strconv.Atoi(m[4])
+			assert.EqualValues(t, num, fld.Number(), "%s.%s", name, m[3])
+			assert.Equal(t, m[1] != "", fld.IsList(), "%s.%s repeated", name, m[3])
+			assert.Equal(t, m[2], typeName(fld), "%s.%s type", name, m[3])
+			counts[name]++
+		case "enum":
+			m := protoValue.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			ed := fd.Enums().ByName(protoreflect.Name(name))
+			require.NotNil(t, ed, "enum %s", name)
+			v := ed.Values().ByName(protoreflect.Name(m[1]))
+			require.NotNil(t, v, "%s.%s", name, m[1])
+			num, _ := strconv.Atoi(m[2])
+			assert.EqualValues(t, num, v.Number(), "%s.%s", name, m[1])
+			counts[name]++
+		case "service":
+			m := protoRPC.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			sd := fd.Services().ByName(protoreflect.Name(name))
+			require.NotNil(t, sd, "service %s", name)
+			md := sd.Methods().ByName(protoreflect.Name(m[1]))
+			require.NotNil(t, md, "%s.%s", name, m[1])
+			assert.Equal(t, m[3], string(md.Input().Name()), "%s.%s input", name, m[1])
+			assert.Equal(t, m[5], string(md.Output().Name()), "%s.%s output", name, m[1])
+			assert.Equal(t, m[2] != "", md.IsStreamingClient(), "%s.%s client streaming", name, m[1])
+			assert.Equal(t, m[4] != "", md.IsStreamingServer(), "%s.%s server streaming", name, m[1])
+			counts[name]++
+		}
+	}
+	require.NoError(t, sc.Err())
+
+	// nothing was dropped from the file either
+	assert.Equal(t, fd.Messages().Len(), counts["message"])
+	assert.Equal(t, fd.Enums().Len(), counts["enum"])
+	assert.Equal(t, fd.Services().Len(), counts["service"])
+	for i := 0; i < fd.Messages().Len(); i++ {
+		md := fd.Messages().Get(i)
+		assert.Equal(t, md.Fields().Len(), counts[string(md.Name())], "fields of %s", md.Name())
+	}
+	for i := 0; i < fd.Enums().Len(); i++ {
+		ed := fd.Enums().Get(i)
+		assert.Equal(t, ed.Values().Len(), counts[string(ed.Name())], "values of %s", ed.Name())
+	}
+	for i := 0; i < fd.Services().Len(); i++ {
+		sd := fd.Services().Get(i)
+		assert.Equal(t, sd.Methods().Len(), counts[string(sd.Name())], "methods of %s", sd.Name())
+	}
+}
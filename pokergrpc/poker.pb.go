@@ -0,0 +1,745 @@
+// The poker evaluation service. This file is the contract for clients in other
+// languages; poker.pb.go and poker_grpc.pb.go are generated from it with
+// go generate ./pokergrpc.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        (unknown)
+// source: pokergrpc/poker.proto
+
+package pokergrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Rank values are the card's pip value, so RANK_ACE is 14.
+type Rank int32
+
+const (
+	Rank_RANK_UNSPECIFIED Rank = 0
+	Rank_RANK_TWO         Rank = 2
+	Rank_RANK_THREE       Rank = 3
+	Rank_RANK_FOUR        Rank = 4
+	Rank_RANK_FIVE        Rank = 5
+	Rank_RANK_SIX         Rank = 6
+	Rank_RANK_SEVEN       Rank = 7
+	Rank_RANK_EIGHT       Rank = 8
+	Rank_RANK_NINE        Rank = 9
+	Rank_RANK_TEN         Rank = 10
+	Rank_RANK_JACK        Rank = 11
+	Rank_RANK_QUEEN       Rank = 12
+	Rank_RANK_KING        Rank = 13
+	Rank_RANK_ACE         Rank = 14
+)
+
+// Enum value maps for Rank.
+var (
+	Rank_name = map[int32]string{
+		0:  "RANK_UNSPECIFIED",
+		2:  "RANK_TWO",
+		3:  "RANK_THREE",
+		4:  "RANK_FOUR",
+		5:  "RANK_FIVE",
+		6:  "RANK_SIX",
+		7:  "RANK_SEVEN",
+		8:  "RANK_EIGHT",
+		9:  "RANK_NINE",
+		10: "RANK_TEN",
+		11: "RANK_JACK",
+		12: "RANK_QUEEN",
+		13: "RANK_KING",
+		14: "RANK_ACE",
+	}
+	Rank_value = map[string]int32{
+		"RANK_UNSPECIFIED": 0,
+		"RANK_TWO":         2,
+		"RANK_THREE":       3,
+		"RANK_FOUR":        4,
+		"RANK_FIVE":        5,
+		"RANK_SIX":         6,
+		"RANK_SEVEN":       7,
+		"RANK_EIGHT":       8,
+		"RANK_NINE":        9,
+		"RANK_TEN":         10,
+		"RANK_JACK":        11,
+		"RANK_QUEEN":       12,
+		"RANK_KING":        13,
+		"RANK_ACE":         14,
+	}
+)
+
+func (x Rank) Enum() *Rank {
+	p := new(Rank)
+	*p = x
+	return p
+}
+
+func (x Rank) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Rank) Descriptor() protoreflect.EnumDescriptor {
+	return file_pokergrpc_poker_proto_enumTypes[0].Descriptor()
+}
+
+func (Rank) Type() protoreflect.EnumType {
+	return &file_pokergrpc_poker_proto_enumTypes[0]
+}
+
+func (x Rank) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Rank.Descriptor instead.
+func (Rank) EnumDescriptor() ([]byte, []int) {
+	return file_pokergrpc_poker_proto_rawDescGZIP(), []int{0}
+}
+
+type Suit int32
+
+const (
+	Suit_SUIT_UNSPECIFIED Suit = 0
+	Suit_SUIT_CLUBS       Suit = 1
+	Suit_SUIT_DIAMONDS    Suit = 2
+	Suit_SUIT_HEARTS      Suit = 3
+	Suit_SUIT_SPADES      Suit = 4
+)
+
+// Enum value maps for Suit.
+var (
+	Suit_name = map[int32]string{
+		0: "SUIT_UNSPECIFIED",
+		1: "SUIT_CLUBS",
+		2: "SUIT_DIAMONDS",
+		3: "SUIT_HEARTS",
+		4: "SUIT_SPADES",
+	}
+	Suit_value = map[string]int32{
+		"SUIT_UNSPECIFIED": 0,
+		"SUIT_CLUBS":       1,
+		"SUIT_DIAMONDS":    2,
+		"SUIT_HEARTS":      3,
+		"SUIT_SPADES":      4,
+	}
+)
+
+func (x Suit) Enum() *Suit {
+	p := new(Suit)
+	*p = x
+	return p
+}
+
+func (x Suit) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Suit) Descriptor() protoreflect.EnumDescriptor {
+	return file_pokergrpc_poker_proto_enumTypes[1].Descriptor()
+}
+
+func (Suit) Type() protoreflect.EnumType {
+	return &file_pokergrpc_poker_proto_enumTypes[1]
+}
+
+func (x Suit) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Suit.Descriptor instead.
+func (Suit) EnumDescriptor() ([]byte, []int) {
+	return file_pokergrpc_poker_proto_rawDescGZIP(), []int{1}
+}
+
+// Category values follow hand.Category, weakest first, so they compare numerically.
+type Category int32
+
+const (
+	Category_CATEGORY_HIGH_CARD       Category = 0
+	Category_CATEGORY_ONE_PAIR        Category = 1
+	Category_CATEGORY_TWO_PAIR        Category = 2
+	Category_CATEGORY_THREE_OF_A_KIND Category = 3
+	Category_CATEGORY_STRAIGHT        Category = 4
+	Category_CATEGORY_FLUSH           Category = 5
+	Category_CATEGORY_FULL_HOUSE      Category = 6
+	Category_CATEGORY_FOUR_OF_A_KIND  Category = 7
+	Category_CATEGORY_STRAIGHT_FLUSH  Category = 8
+)
+
+// Enum value maps for Category.
+var (
+	Category_name = map[int32]string{
+		0: "CATEGORY_HIGH_CARD",
+		1: "CATEGORY_ONE_PAIR",
+		2: "CATEGORY_TWO_PAIR",
+		3: "CATEGORY_THREE_OF_A_KIND",
+		4: "CATEGORY_STRAIGHT",
+		5: "CATEGORY_FLUSH",
+		6: "CATEGORY_FULL_HOUSE",
+		7: "CATEGORY_FOUR_OF_A_KIND",
+		8: "CATEGORY_STRAIGHT_FLUSH",
+	}
+	Category_value = map[string]int32{
+		"CATEGORY_HIGH_CARD":       0,
+		"CATEGORY_ONE_PAIR":        1,
+		"CATEGORY_TWO_PAIR":        2,
+		"CATEGORY_THREE_OF_A_KIND": 3,
+		"CATEGORY_STRAIGHT":        4,
+		"CATEGORY_FLUSH":           5,
+		"CATEGORY_FULL_HOUSE":      6,
+		"CATEGORY_FOUR_OF_A_KIND":  7,
+		"CATEGORY_STRAIGHT_FLUSH":  8,
+	}
+)
+
+func (x Category) Enum() *Category {
+	p := new(Category)
+	*p = x
+	return p
+}
+
+func (x Category) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Category) Descriptor() protoreflect.EnumDescriptor {
+	return file_pokergrpc_poker_proto_enumTypes[2].Descriptor()
+}
+
+func (Category) Type() protoreflect.EnumType {
+	return &file_pokergrpc_poker_proto_enumTypes[2]
+}
+
+func (x Category) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Category.Descriptor instead.
+func (Category) EnumDescriptor() ([]byte, []int) {
+	return file_pokergrpc_poker_proto_rawDescGZIP(), []int{2}
+}
+
+type Card struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rank          Rank                   `protobuf:"varint,1,opt,name=rank,proto3,enum=gopoker.v1.Rank" json:"rank,omitempty"`
+	Suit          Suit                   `protobuf:"varint,2,opt,name=suit,proto3,enum=gopoker.v1.Suit" json:"suit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Card) Reset() {
+	*x = Card{}
+	mi := &file_pokergrpc_poker_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Card) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Card) ProtoMessage() {}
+
+func (x *Card) ProtoReflect() protoreflect.Message {
+	mi := &file_pokergrpc_poker_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Card.ProtoReflect.Descriptor instead.
+func (*Card) Descriptor() ([]byte, []int) {
+	return file_pokergrpc_poker_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Card) GetRank() Rank {
+	if x != nil {
+		return x.Rank
+	}
+	return Rank_RANK_UNSPECIFIED
+}
+
+func (x *Card) GetSuit() Suit {
+	if x != nil {
+		return x.Suit
+	}
+	return Suit_SUIT_UNSPECIFIED
+}
+
+// Hand is five cards. Id is echoed back in results so streamed and compared hands can
+// be matched up by the caller.
+type Hand struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cards         []*Card                `protobuf:"bytes,1,rep,name=cards,proto3" json:"cards,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hand) Reset() {
+	*x = Hand{}
+	mi := &file_pokergrpc_poker_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hand) ProtoMessage() {}
+
+func (x *Hand) ProtoReflect() protoreflect.Message {
+	mi := &file_pokergrpc_poker_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hand.ProtoReflect.Descriptor instead.
+func (*Hand) Descriptor() ([]byte, []int) {
+	return file_pokergrpc_poker_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Hand) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *Hand) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type EvaluatedHand struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Category Category               `protobuf:"varint,2,opt,name=category,proto3,enum=gopoker.v1.Category" json:"category,omitempty"`
+	// Tiebreak ranks, most significant first.
+	Ranks []Rank `protobuf:"varint,3,rep,packed,name=ranks,proto3,enum=gopoker.v1.Rank" json:"ranks,omitempty"`
+	// For example "Kings and Sevens, 10 kicker".
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// 1 for a royal flush down to 7462 among the distinct five-card hands.
+	AbsoluteRank int32 `protobuf:"varint,5,opt,name=absolute_rank,json=absoluteRank,proto3" json:"absolute_rank,omitempty"`
+	// Fraction of dealt five-card hands that are strictly weaker.
+	Beats         float64 `protobuf:"fixed64,6,opt,name=beats,proto3" json:"beats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluatedHand) Reset() {
+	*x = EvaluatedHand{}
+	mi := &file_pokergrpc_poker_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluatedHand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluatedHand) ProtoMessage() {}
+
+func (x *EvaluatedHand) ProtoReflect() protoreflect.Message {
+	mi := &file_pokergrpc_poker_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluatedHand.ProtoReflect.Descriptor instead.
+func (*EvaluatedHand) Descriptor() ([]byte, []int) {
+	return file_pokergrpc_poker_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EvaluatedHand) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EvaluatedHand) GetCategory() Category {
+	if x != nil {
+		return x.Category
+	}
+	return Category_CATEGORY_HIGH_CARD
+}
+
+func (x *EvaluatedHand) GetRanks() []Rank {
+	if x != nil {
+		return x.Ranks
+	}
+	return nil
+}
+
+func (x *EvaluatedHand) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *EvaluatedHand) GetAbsoluteRank() int32 {
+	if x != nil {
+		return x.AbsoluteRank
+	}
+	return 0
+}
+
+func (x *EvaluatedHand) GetBeats() float64 {
+	if x != nil {
+		return x.Beats
+	}
+	return 0
+}
+
+type CompareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hands         []*Hand                `protobuf:"bytes,1,rep,name=hands,proto3" json:"hands,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareRequest) Reset() {
+	*x = CompareRequest{}
+	mi := &file_pokergrpc_poker_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareRequest) ProtoMessage() {}
+
+func (x *CompareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pokergrpc_poker_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareRequest.ProtoReflect.Descriptor instead.
+func (*CompareRequest) Descriptor() ([]byte, []int) {
+	return file_pokergrpc_poker_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CompareRequest) GetHands() []*Hand {
+	if x != nil {
+		return x.Hands
+	}
+	return nil
+}
+
+type Placing struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position of the hand in the request.
+	Index int32          `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Hand  *EvaluatedHand `protobuf:"bytes,2,opt,name=hand,proto3" json:"hand,omitempty"`
+	// 1 for the winners; tied hands share a place.
+	Place         int32 `protobuf:"varint,3,opt,name=place,proto3" json:"place,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Placing) Reset() {
+	*x = Placing{}
+	mi := &file_pokergrpc_poker_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Placing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Placing) ProtoMessage() {}
+
+func (x *Placing) ProtoReflect() protoreflect.Message {
+	mi := &file_pokergrpc_poker_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Placing.ProtoReflect.Descriptor instead.
+func (*Placing) Descriptor() ([]byte, []int) {
+	return file_pokergrpc_poker_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Placing) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Placing) GetHand() *EvaluatedHand {
+	if x != nil {
+		return x.Hand
+	}
+	return nil
+}
+
+func (x *Placing) GetPlace() int32 {
+	if x != nil {
+		return x.Place
+	}
+	return 0
+}
+
+type CompareResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Strongest hand first.
+	Placings      []*Placing `protobuf:"bytes,1,rep,name=placings,proto3" json:"placings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareResponse) Reset() {
+	*x = CompareResponse{}
+	mi := &file_pokergrpc_poker_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareResponse) ProtoMessage() {}
+
+func (x *CompareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pokergrpc_poker_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareResponse.ProtoReflect.Descriptor instead.
+func (*CompareResponse) Descriptor() ([]byte, []int) {
+	return file_pokergrpc_poker_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CompareResponse) GetPlacings() []*Placing {
+	if x != nil {
+		return x.Placings
+	}
+	return nil
+}
+
+var File_pokergrpc_poker_proto protoreflect.FileDescriptor
+
+var file_pokergrpc_poker_proto_rawDesc = string([]byte{
+	0x0a, 0x15, 0x70, 0x6f, 0x6b, 0x65, 0x72, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x6f, 0x6b, 0x65,
+	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x67, 0x6f, 0x70, 0x6f, 0x6b, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x22, 0x52, 0x0a, 0x04, 0x43, 0x61, 0x72, 0x64, 0x12, 0x24, 0x0a, 0x04, 0x72,
+	0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x67, 0x6f, 0x70, 0x6f,
+	0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x04, 0x72, 0x61, 0x6e,
+	0x6b, 0x12, 0x24, 0x0a, 0x04, 0x73, 0x75, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x10, 0x2e, 0x67, 0x6f, 0x70, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x69,
+	0x74, 0x52, 0x04, 0x73, 0x75, 0x69, 0x74, 0x22, 0x3e, 0x0a, 0x04, 0x48, 0x61, 0x6e, 0x64, 0x12,
+	0x26, 0x0a, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x67, 0x6f, 0x70, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x64,
+	0x52, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xd6, 0x01, 0x0a, 0x0d, 0x45, 0x76, 0x61, 0x6c,
+	0x75, 0x61, 0x74, 0x65, 0x64, 0x48, 0x61, 0x6e, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x08, 0x63, 0x61, 0x74,
+	0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x67, 0x6f,
+	0x70, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
+	0x79, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x26, 0x0a, 0x05, 0x72,
+	0x61, 0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x67, 0x6f, 0x70,
+	0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x05, 0x72, 0x61,
+	0x6e, 0x6b, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x62, 0x73, 0x6f, 0x6c, 0x75, 0x74,
+	0x65, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x61, 0x62,
+	0x73, 0x6f, 0x6c, 0x75, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x65,
+	0x61, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x62, 0x65, 0x61, 0x74, 0x73,
+	0x22, 0x38, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x68, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x67, 0x6f, 0x70, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48,
+	0x61, 0x6e, 0x64, 0x52, 0x05, 0x68, 0x61, 0x6e, 0x64, 0x73, 0x22, 0x64, 0x0a, 0x07, 0x50, 0x6c,
+	0x61, 0x63, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2d, 0x0a, 0x04, 0x68,
+	0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x70, 0x6f,
+	0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x64,
+	0x48, 0x61, 0x6e, 0x64, 0x52, 0x04, 0x68, 0x61, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x22, 0x42, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x6f, 0x70, 0x6f, 0x6b, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x63,
+	0x69, 0x6e, 0x67, 0x73, 0x2a, 0xdf, 0x01, 0x0a, 0x04, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x14, 0x0a,
+	0x10, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x54, 0x57, 0x4f, 0x10,
+	0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x54, 0x48, 0x52, 0x45, 0x45, 0x10,
+	0x03, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x46, 0x4f, 0x55, 0x52, 0x10, 0x04,
+	0x12, 0x0d, 0x0a, 0x09, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x46, 0x49, 0x56, 0x45, 0x10, 0x05, 0x12,
+	0x0c, 0x0a, 0x08, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x53, 0x49, 0x58, 0x10, 0x06, 0x12, 0x0e, 0x0a,
+	0x0a, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x53, 0x45, 0x56, 0x45, 0x4e, 0x10, 0x07, 0x12, 0x0e, 0x0a,
+	0x0a, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x45, 0x49, 0x47, 0x48, 0x54, 0x10, 0x08, 0x12, 0x0d, 0x0a,
+	0x09, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x4e, 0x49, 0x4e, 0x45, 0x10, 0x09, 0x12, 0x0c, 0x0a, 0x08,
+	0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x54, 0x45, 0x4e, 0x10, 0x0a, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x41,
+	0x4e, 0x4b, 0x5f, 0x4a, 0x41, 0x43, 0x4b, 0x10, 0x0b, 0x12, 0x0e, 0x0a, 0x0a, 0x52, 0x41, 0x4e,
+	0x4b, 0x5f, 0x51, 0x55, 0x45, 0x45, 0x4e, 0x10, 0x0c, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x41, 0x4e,
+	0x4b, 0x5f, 0x4b, 0x49, 0x4e, 0x47, 0x10, 0x0d, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x41, 0x4e, 0x4b,
+	0x5f, 0x41, 0x43, 0x45, 0x10, 0x0e, 0x2a, 0x61, 0x0a, 0x04, 0x53, 0x75, 0x69, 0x74, 0x12, 0x14,
+	0x0a, 0x10, 0x53, 0x55, 0x49, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x55, 0x49, 0x54, 0x5f, 0x43, 0x4c, 0x55,
+	0x42, 0x53, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x55, 0x49, 0x54, 0x5f, 0x44, 0x49, 0x41,
+	0x4d, 0x4f, 0x4e, 0x44, 0x53, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x55, 0x49, 0x54, 0x5f,
+	0x48, 0x45, 0x41, 0x52, 0x54, 0x53, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x55, 0x49, 0x54,
+	0x5f, 0x53, 0x50, 0x41, 0x44, 0x45, 0x53, 0x10, 0x04, 0x2a, 0xec, 0x01, 0x0a, 0x08, 0x43, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x12, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f,
+	0x52, 0x59, 0x5f, 0x48, 0x49, 0x47, 0x48, 0x5f, 0x43, 0x41, 0x52, 0x44, 0x10, 0x00, 0x12, 0x15,
+	0x0a, 0x11, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x4f, 0x4e, 0x45, 0x5f, 0x50,
+	0x41, 0x49, 0x52, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52,
+	0x59, 0x5f, 0x54, 0x57, 0x4f, 0x5f, 0x50, 0x41, 0x49, 0x52, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18,
+	0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x54, 0x48, 0x52, 0x45, 0x45, 0x5f, 0x4f,
+	0x46, 0x5f, 0x41, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x41,
+	0x54, 0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x49, 0x47, 0x48, 0x54, 0x10,
+	0x04, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x46, 0x4c,
+	0x55, 0x53, 0x48, 0x10, 0x05, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52,
+	0x59, 0x5f, 0x46, 0x55, 0x4c, 0x4c, 0x5f, 0x48, 0x4f, 0x55, 0x53, 0x45, 0x10, 0x06, 0x12, 0x1b,
+	0x0a, 0x17, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x46, 0x4f, 0x55, 0x52, 0x5f,
+	0x4f, 0x46, 0x5f, 0x41, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x10, 0x07, 0x12, 0x1b, 0x0a, 0x17, 0x43,
+	0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x49, 0x47, 0x48, 0x54,
+	0x5f, 0x46, 0x4c, 0x55, 0x53, 0x48, 0x10, 0x08, 0x32, 0xcb, 0x01, 0x0a, 0x09, 0x45, 0x76, 0x61,
+	0x6c, 0x75, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x37, 0x0a, 0x08, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61,
+	0x74, 0x65, 0x12, 0x10, 0x2e, 0x67, 0x6f, 0x70, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x48, 0x61, 0x6e, 0x64, 0x1a, 0x19, 0x2e, 0x67, 0x6f, 0x70, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x64, 0x48, 0x61, 0x6e, 0x64, 0x12,
+	0x42, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x12, 0x1a, 0x2e, 0x67, 0x6f, 0x70,
+	0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x67, 0x6f, 0x70, 0x6f, 0x6b, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x10, 0x2e, 0x67, 0x6f, 0x70, 0x6f, 0x6b, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x1a, 0x19, 0x2e, 0x67, 0x6f, 0x70, 0x6f, 0x6b, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x64, 0x48, 0x61,
+	0x6e, 0x64, 0x28, 0x01, 0x30, 0x01, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6e, 0x67, 0x6f, 0x67, 0x68, 0x2f, 0x47, 0x6f, 0x50,
+	0x6f, 0x6b, 0x65, 0x72, 0x2f, 0x70, 0x6f, 0x6b, 0x65, 0x72, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_pokergrpc_poker_proto_rawDescOnce sync.Once
+	file_pokergrpc_poker_proto_rawDescData []byte
+)
+
+func file_pokergrpc_poker_proto_rawDescGZIP() []byte {
+	file_pokergrpc_poker_proto_rawDescOnce.Do(func() {
+		file_pokergrpc_poker_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pokergrpc_poker_proto_rawDesc), len(file_pokergrpc_poker_proto_rawDesc)))
+	})
+	return file_pokergrpc_poker_proto_rawDescData
+}
+
+var file_pokergrpc_poker_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_pokergrpc_poker_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_pokergrpc_poker_proto_goTypes = []any{
+	(Rank)(0),               // 0: gopoker.v1.Rank
+	(Suit)(0),               // 1: gopoker.v1.Suit
+	(Category)(0),           // 2: gopoker.v1.Category
+	(*Card)(nil),            // 3: gopoker.v1.Card
+	(*Hand)(nil),            // 4: gopoker.v1.Hand
+	(*EvaluatedHand)(nil),   // 5: gopoker.v1.EvaluatedHand
+	(*CompareRequest)(nil),  // 6: gopoker.v1.CompareRequest
+	(*Placing)(nil),         // 7: gopoker.v1.Placing
+	(*CompareResponse)(nil), // 8: gopoker.v1.CompareResponse
+}
+var file_pokergrpc_poker_proto_depIdxs = []int32{
+	0,  // 0: gopoker.v1.Card.rank:type_name -> gopoker.v1.Rank
+	1,  // 1: gopoker.v1.Card.suit:type_name -> gopoker.v1.Suit
+	3,  // 2: gopoker.v1.Hand.cards:type_name -> gopoker.v1.Card
+	2,  // 3: gopoker.v1.EvaluatedHand.category:type_name -> gopoker.v1.Category
+	0,  // 4: gopoker.v1.EvaluatedHand.ranks:type_name -> gopoker.v1.Rank
+	4,  // 5: gopoker.v1.CompareRequest.hands:type_name -> gopoker.v1.Hand
+	5,  // 6: gopoker.v1.Placing.hand:type_name -> gopoker.v1.EvaluatedHand
+	7,  // 7: gopoker.v1.CompareResponse.placings:type_name -> gopoker.v1.Placing
+	4,  // 8: gopoker.v1.Evaluator.Evaluate:input_type -> gopoker.v1.Hand
+	6,  // 9: gopoker.v1.Evaluator.Compare:input_type -> gopoker.v1.CompareRequest
+	4,  // 10: gopoker.v1.Evaluator.EvaluateStream:input_type -> gopoker.v1.Hand
+	5,  // 11: gopoker.v1.Evaluator.Evaluate:output_type -> gopoker.v1.EvaluatedHand
+	8,  // 12: gopoker.v1.Evaluator.Compare:output_type -> gopoker.v1.CompareResponse
+	5,  // 13: gopoker.v1.Evaluator.EvaluateStream:output_type -> gopoker.v1.EvaluatedHand
+	11, // [11:14] is the sub-list for method output_type
+	8,  // [8:11] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_pokergrpc_poker_proto_init() }
+func file_pokergrpc_poker_proto_init() {
+	if File_pokergrpc_poker_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pokergrpc_poker_proto_rawDesc), len(file_pokergrpc_poker_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pokergrpc_poker_proto_goTypes,
+		DependencyIndexes: file_pokergrpc_poker_proto_depIdxs,
+		EnumInfos:         file_pokergrpc_poker_proto_enumTypes,
+		MessageInfos:      file_pokergrpc_poker_proto_msgTypes,
+	}.Build()
+	File_pokergrpc_poker_proto = out.File
+	file_pokergrpc_poker_proto_goTypes = nil
+	file_pokergrpc_poker_proto_depIdxs = nil
+}
@@ -0,0 +1,133 @@
+// Package pokergrpc serves the hand evaluator over gRPC, so Go services can evaluate and
+// compare five-card hands across process boundaries. poker.proto defines the service;
+// NewServer builds a server for it and Client calls it.
+//
+// Evaluate and Compare are unary calls. EvaluateStream evaluates a stream of hands over
+// one call, which avoids a round trip per hand when scoring large batches.
+package pokergrpc
+
+//go:generate protoc -I.. --go_out=.. --go_opt=paths=source_relative --go-grpc_out=.. --go-grpc_opt=paths=source_relative ../pokergrpc/poker.proto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dangogh/GoPoker/cards"
+	"github.com/dangogh/GoPoker/hand"
+)
+
+// MaxCompare bounds the hands in one Compare call.
+const MaxCompare = 10
+
+// NewServer returns a gRPC server with the evaluator registered. The options are passed
+// on to grpc.NewServer, e.g. for TLS or interceptors; other services, such as health
+// checks or reflection, can be registered on it too.
+func NewServer(opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(opts...)
+	RegisterEvaluatorServer(s, evaluator{})
+	return s
+}
+
+// evaluator implements the service with the hand package.
+type evaluator struct {
+	UnimplementedEvaluatorServer
+}
+
+func (evaluator) Evaluate(_ context.Context, h *Hand) (*EvaluatedHand, error) {
+	cs, err := h.cards(nil)
+	if err != nil {
+		return nil, invalidHand(h, err)
+	}
+	return evaluate(h.GetId(), hand.Evaluate(hand.Hand{Cards: cs})), nil
+}
+
+func (evaluator) Compare(_ context.Context, req *CompareRequest) (*CompareResponse, error) {
+	if len(req.Hands) < 2 || len(req.Hands) > MaxCompare {
+		return nil, status.Errorf(codes.InvalidArgument, "2 to %d hands are required, got %d", MaxCompare, len(req.Hands))
+	}
+	seen := map[cards.Card]bool{}
+	evals := make([]hand.EvaluatedHand, len(req.Hands))
+	for i, h := range req.Hands {
+		cs, err := h.cards(seen)
+		if err != nil {
+			return nil, invalidHand(h, err)
+		}
+		evals[i] = hand.Evaluate(hand.Hand{Cards: cs})
+	}
+
+	resp := &CompareResponse{}
+	for i, place := range hand.Places(evals) {
+		resp.Placings = append(resp.Placings, &Placing{Index: int32(i), Hand: evaluate(req.Hands[i].GetId(), evals[i]), Place: int32(place)})
+	}
+	sort.SliceStable(resp.Placings, func(a, b int) bool { return resp.Placings[a].Place < resp.Placings[b].Place })
+	return resp, nil
+}
+
+func (e evaluator) EvaluateStream(stream grpc.BidiStreamingServer[Hand, EvaluatedHand]) error {
+	for {
+		h, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		out, err := e.Evaluate(stream.Context(), h)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(out); err != nil {
+			return err
+		}
+	}
+}
+
+// cards validates a hand: five valid cards, none repeated here or, with seen, in the
+// other hands of the same request.
+func (h *Hand) cards(seen map[cards.Card]bool) ([]cards.Card, error) {
+	if len(h.GetCards()) != 5 {
+		return nil, fmt.Errorf("exactly 5 cards are required, got %d", len(h.GetCards()))
+	}
+	if seen == nil {
+		seen = map[cards.Card]bool{}
+	}
+	out := make([]cards.Card, 5)
+	for i, wc := range h.GetCards() {
+		c, err := wc.Card()
+		if err != nil {
+			return nil, fmt.Errorf("card %d: %w", i, err)
+		}
+		if seen[c] {
+			return nil, fmt.Errorf("card %s appears more than once", c)
+		}
+		seen[c] = true
+		out[i] = c
+	}
+	return out, nil
+}
+
+func invalidHand(h *Hand, err error) error {
+	if h.GetId() != "" {
+		return status.Errorf(codes.InvalidArgument, "hand %q: %v", h.GetId(), err)
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+func evaluate(id string, e hand.EvaluatedHand) *EvaluatedHand {
+	out := &EvaluatedHand{Id: id, Category: Category(e.Category), Description: hand.Describe(e)}
+	for _, r := range e.Ranks {
+		out.Ranks = append(out.Ranks, Rank(r))
+	}
+	// every valid five-card hand has a strength, so the error cannot happen here
+	if st, err := hand.StrengthOf(e); err == nil {
+		out.AbsoluteRank, out.Beats = int32(st.Rank), st.Beats
+	}
+	return out
+}
@@ -0,0 +1,146 @@
+package pokergrpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/dangogh/GoPoker/cards"
+)
+
+// newTestClient serves the evaluator in-process over an in-memory listener.
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	return NewClient(serveTest(t, NewServer()))
+}
+
+// serveTest serves srv over an in-memory listener and connects to it.
+func serveTest(t *testing.T, srv *grpc.Server) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	cc, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { cc.Close() })
+	return cc
+}
+
+func mustHand(t *testing.T, id, s string) *Hand {
+	t.Helper()
+	cs, err := cards.ParseList(s)
+	require.NoError(t, err)
+	return HandOf(id, cs)
+}
+
+func TestEvaluate(t *testing.T) {
+	c := newTestClient(t)
+	e, err := c.Evaluate(context.Background(), mustHand(t, "kk77", "Kh Kd 7c 7s Th"))
+	require.NoError(t, err)
+	assert.Equal(t, "kk77", e.GetId())
+	assert.Equal(t, Category_CATEGORY_TWO_PAIR, e.Category)
+	assert.Equal(t, []Rank{13, 7, 10}, e.Ranks)
+	assert.Equal(t, "Kings and Sevens, 10 kicker", e.Description)
+	assert.Positive(t, e.AbsoluteRank)
+	assert.Greater(t, e.Beats, 0.9)
+}
+
+func TestEvaluateRejectsBadHands(t *testing.T) {
+	c := newTestClient(t)
+	tests := []struct {
+		hand *Hand
+		want string
+	}{
+		{mustHand(t, "", "As Kd"), "exactly 5 cards"},
+		{&Hand{Id: "bad", Cards: []*Card{{Rank: 14, Suit: 4}, {Rank: 13, Suit: 4}, {Rank: 12, Suit: 4}, {Rank: 11, Suit: 4}, {Rank: 10}}}, `hand "bad": card 4: invalid suit 0`},
+		{&Hand{Cards: []*Card{{Rank: 14, Suit: 4}, {Rank: 14, Suit: 4}, {Rank: 12, Suit: 4}, {Rank: 11, Suit: 4}, {Rank: 10, Suit: 4}}}, "appears more than once"},
+	}
+	for _, tt := range tests {
+		_, err := c.Evaluate(context.Background(), tt.hand)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), tt.want)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	c := newTestClient(t)
+	resp, err := c.Compare(context.Background(), &CompareRequest{Hands: []*Hand{
+		mustHand(t, "low", "2c 3d 4h 5s 7c"),
+		mustHand(t, "aakk", "Ac Ad Kh Ks 2d"),
+		mustHand(t, "aakk2", "Ah As Kc Kd 2h"),
+	}})
+	require.NoError(t, err)
+	require.Len(t, resp.Placings, 3)
+	var got []string
+	for _, p := range resp.Placings {
+		got = append(got, fmt.Sprintf("%d:%s@%d", p.Place, p.Hand.GetId(), p.Index))
+	}
+	assert.Equal(t, []string{"1:aakk@1", "1:aakk2@2", "3:low@0"}, got)
+
+	_, err = c.Compare(context.Background(), &CompareRequest{Hands: []*Hand{mustHand(t, "a", "2c 3d 4h 5s 7c")}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = c.Compare(context.Background(), &CompareRequest{Hands: []*Hand{
+		mustHand(t, "a", "2c 3d 4h 5s 7c"), mustHand(t, "b", "2c 3h 4d 5c 7d"),
+	}})
+	assert.ErrorContains(t, err, `hand "b": card 2♣ appears more than once`)
+}
+
+func TestEvaluateAll(t *testing.T) {
+	c := newTestClient(t)
+	var hands []*Hand
+	for i := 0; i < 500; i++ {
+		hands = append(hands, mustHand(t, fmt.Sprint(i), "As Ks Qs Js Ts"))
+		hands = append(hands, mustHand(t, fmt.Sprint(i), "7c 5d 4h 3s 2c"))
+	}
+	out, err := c.EvaluateAll(context.Background(), hands)
+	require.NoError(t, err)
+	require.Len(t, out, len(hands))
+	for i, e := range out {
+		assert.Equal(t, hands[i].GetId(), e.GetId())
+	}
+	assert.Equal(t, int32(1), out[0].AbsoluteRank)
+	assert.Equal(t, int32(7462), out[1].AbsoluteRank)
+
+	hands[3] = &Hand{Id: "broken"}
+	_, err = c.EvaluateAll(context.Background(), hands)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), `hand "broken"`)
+}
+
+func TestInterceptorSeesUnaryCalls(t *testing.T) {
+	var methods []string
+	cc := serveTest(t, NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, h grpc.UnaryHandler) (any, error) {
+		methods = append(methods, info.FullMethod)
+		return h(ctx, req)
+	})))
+
+	_, err := NewClient(cc).Evaluate(context.Background(), mustHand(t, "", "As Ks Qs Js Ts"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{Evaluator_Evaluate_FullMethodName}, methods)
+}
+
+func TestServerHostsOtherServices(t *testing.T) {
+	srv := NewServer()
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	cc := serveTest(t, srv)
+
+	resp, err := healthpb.NewHealthClient(cc).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	_, err = NewClient(cc).Evaluate(context.Background(), mustHand(t, "", "As Ks Qs Js Ts"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,254 @@
+// Package table runs a session of consecutive hands at one table: it keeps seats and
+// chip stacks between hands, moves the dealer button, shuffles a fresh deck for every
+// hand, removes players who bust and lets new ones sit down between hands.
+package table
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+
+	"github.com/dangogh/GoPoker/deck"
+	"github.com/dangogh/GoPoker/game"
+)
+
+// ErrTableFull is returned by Sit when every seat is taken.
+var ErrTableFull = errors.New("table is full")
+
+// Snapshot records the stacks after one hand, keyed by player name.
+type Snapshot struct {
+	Hand   int            `json:"hand"`
+	Button string         `json:"button"`
+	Stacks map[string]int `json:"stacks"`
+}
+
+// Bust records a player who lost their last chip.
+type Bust struct {
+	Name string `json:"name"`
+	Hand int    `json:"hand"`
+	// Start is the stack they began the losing hand with; when several players bust
+	// in the same hand the bigger starting stack finishes ahead.
+	Start int `json:"start"`
+}
+
+// Table is a session. Seat numbers are stable: a player keeps their seat until they
+// leave or bust, and empty seats are simply skipped by the button and the blinds.
+type Table struct {
+	cfg     game.Config
+	rng     *rand.Rand
+	seats   []*game.Seat // len game.MaxSeats; empty seats have no Player
+	button  int          // -1 until the first hand
+	hands   int
+	names   []string // everyone who ever sat, in order, for reports
+	history []Snapshot
+	busts   []Bust
+}
+
+// New creates an empty table. The rng shuffles every deck and picks the first button,
+// so a seeded rng replays the same session.
+func New(cfg game.Config, rng *rand.Rand) *Table {
+	t := &Table{cfg: cfg, rng: rng, button: -1, seats: make([]*game.Seat, game.MaxSeats)}
+	for i := range t.seats {
+		t.seats[i] = &game.Seat{}
+	}
+	return t
+}
+
+// Sit puts a player in the given seat, or the first free one if seat is -1, and returns
+// the seat number. Players who sit down between hands are dealt into the next one.
+func (t *Table) Sit(seat int, name string, stack int, p game.Player) (int, error) {
+	if name == "" || p == nil {
+		return 0, fmt.Errorf("a player needs a name and a Player")
+	}
+	if stack <= 0 {
+		return 0, fmt.Errorf("%s: stack must be > 0", name)
+	}
+	if t.Seat(name) >= 0 {
+		return 0, fmt.Errorf("%s is already seated", name)
+	}
+	if seat == -1 {
+		for i, s := range t.seats {
+			if s.Player == nil {
+				seat = i
+				break
+			}
+		}
+		if seat == -1 {
+			return 0, ErrTableFull
+		}
+	}
+	if seat < 0 || seat >= len(t.seats) {
+		return 0, fmt.Errorf("seat %d out of range", seat)
+	}
+	if t.seats[seat].Player != nil {
+		return 0, fmt.Errorf("seat %d is taken by %s", seat, t.seats[seat].Name)
+	}
+	t.seats[seat] = &game.Seat{Name: name, Stack: stack, Player: p}
+	if !contains(t.names, name) {
+		t.names = append(t.names, name)
+	}
+	return seat, nil
+}
+
+// Leave removes a player between hands and returns the chips they take with them.
+func (t *Table) Leave(name string) (int, error) {
+	i := t.Seat(name)
+	if i < 0 {
+		return 0, fmt.Errorf("%s is not seated", name)
+	}
+	chips := t.seats[i].Stack
+	t.seats[i] = &game.Seat{}
+	return chips, nil
+}
+
+// Seat returns the seat number of the named player, or -1.
+func (t *Table) Seat(name string) int {
+	for i, s := range t.seats {
+		if s.Player != nil && s.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Seats returns the table's seats, empty ones included. Stacks may be read between
+// hands; use Sit and Leave to change who is playing.
+func (t *Table) Seats() []*game.Seat { return t.seats }
+
+// Active is the number of seated players with chips.
+func (t *Table) Active() int {
+	n := 0
+	for _, s := range t.seats {
+		if s.Player != nil && s.Stack > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Button is the dealer seat of the last hand played, or -1 before the first.
+func (t *Table) Button() int { return t.button }
+
+// Hands is the number of hands played so far.
+func (t *Table) Hands() int { return t.hands }
+
+// History returns one snapshot per hand played.
+func (t *Table) History() []Snapshot { return t.history }
+
+// Busts lists players who went broke, in the order they busted.
+func (t *Table) Busts() []Bust { return t.busts }
+
+// SetConfig changes the forced bets from the next hand on, e.g. when blinds go up.
+func (t *Table) SetConfig(cfg game.Config) { t.cfg = cfg }
+
+// PlayHand moves the button, shuffles a fresh deck and plays one hand. If a player
+// returns an error the hand is abandoned with stacks restored and the button stays put.
+func (t *Table) PlayHand() (*game.Result, error) {
+	if t.Active() < 2 {
+		return nil, game.ErrNotEnoughPlayers
+	}
+	button := t.nextButton()
+	start := make([]int, len(t.seats))
+	for i, s := range t.seats {
+		start[i] = s.Stack
+	}
+
+	d := deck.NewDeck()
+	d.ShuffleWith(t.rng)
+	res, err := game.Play(d, t.seats, button, t.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("hand %d: %w", t.hands+1, err)
+	}
+	t.button = button
+	t.hands++
+
+	snap := Snapshot{Hand: t.hands, Button: t.seats[button].Name, Stacks: map[string]int{}}
+	for i, s := range t.seats {
+		if s.Player == nil {
+			continue
+		}
+		snap.Stacks[s.Name] = s.Stack
+		if s.Stack == 0 && start[i] > 0 {
+			t.busts = append(t.busts, Bust{Name: s.Name, Hand: t.hands, Start: start[i]})
+			t.seats[i] = &game.Seat{}
+		}
+	}
+	t.history = append(t.history, snap)
+	return res, nil
+}
+
+// Run plays up to n hands (n <= 0 means no limit), stopping early once fewer than two
+// players have chips. It returns the number of hands played.
+func (t *Table) Run(n int) (int, error) {
+	played := 0
+	for n <= 0 || played < n {
+		if t.Active() < 2 {
+			break
+		}
+		if _, err := t.PlayHand(); err != nil {
+			return played, err
+		}
+		played++
+	}
+	return played, nil
+}
+
+// nextButton picks a random dealer for the first hand and otherwise the next seat
+// with chips after the last one.
+func (t *Table) nextButton() int {
+	if t.button < 0 {
+		var live []int
+		for i, s := range t.seats {
+			if s.Player != nil && s.Stack > 0 {
+				live = append(live, i)
+			}
+		}
+		return live[t.rng.Intn(len(live))]
+	}
+	for k := 1; k <= len(t.seats); k++ {
+		i := (t.button + k) % len(t.seats)
+		if t.seats[i].Player != nil && t.seats[i].Stack > 0 {
+			return i
+		}
+	}
+	return t.button
+}
+
+// WriteHistory prints the stack evolution as a table: one row per hand, one column per
+// player; "-" marks a player who was not seated after that hand.
+func (t *Table) WriteHistory(w io.Writer) error {
+	width := 6
+	for _, name := range t.names {
+		width = max(width, len(name))
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%5s  %-*s", "Hand", width, "Button")
+	for _, name := range t.names {
+		fmt.Fprintf(&b, "  %*s", width, name)
+	}
+	b.WriteString("\n")
+	for _, s := range t.history {
+		fmt.Fprintf(&b, "%5d  %-*s", s.Hand, width, s.Button)
+		for _, name := range t.names {
+			if stack, ok := s.Stacks[name]; ok {
+				fmt.Fprintf(&b, "  %*d", width, stack)
+			} else {
+				fmt.Fprintf(&b, "  %*s", width, "-")
+			}
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package table
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/game"
+)
+
+// caller calls every bet and stands pat; pusher moves all-in every time it can.
+type caller struct{}
+
+func (caller) Act(game.View) (game.Action, error) { return game.Action{Kind: game.Call}, nil }
+func (caller) Discard(game.View) ([]int, error)   { return nil, nil }
+
+type pusher struct{}
+
+func (pusher) Act(v game.View) (game.Action, error) {
+	return game.Action{Kind: game.Raise, Amount: v.Bets[v.Seat] + v.Stack}, nil
+}
+func (pusher) Discard(game.View) ([]int, error) { return nil, nil }
+
+type failing struct{ err error }
+
+func (f failing) Act(game.View) (game.Action, error) { return game.Action{}, f.err }
+func (f failing) Discard(game.View) ([]int, error)   { return nil, f.err }
+
+var blinds = game.Config{SmallBlind: 1, BigBlind: 2}
+
+func TestSit(t *testing.T) {
+	tb := New(blinds, rand.New(rand.NewSource(1)))
+	seat, err := tb.Sit(-1, "A", 100, caller{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, seat)
+	seat, err = tb.Sit(5, "B", 100, caller{})
+	require.NoError(t, err)
+	assert.Equal(t, 5, seat)
+	assert.Equal(t, 5, tb.Seat("B"))
+	assert.Equal(t, -1, tb.Seat("Z"))
+
+	_, err = tb.Sit(-1, "A", 100, caller{})
+	assert.Error(t, err, "duplicate name")
+	_, err = tb.Sit(5, "C", 100, caller{})
+	assert.Error(t, err, "seat taken")
+	_, err = tb.Sit(9, "C", 100, caller{})
+	assert.Error(t, err, "no such seat")
+	_, err = tb.Sit(-1, "C", 0, caller{})
+	assert.Error(t, err, "no chips")
+
+	for _, name := range []string{"C", "D", "E", "F", "G", "H"} {
+		_, err = tb.Sit(-1, name, 100, caller{})
+		require.NoError(t, err)
+	}
+	_, err = tb.Sit(-1, "I", 100, caller{})
+	assert.ErrorIs(t, err, ErrTableFull)
+
+	chips, err := tb.Leave("B")
+	require.NoError(t, err)
+	assert.Equal(t, 100, chips)
+	_, err = tb.Leave("B")
+	assert.Error(t, err)
+	_, err = tb.Sit(-1, "I", 100, caller{})
+	assert.NoError(t, err, "a seat opened up")
+}
+
+func TestButtonRotatesOverOccupiedSeats(t *testing.T) {
+	tb := New(blinds, rand.New(rand.NewSource(1)))
+	for _, seat := range []int{1, 4, 6} {
+		_, err := tb.Sit(seat, string(rune('A'+seat)), 1000, caller{})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, -1, tb.Button())
+
+	var buttons []int
+	for i := 0; i < 6; i++ {
+		_, err := tb.PlayHand()
+		require.NoError(t, err)
+		buttons = append(buttons, tb.Button())
+	}
+	next := map[int]int{1: 4, 4: 6, 6: 1}
+	for i := 1; i < len(buttons); i++ {
+		assert.Equal(t, next[buttons[i-1]], buttons[i])
+	}
+	assert.Equal(t, 6, tb.Hands())
+	assert.Len(t, tb.History(), 6)
+}
+
+func TestRunUntilOnePlayerLeft(t *testing.T) {
+	tb := New(game.Config{Ante: 1, SmallBlind: 1, BigBlind: 2}, rand.New(rand.NewSource(7)))
+	for _, name := range []string{"A", "B", "C", "D"} {
+		_, err := tb.Sit(-1, name, 50, pusher{})
+		require.NoError(t, err)
+	}
+	played, err := tb.Run(0)
+	require.NoError(t, err)
+	assert.Equal(t, tb.Hands(), played)
+	assert.Equal(t, 1, tb.Active())
+	require.Len(t, tb.Busts(), 3)
+
+	for _, snap := range tb.History() {
+		total := 0
+		for _, s := range snap.Stacks {
+			total += s
+		}
+		assert.Equal(t, 200, total, "hand %d conserves chips", snap.Hand)
+	}
+	for _, b := range tb.Busts() {
+		assert.Equal(t, -1, tb.Seat(b.Name), "busted players leave their seat")
+	}
+
+	_, err = tb.PlayHand()
+	assert.ErrorIs(t, err, game.ErrNotEnoughPlayers)
+}
+
+func TestSeededSessionsRepeat(t *testing.T) {
+	run := func() []Snapshot {
+		tb := New(blinds, rand.New(rand.NewSource(42)))
+		for _, name := range []string{"A", "B", "C"} {
+			_, err := tb.Sit(-1, name, 100, pusher{})
+			require.NoError(t, err)
+		}
+		_, err := tb.Run(20)
+		require.NoError(t, err)
+		return tb.History()
+	}
+	assert.Equal(t, run(), run())
+}
+
+func TestJoinBetweenHands(t *testing.T) {
+	tb := New(blinds, rand.New(rand.NewSource(3)))
+	_, err := tb.Sit(-1, "A", 100, caller{})
+	require.NoError(t, err)
+	_, err = tb.Sit(-1, "B", 100, caller{})
+	require.NoError(t, err)
+	_, err = tb.PlayHand()
+	require.NoError(t, err)
+	assert.NotContains(t, tb.History()[0].Stacks, "C")
+
+	_, err = tb.Sit(-1, "C", 100, caller{})
+	require.NoError(t, err)
+	res, err := tb.PlayHand()
+	require.NoError(t, err)
+	assert.NotNil(t, res.Hands[2], "the new player is dealt in")
+	assert.Contains(t, tb.History()[1].Stacks, "C")
+
+	var buf bytes.Buffer
+	require.NoError(t, tb.WriteHistory(&buf))
+	assert.Regexp(t, `Hand\s+Button\s+A\s+B\s+C\n\s+1\s+\w\s+\d+\s+\d+\s+-\n\s+2 `, buf.String())
+}
+
+func TestPlayerErrorAbandonsHand(t *testing.T) {
+	boom := errors.New("boom")
+	tb := New(blinds, rand.New(rand.NewSource(1)))
+	_, err := tb.Sit(-1, "A", 100, failing{boom})
+	require.NoError(t, err)
+	_, err = tb.Sit(-1, "B", 100, failing{boom})
+	require.NoError(t, err)
+
+	_, err = tb.PlayHand()
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, 0, tb.Hands())
+	assert.Equal(t, -1, tb.Button())
+	assert.Equal(t, 100, tb.Seats()[0].Stack)
+	assert.Equal(t, 100, tb.Seats()[1].Stack)
+}
@@ -0,0 +1,60 @@
+package tournament
+
+import (
+	"fmt"
+	"math"
+)
+
+// DefaultPayouts returns the share of the prize pool for each paid place, roughly
+// paying the top 15% of the field.
+func DefaultPayouts(entrants int) []float64 {
+	switch {
+	case entrants <= 1:
+		return []float64{1}
+	case entrants <= 6:
+		return []float64{0.65, 0.35}
+	case entrants <= 10:
+		return []float64{0.50, 0.30, 0.20}
+	case entrants <= 20:
+		return []float64{0.40, 0.25, 0.16, 0.11, 0.08}
+	case entrants <= 40:
+		return []float64{0.32, 0.20, 0.14, 0.10, 0.08, 0.06, 0.05, 0.05}
+	default:
+		return []float64{0.29, 0.17, 0.12, 0.09, 0.075, 0.06, 0.05, 0.045, 0.04, 0.035, 0.025}
+	}
+}
+
+func validatePayouts(shares []float64, entrants int) error {
+	if len(shares) == 0 {
+		return fmt.Errorf("payouts are empty")
+	}
+	if len(shares) > entrants {
+		return fmt.Errorf("%d places paid but only %d entrants", len(shares), entrants)
+	}
+	sum := 0.0
+	for i, p := range shares {
+		if p < 0 {
+			return fmt.Errorf("payout for place %d is negative", i+1)
+		}
+		sum += p
+	}
+	if math.Abs(sum-1) > 1e-6 {
+		return fmt.Errorf("payouts sum to %.4f, not 1", sum)
+	}
+	return nil
+}
+
+// prizes splits the pool by shares, rounding down; the chips lost to rounding go to
+// first place so the whole pool is paid out.
+func prizes(pool int, shares []float64) []int {
+	out := make([]int, len(shares))
+	paid := 0
+	for i, p := range shares {
+		out[i] = int(float64(pool) * p)
+		paid += out[i]
+	}
+	if len(out) > 0 {
+		out[0] += pool - paid
+	}
+	return out
+}
@@ -0,0 +1,29 @@
+package tournament
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultPayouts(t *testing.T) {
+	for _, n := range []int{1, 2, 6, 9, 18, 27, 45, 100} {
+		shares := DefaultPayouts(n)
+		assert.NoError(t, validatePayouts(shares, n), "%d entrants", n)
+		for i := 1; i < len(shares); i++ {
+			assert.LessOrEqual(t, shares[i], shares[i-1], "%d entrants: place %d", n, i+1)
+		}
+	}
+}
+
+func TestValidatePayouts(t *testing.T) {
+	assert.Error(t, validatePayouts(nil, 5))
+	assert.Error(t, validatePayouts([]float64{0.5, 0.3, 0.2}, 2), "more places than players")
+	assert.Error(t, validatePayouts([]float64{0.5, 0.3}, 5), "does not sum to 1")
+	assert.Error(t, validatePayouts([]float64{1.2, -0.2}, 5))
+}
+
+func TestPrizes(t *testing.T) {
+	assert.Equal(t, []int{501, 300, 200}, prizes(1001, []float64{0.5, 0.3, 0.2}))
+	assert.Equal(t, []int{0, 0}, prizes(0, []float64{0.65, 0.35}))
+}
@@ -0,0 +1,93 @@
+package tournament
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dangogh/GoPoker/game"
+)
+
+// Level is one step of the blind structure. A level lasts either a number of hands
+// (counted per table) or a span of simulated time; exactly one of the two is set.
+// The last level never ends.
+type Level struct {
+	Ante       int           `json:"ante"`
+	SmallBlind int           `json:"small_blind"`
+	BigBlind   int           `json:"big_blind"`
+	Hands      int           `json:"hands,omitempty"`
+	Duration   time.Duration `json:"duration,omitempty"`
+}
+
+func (l Level) String() string {
+	s := fmt.Sprintf("%d/%d", l.SmallBlind, l.BigBlind)
+	if l.Ante > 0 {
+		s += fmt.Sprintf(" ante %d", l.Ante)
+	}
+	return s
+}
+
+func (l Level) config() game.Config {
+	return game.Config{Ante: l.Ante, SmallBlind: l.SmallBlind, BigBlind: l.BigBlind}
+}
+
+// Schedule is the blind structure. Tables play in lockstep, so every round of hands
+// advances the simulated clock by HandTime.
+type Schedule struct {
+	Levels   []Level       `json:"levels"`
+	HandTime time.Duration `json:"hand_time"`
+}
+
+// DefaultHandTime is a typical live pace of about 30 hands an hour.
+const DefaultHandTime = 2 * time.Minute
+
+func (s Schedule) validate() error {
+	if len(s.Levels) == 0 {
+		return fmt.Errorf("schedule has no levels")
+	}
+	for i, l := range s.Levels {
+		if l.BigBlind <= 0 && l.Ante <= 0 {
+			return fmt.Errorf("level %d: a big blind or an ante is required", i+1)
+		}
+		if l.SmallBlind < 0 || l.BigBlind < 0 || l.Ante < 0 {
+			return fmt.Errorf("level %d: forced bets cannot be negative", i+1)
+		}
+		if i < len(s.Levels)-1 && (l.Hands > 0) == (l.Duration > 0) {
+			return fmt.Errorf("level %d: set exactly one of hands or duration", i+1)
+		}
+	}
+	return nil
+}
+
+// At returns the index of the level in force after the given number of rounds. A timed
+// level lasts as many rounds as it takes the clock to reach its duration.
+func (s Schedule) At(rounds int) int {
+	handTime := s.HandTime
+	if handTime <= 0 {
+		handTime = DefaultHandTime
+	}
+	for i, l := range s.Levels[:len(s.Levels)-1] {
+		length := l.Hands
+		if l.Duration > 0 {
+			length = int((l.Duration + handTime - 1) / handTime)
+		}
+		if rounds < length {
+			return i
+		}
+		rounds -= length
+	}
+	return len(s.Levels) - 1
+}
+
+// Doubling builds a schedule whose blinds double every `hands` hands, starting at
+// small/big, with an ante of one small blind from the fourth level on.
+func Doubling(small, big, hands, levels int) Schedule {
+	s := Schedule{}
+	for i := 0; i < levels; i++ {
+		l := Level{SmallBlind: small << i, BigBlind: big << i, Hands: hands}
+		if i >= 3 {
+			l.Ante = l.SmallBlind
+		}
+		s.Levels = append(s.Levels, l)
+	}
+	return s
+}
@@ -0,0 +1,49 @@
+package tournament
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleAt(t *testing.T) {
+	s := Schedule{
+		HandTime: time.Minute,
+		Levels: []Level{
+			{SmallBlind: 1, BigBlind: 2, Hands: 10},
+			{SmallBlind: 2, BigBlind: 4, Duration: 15 * time.Minute},
+			{SmallBlind: 5, BigBlind: 10, Duration: 90 * time.Second}, // rounds up to 2 hands
+			{SmallBlind: 10, BigBlind: 20},
+		},
+	}
+	tests := []struct{ rounds, want int }{
+		{0, 0}, {9, 0}, {10, 1}, {24, 1}, {25, 2}, {26, 2}, {27, 3}, {1000, 3},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, s.At(tc.rounds), "after %d rounds", tc.rounds)
+	}
+
+	// the default pace is two minutes a hand
+	s.HandTime = 0
+	assert.Equal(t, 1, s.At(17))
+	assert.Equal(t, 2, s.At(18))
+}
+
+func TestScheduleValidate(t *testing.T) {
+	assert.Error(t, Schedule{}.validate())
+	assert.Error(t, Schedule{Levels: []Level{{BigBlind: 2}, {BigBlind: 4}}}.validate(), "first level never ends")
+	assert.Error(t, Schedule{Levels: []Level{{BigBlind: 2, Hands: 5, Duration: time.Minute}, {BigBlind: 4}}}.validate())
+	assert.Error(t, Schedule{Levels: []Level{{SmallBlind: 1}}}.validate(), "no big blind or ante")
+	assert.NoError(t, Schedule{Levels: []Level{{Ante: 1}}}.validate())
+	assert.NoError(t, Doubling(1, 2, 10, 6).validate())
+}
+
+func TestDoubling(t *testing.T) {
+	s := Doubling(5, 10, 8, 5)
+	assert.Len(t, s.Levels, 5)
+	assert.Equal(t, Level{SmallBlind: 20, BigBlind: 40, Hands: 8}, s.Levels[2])
+	assert.Equal(t, Level{Ante: 40, SmallBlind: 40, BigBlind: 80, Hands: 8}, s.Levels[3])
+	assert.Equal(t, "40/80 ante 40", s.Levels[3].String())
+	assert.Equal(t, "5/10", s.Levels[0].String())
+}
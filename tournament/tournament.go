@@ -0,0 +1,313 @@
+// Package tournament runs freezeout tournaments on top of the table and game packages:
+// blind levels by hand count or simulated time, several tables that are balanced and
+// broken as players bust, finishing order and payouts.
+package tournament
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/dangogh/GoPoker/game"
+	"github.com/dangogh/GoPoker/table"
+)
+
+// DefaultMaxRounds stops tournaments between players who never put their chips at risk.
+const DefaultMaxRounds = 10000
+
+// ErrTooLong is returned when MaxRounds pass without a winner.
+var ErrTooLong = errors.New("tournament did not finish")
+
+// Entrant is a registered player.
+type Entrant struct {
+	Name   string
+	Player game.Player
+}
+
+// Config describes a tournament.
+type Config struct {
+	Entrants      []Entrant
+	StartingStack int
+	// TableSize is the number of seats per table, at most game.MaxSeats (the default).
+	TableSize int
+	Schedule  Schedule
+	BuyIn     int
+	// Payouts are the prize pool shares by place; nil uses DefaultPayouts.
+	Payouts   []float64
+	Seed      int64 // 0 picks a time-based seed
+	MaxRounds int   // 0 means DefaultMaxRounds
+	// Observer, if set, receives every event from every table.
+	Observer func(table int, e game.Event)
+}
+
+// Standing is a player's finish.
+type Standing struct {
+	Place int    `json:"place"`
+	Name  string `json:"name"`
+	Prize int    `json:"prize"`
+	// Round is the round the player busted in; the winner's is the final round.
+	Round int `json:"round"`
+}
+
+// Result is the outcome of a finished tournament.
+type Result struct {
+	Standings []Standing    `json:"standings"` // first place first
+	Rounds    int           `json:"rounds"`
+	Level     int           `json:"level"` // index of the final blind level
+	Elapsed   time.Duration `json:"elapsed"`
+	PrizePool int           `json:"prize_pool"`
+}
+
+// Tournament is a tournament in progress.
+type Tournament struct {
+	cfg     Config
+	rng     *rand.Rand
+	tables  []*table.Table
+	ids     []int // stable table numbers for observers
+	players map[string]game.Player
+	busts   []int // per table: busts already recorded
+	out     []Standing
+	rounds  int
+	level   int
+}
+
+// New validates the config and seats the entrants at random, spread evenly over as
+// few tables as hold them.
+func New(cfg Config) (*Tournament, error) {
+	if cfg.TableSize == 0 {
+		cfg.TableSize = game.MaxSeats
+	}
+	if cfg.TableSize < 2 || cfg.TableSize > game.MaxSeats {
+		return nil, fmt.Errorf("table size must be between 2 and %d", game.MaxSeats)
+	}
+	if len(cfg.Entrants) < 2 {
+		return nil, game.ErrNotEnoughPlayers
+	}
+	if cfg.StartingStack <= 0 {
+		return nil, fmt.Errorf("starting stack must be > 0")
+	}
+	if cfg.BuyIn < 0 {
+		return nil, fmt.Errorf("buy-in cannot be negative")
+	}
+	if err := cfg.Schedule.validate(); err != nil {
+		return nil, err
+	}
+	if cfg.Payouts == nil {
+		cfg.Payouts = DefaultPayouts(len(cfg.Entrants))
+	}
+	if err := validatePayouts(cfg.Payouts, len(cfg.Entrants)); err != nil {
+		return nil, err
+	}
+	if cfg.MaxRounds <= 0 {
+		cfg.MaxRounds = DefaultMaxRounds
+	}
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+
+	t := &Tournament{cfg: cfg, rng: rand.New(rand.NewSource(cfg.Seed)), players: map[string]game.Player{}}
+	for _, e := range cfg.Entrants {
+		if e.Name == "" || e.Player == nil {
+			return nil, fmt.Errorf("every entrant needs a name and a Player")
+		}
+		if _, dup := t.players[e.Name]; dup {
+			return nil, fmt.Errorf("duplicate entrant %q", e.Name)
+		}
+		t.players[e.Name] = e.Player
+	}
+
+	n := (len(cfg.Entrants) + cfg.TableSize - 1) / cfg.TableSize
+	for i := 0; i < n; i++ {
+		t.addTable(i)
+	}
+	order := t.rng.Perm(len(cfg.Entrants))
+	for k, idx := range order {
+		e := cfg.Entrants[idx]
+		if _, err := t.tables[k%n].Sit(-1, e.Name, cfg.StartingStack, e.Player); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+func (t *Tournament) addTable(id int) {
+	// PlayRound sets the blinds and observer before every hand
+	t.tables = append(t.tables, table.New(game.Config{}, rand.New(rand.NewSource(t.rng.Int63()))))
+	t.ids = append(t.ids, id)
+	t.busts = append(t.busts, 0)
+}
+
+// Tables returns the tables still in play.
+func (t *Tournament) Tables() []*table.Table { return t.tables }
+
+// Remaining is the number of players with chips.
+func (t *Tournament) Remaining() int {
+	n := 0
+	for _, tb := range t.tables {
+		n += tb.Active()
+	}
+	return n
+}
+
+// Level returns the blind level in force.
+func (t *Tournament) Level() Level { return t.cfg.Schedule.Levels[t.level] }
+
+// Elapsed is the simulated time played so far.
+func (t *Tournament) Elapsed() time.Duration {
+	handTime := t.cfg.Schedule.HandTime
+	if handTime <= 0 {
+		handTime = DefaultHandTime
+	}
+	return time.Duration(t.rounds) * handTime
+}
+
+// Done reports whether one player holds every chip.
+func (t *Tournament) Done() bool { return t.Remaining() < 2 }
+
+// PlayRound plays one hand at every table, then records eliminations and rebalances.
+func (t *Tournament) PlayRound() error {
+	if t.Done() {
+		return nil
+	}
+	t.level = t.cfg.Schedule.At(t.rounds)
+	for i, tb := range t.tables {
+		cfg := t.Level().config()
+		if t.cfg.Observer != nil {
+			obs, id := t.cfg.Observer, t.ids[i]
+			cfg.Observer = func(e game.Event) { obs(id, e) }
+		}
+		tb.SetConfig(cfg)
+		// a short-handed table waits for balancing to bring it players
+		if tb.Active() < 2 {
+			continue
+		}
+		if _, err := tb.PlayHand(); err != nil {
+			return fmt.Errorf("table %d: %w", t.ids[i]+1, err)
+		}
+	}
+	t.rounds++
+	t.recordBusts()
+	return t.rebalance()
+}
+
+// recordBusts assigns finishing places. Players who bust in the same round finish in
+// order of the stacks they started their last hand with.
+func (t *Tournament) recordBusts() {
+	var fresh []table.Bust
+	for i, tb := range t.tables {
+		all := tb.Busts()
+		fresh = append(fresh, all[t.busts[i]:]...)
+		t.busts[i] = len(all)
+	}
+	sort.SliceStable(fresh, func(i, j int) bool { return fresh[i].Start > fresh[j].Start })
+	place := t.Remaining() + 1
+	for _, b := range fresh {
+		t.out = append(t.out, Standing{Place: place, Name: b.Name, Round: t.rounds})
+		place++
+	}
+}
+
+// rebalance breaks a table whenever the others have room for its players, then moves
+// players from the fullest table to the emptiest until they differ by at most one.
+func (t *Tournament) rebalance() error {
+	for len(t.tables) > 1 && t.Remaining() <= (len(t.tables)-1)*t.cfg.TableSize {
+		smallest := 0
+		for i, tb := range t.tables {
+			if tb.Active() < t.tables[smallest].Active() {
+				smallest = i
+			}
+		}
+		broken := t.tables[smallest]
+		t.tables = append(t.tables[:smallest], t.tables[smallest+1:]...)
+		t.ids = append(t.ids[:smallest], t.ids[smallest+1:]...)
+		t.busts = append(t.busts[:smallest], t.busts[smallest+1:]...)
+		for _, s := range broken.Seats() {
+			if s.Player == nil || s.Stack == 0 {
+				continue
+			}
+			dst := 0
+			for i, tb := range t.tables {
+				if tb.Active() < t.tables[dst].Active() {
+					dst = i
+				}
+			}
+			if _, err := t.tables[dst].Sit(-1, s.Name, s.Stack, s.Player); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		big, small := 0, 0
+		for i, tb := range t.tables {
+			if tb.Active() > t.tables[big].Active() {
+				big = i
+			}
+			if tb.Active() < t.tables[small].Active() {
+				small = i
+			}
+		}
+		if t.tables[big].Active()-t.tables[small].Active() <= 1 {
+			return nil
+		}
+		name := nextBigBlind(t.tables[big])
+		chips, err := t.tables[big].Leave(name)
+		if err != nil {
+			return err
+		}
+		if _, err := t.tables[small].Sit(-1, name, chips, t.players[name]); err != nil {
+			return err
+		}
+	}
+}
+
+// nextBigBlind picks the player who would post the big blind next hand, the usual
+// choice when a table has to give up a player: it costs them the least.
+func nextBigBlind(tb *table.Table) string {
+	seats := tb.Seats()
+	var live []int
+	for k := 1; k <= len(seats); k++ {
+		i := (max(tb.Button(), 0) + k) % len(seats)
+		if seats[i].Player != nil && seats[i].Stack > 0 {
+			live = append(live, i)
+		}
+	}
+	// the button moves one seat before the hand, then the blinds follow it
+	return seats[live[min(2, len(live)-1)]].Name
+}
+
+// Run plays until one player has every chip and returns the standings.
+func (t *Tournament) Run() (*Result, error) {
+	for !t.Done() {
+		if t.rounds >= t.cfg.MaxRounds {
+			return nil, fmt.Errorf("%w after %d rounds", ErrTooLong, t.rounds)
+		}
+		if err := t.PlayRound(); err != nil {
+			return nil, err
+		}
+	}
+	return t.result(), nil
+}
+
+func (t *Tournament) result() *Result {
+	pool := t.cfg.BuyIn * len(t.cfg.Entrants)
+	r := &Result{Rounds: t.rounds, Level: t.level, Elapsed: t.Elapsed(), PrizePool: pool}
+	for _, tb := range t.tables {
+		for _, s := range tb.Seats() {
+			if s.Player != nil && s.Stack > 0 {
+				r.Standings = append(r.Standings, Standing{Place: 1, Name: s.Name, Round: t.rounds})
+			}
+		}
+	}
+	r.Standings = append(r.Standings, t.out...)
+	sort.SliceStable(r.Standings, func(i, j int) bool { return r.Standings[i].Place < r.Standings[j].Place })
+	paid := prizes(pool, t.cfg.Payouts)
+	for i := range r.Standings {
+		if p := r.Standings[i].Place - 1; p < len(paid) {
+			r.Standings[i].Prize = paid[p]
+		}
+	}
+	return r
+}
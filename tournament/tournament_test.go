@@ -0,0 +1,164 @@
+package tournament
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dangogh/GoPoker/game"
+)
+
+// pusher moves all-in whenever it can, so tournaments end quickly.
+type pusher struct{}
+
+func (pusher) Act(v game.View) (game.Action, error) {
+	return game.Action{Kind: game.Raise, Amount: v.Bets[v.Seat] + v.Stack}, nil
+}
+func (pusher) Discard(game.View) ([]int, error) { return nil, nil }
+
+// checker never bets and folds to any bet.
+type checker struct{}
+
+func (checker) Act(v game.View) (game.Action, error) {
+	if v.ToCall > 0 {
+		return game.Action{Kind: game.Fold}, nil
+	}
+	return game.Action{Kind: game.Check}, nil
+}
+func (checker) Discard(game.View) ([]int, error) { return nil, nil }
+
+func entrants(n int, p game.Player) []Entrant {
+	es := make([]Entrant, n)
+	for i := range es {
+		es[i] = Entrant{Name: fmt.Sprintf("P%02d", i+1), Player: p}
+	}
+	return es
+}
+
+func TestMultiTableTournament(t *testing.T) {
+	tr, err := New(Config{
+		Entrants:      entrants(20, pusher{}),
+		StartingStack: 100,
+		TableSize:     6,
+		Schedule:      Doubling(1, 2, 5, 8),
+		BuyIn:         10,
+		Seed:          11,
+	})
+	require.NoError(t, err)
+	require.Len(t, tr.Tables(), 4)
+	for _, tb := range tr.Tables() {
+		assert.Equal(t, 5, tb.Active(), "entrants are spread evenly")
+	}
+
+	tables := len(tr.Tables())
+	for !tr.Done() {
+		require.NoError(t, tr.PlayRound())
+		lo, hi, chips := 100, 0, 0
+		for _, tb := range tr.Tables() {
+			lo, hi = min(lo, tb.Active()), max(hi, tb.Active())
+			assert.LessOrEqual(t, tb.Active(), 6)
+			for _, s := range tb.Seats() {
+				chips += s.Stack
+			}
+		}
+		assert.LessOrEqual(t, hi-lo, 1, "tables stay balanced")
+		assert.Equal(t, 2000, chips, "no chips are created or lost")
+		assert.LessOrEqual(t, len(tr.Tables()), tables, "tables only ever break")
+		tables = len(tr.Tables())
+		assert.Greater(t, tr.Remaining(), (len(tr.Tables())-1)*6, "no table could be broken")
+	}
+	require.Len(t, tr.Tables(), 1)
+
+	res, err := tr.Run()
+	require.NoError(t, err)
+	require.Len(t, res.Standings, 20)
+	assert.Equal(t, 200, res.PrizePool)
+	total := 0
+	for i, s := range res.Standings {
+		assert.Equal(t, i+1, s.Place)
+		total += s.Prize
+	}
+	assert.Equal(t, 200, total)
+	assert.Equal(t, []int{80, 50, 32, 22, 16}, []int{res.Standings[0].Prize, res.Standings[1].Prize,
+		res.Standings[2].Prize, res.Standings[3].Prize, res.Standings[4].Prize})
+	assert.Zero(t, res.Standings[5].Prize)
+	assert.Equal(t, res.Rounds, res.Standings[0].Round)
+	for i := 1; i < len(res.Standings); i++ {
+		assert.LessOrEqual(t, res.Standings[i].Round, res.Standings[i-1].Round, "later busts finish higher")
+	}
+}
+
+func TestTournamentIsReproducible(t *testing.T) {
+	run := func() *Result {
+		tr, err := New(Config{Entrants: entrants(9, pusher{}), StartingStack: 50, Schedule: Doubling(1, 2, 3, 6), Seed: 4})
+		require.NoError(t, err)
+		res, err := tr.Run()
+		require.NoError(t, err)
+		return res
+	}
+	assert.Equal(t, run(), run())
+}
+
+func TestLevelsAdvance(t *testing.T) {
+	tr, err := New(Config{Entrants: entrants(3, checker{}), StartingStack: 1000, Schedule: Doubling(1, 2, 2, 3), Seed: 1})
+	require.NoError(t, err)
+	var levels []string
+	for i := 0; i < 6; i++ {
+		require.NoError(t, tr.PlayRound())
+		levels = append(levels, tr.Level().String())
+	}
+	assert.Equal(t, []string{"1/2", "1/2", "2/4", "2/4", "4/8", "4/8"}, levels)
+}
+
+func TestTournamentTooLong(t *testing.T) {
+	tr, err := New(Config{Entrants: entrants(2, checker{}), StartingStack: 1000, Schedule: Schedule{Levels: []Level{{BigBlind: 0, Ante: 0, SmallBlind: 0}}}})
+	assert.Error(t, err, "no forced bets")
+	assert.Nil(t, tr)
+
+	// with only a small big blind, folding players take a long time to bust
+	tr, err = New(Config{Entrants: entrants(2, checker{}), StartingStack: 1000, Schedule: Schedule{Levels: []Level{{BigBlind: 1}}}, MaxRounds: 50})
+	require.NoError(t, err)
+	_, err = tr.Run()
+	assert.ErrorIs(t, err, ErrTooLong)
+}
+
+func TestConfigErrors(t *testing.T) {
+	good := Config{Entrants: entrants(4, pusher{}), StartingStack: 100, Schedule: Doubling(1, 2, 5, 3)}
+	tests := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{"one entrant", func(c *Config) { c.Entrants = c.Entrants[:1] }},
+		{"no stack", func(c *Config) { c.StartingStack = 0 }},
+		{"table too big", func(c *Config) { c.TableSize = 9 }},
+		{"table too small", func(c *Config) { c.TableSize = 1 }},
+		{"bad payouts", func(c *Config) { c.Payouts = []float64{0.5} }},
+		{"negative buy-in", func(c *Config) { c.BuyIn = -1 }},
+		{"duplicate name", func(c *Config) { c.Entrants = append(c.Entrants, c.Entrants[0]) }},
+		{"no player", func(c *Config) { c.Entrants = append(c.Entrants, Entrant{Name: "X"}) }},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := good
+			cfg.Entrants = append([]Entrant(nil), good.Entrants...)
+			tc.modify(&cfg)
+			_, err := New(cfg)
+			assert.Error(t, err)
+		})
+	}
+	_, err := New(good)
+	assert.NoError(t, err)
+}
+
+func TestObserverSeesTables(t *testing.T) {
+	seen := map[int]bool{}
+	tr, err := New(Config{
+		Entrants: entrants(10, pusher{}), StartingStack: 100, TableSize: 5, Schedule: Doubling(1, 2, 5, 3), Seed: 2,
+		Observer: func(table int, e game.Event) { seen[table] = true },
+	})
+	require.NoError(t, err)
+	require.NoError(t, tr.PlayRound())
+	assert.Equal(t, map[int]bool{0: true, 1: true}, seen)
+}